@@ -8,14 +8,18 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/google/subcommands"
 	"github.com/gookit/color"
 	"github.com/iancoleman/strcase"
 
+	"github.com/swipe-io/swipe/pkg/apidiff"
 	"github.com/swipe-io/swipe/pkg/astloader"
 	"github.com/swipe-io/swipe/pkg/gen"
+	"github.com/swipe-io/swipe/pkg/git"
+	"github.com/swipe-io/swipe/pkg/openapiimport"
 	"github.com/swipe-io/swipe/pkg/stcreator"
 
 	"golang.org/x/mod/modfile"
@@ -36,6 +40,8 @@ func main() {
 	subcommands.Register(&versionCmd{}, "")
 	subcommands.Register(&genCmd{}, "")
 	subcommands.Register(&crudServiceCmd{}, "")
+	subcommands.Register(&importOpenapiCmd{}, "")
+	subcommands.Register(&apiDiffCmd{}, "")
 
 	flag.Parse()
 
@@ -44,13 +50,15 @@ func main() {
 	log.SetOutput(os.Stderr)
 
 	allCmds := map[string]bool{
-		"commands":     true,
-		"crud-service": true,
-		"version":      true,
-		"help":         true,
-		"flags":        true,
-		"gen":          true,
-		"show":         true,
+		"commands":       true,
+		"crud-service":   true,
+		"import-openapi": true,
+		"api-diff":       true,
+		"version":        true,
+		"help":           true,
+		"flags":          true,
+		"gen":            true,
+		"show":           true,
 	}
 	if args := flag.Args(); len(args) == 0 || !allCmds[args[0]] {
 		genCmd := &genCmd{}
@@ -163,7 +171,10 @@ func (cmd *genCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interfa
 		if len(g.Content) == 0 {
 			continue
 		}
-		err := ioutil.WriteFile(g.OutputPath, g.Content, 0755)
+		err := os.MkdirAll(filepath.Dir(g.OutputPath), 0755)
+		if err == nil {
+			err = ioutil.WriteFile(g.OutputPath, g.Content, 0755)
+		}
 		if err == nil {
 			log.Printf("%s: wrote %s\n", colorSuccess(g.PkgPath), colorAccent(g.OutputPath))
 		} else {
@@ -241,6 +252,202 @@ func (cmd *crudServiceCmd) Execute(ctx context.Context, f *flag.FlagSet, args ..
 	return subcommands.ExitSuccess
 }
 
+type importOpenapiCmd struct {
+	pkg   string
+	iface string
+}
+
+func (cmd *importOpenapiCmd) Name() string { return "import-openapi" }
+
+func (cmd *importOpenapiCmd) Synopsis() string {
+	return "generate the service interface, models and swipe.go from the OpenAPI document"
+}
+
+func (cmd *importOpenapiCmd) Usage() string {
+	return `swipe import-openapi [-pkg] [-name] spec.(json|yaml)
+  Given the OpenAPI 3 document, import-openapi creates the service.go, model.go and swipe.go files
+  in the package directory, the REST transport of the swipe.go exports the equivalent document.
+`
+}
+
+func (cmd *importOpenapiCmd) SetFlags(set *flag.FlagSet) {
+	set.StringVar(&cmd.pkg, "pkg", ".", "package directory")
+	set.StringVar(&cmd.iface, "name", "Service", "service interface name")
+}
+
+func (cmd *importOpenapiCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	specPath := f.Arg(0)
+	if specPath == "" {
+		log.Println(colorFail("OpenAPI document path required"))
+		return subcommands.ExitFailure
+	}
+	data, err := ioutil.ReadFile(specPath)
+	if err != nil {
+		log.Println(colorFail("failed read OpenAPI document: "), colorFail(err))
+		return subcommands.ExitFailure
+	}
+	pkgPath, err := filepath.Abs(cmd.pkg)
+	if err != nil {
+		log.Println(colorFail(err.Error()))
+		return subcommands.ExitFailure
+	}
+	format := "json"
+	if ext := filepath.Ext(specPath); ext == ".yaml" || ext == ".yml" {
+		format = "yaml"
+	}
+	result, err := openapiimport.Import(data, openapiimport.Config{
+		Package:   strings.ReplaceAll(strcase.ToSnake(filepath.Base(pkgPath)), "_", ""),
+		Interface: cmd.iface,
+		Format:    format,
+	})
+	if err != nil {
+		log.Println(colorFail("failed import OpenAPI document: "), colorFail(err))
+		return subcommands.ExitFailure
+	}
+	for _, warning := range result.Warnings {
+		log.Println(colorAccent(warning))
+	}
+	if err := os.MkdirAll(pkgPath, 0755); err != nil {
+		log.Println(colorFail(err.Error()))
+		return subcommands.ExitFailure
+	}
+	names := make([]string, 0, len(result.Files))
+	for name := range result.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		outputPath := filepath.Join(pkgPath, name)
+		if err := ioutil.WriteFile(outputPath, result.Files[name], 0644); err != nil {
+			log.Printf("failed to write %s: %v\n", colorAccent(outputPath), colorFail(err))
+			return subcommands.ExitFailure
+		}
+		log.Printf("%s %s\n", colorSuccess("wrote"), colorAccent(outputPath))
+	}
+	return subcommands.ExitSuccess
+}
+
+type apiDiffCmd struct {
+	version string
+	base    string
+}
+
+func (cmd *apiDiffCmd) Name() string { return "api-diff" }
+
+func (cmd *apiDiffCmd) Synopsis() string {
+	return "report the breaking changes of the services since the git tag"
+}
+
+func (cmd *apiDiffCmd) Usage() string {
+	return `swipe api-diff [-version] [-base] [base-tag] [packages]
+  Given the base tag, api-diff compares the services of the packages at the tag and in the working tree
+  and fails on the breaking changes without the major version bump.
+  The base tag is set with the -base flag or with the first argument, if it names a git commit.
+  If no base tag is given, it defaults to the nearest tag before HEAD, the tags of HEAD are skipped,
+  if no packages are listed, it defaults to "./...".
+`
+}
+
+func (cmd *apiDiffCmd) SetFlags(set *flag.FlagSet) {
+	set.StringVar(&cmd.version, "version", "", "version of the working tree, default is the tag of HEAD")
+	set.StringVar(&cmd.base, "base", "", "base git tag or commit, default is the nearest tag before HEAD")
+}
+
+func (cmd *apiDiffCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
+	g := git.NewGIT()
+
+	cmdArgs := f.Args()
+	baseTag := cmd.base
+	if baseTag == "" && len(cmdArgs) > 0 && g.IsCommit(cmdArgs[0]) {
+		baseTag, cmdArgs = cmdArgs[0], cmdArgs[1:]
+	}
+	if baseTag == "" {
+		tag, err := g.PreviousTag()
+		if err != nil || tag == "" {
+			log.Println(colorFail("base tag required, no git tags found before HEAD"))
+			return subcommands.ExitFailure
+		}
+		baseTag = tag
+	}
+	headVersion := cmd.version
+	if headVersion == "" {
+		tags, _ := g.HeadTags()
+		for _, tag := range tags {
+			headVersion = tag
+			if apidiff.IsMajorBump(baseTag, tag) {
+				break
+			}
+		}
+	}
+	pkgs := []string{"./..."}
+	if len(cmdArgs) > 0 {
+		pkgs = cmdArgs
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		log.Println(colorFail("failed to get working directory: "), colorFail(err))
+		return subcommands.ExitFailure
+	}
+	topLevel, err := g.TopLevel()
+	if err != nil || topLevel == "" {
+		log.Println(colorFail("failed to get git working tree"))
+		return subcommands.ExitFailure
+	}
+	rel, err := filepath.Rel(topLevel, wd)
+	if err != nil {
+		log.Println(colorFail(err.Error()))
+		return subcommands.ExitFailure
+	}
+	tmpDir, err := ioutil.TempDir("", "swipe-api-diff")
+	if err != nil {
+		log.Println(colorFail(err.Error()))
+		return subcommands.ExitFailure
+	}
+	defer os.RemoveAll(tmpDir)
+
+	worktree := filepath.Join(tmpDir, "base")
+	if err := g.AddWorktree(worktree, baseTag); err != nil {
+		log.Println(colorFail("failed to check out "+baseTag+": "), colorFail(err))
+		return subcommands.ExitFailure
+	}
+	defer func() {
+		_ = g.RemoveWorktree(worktree)
+	}()
+
+	base, errs := gen.NewSwipe(ctx, version, astloader.NewLoader(filepath.Join(worktree, rel), os.Environ(), pkgs)).Services()
+	if len(errs) > 0 {
+		log.Println(colorFail("failed to load the services at " + baseTag))
+		logErrors(errs)
+		return subcommands.ExitFailure
+	}
+	head, errs := gen.NewSwipe(ctx, version, astloader.NewLoader(wd, os.Environ(), pkgs)).Services()
+	if len(errs) > 0 {
+		log.Println(colorFail("failed to load the services in the working tree"))
+		logErrors(errs)
+		return subcommands.ExitFailure
+	}
+
+	var hasBreaking bool
+	for _, c := range apidiff.Compare(apidiff.Describe(base), apidiff.Describe(head)) {
+		if c.Breaking {
+			hasBreaking = true
+			log.Printf("%s %s\n", colorFail("breaking:"), c.Message)
+		} else {
+			log.Printf("%s %s\n", colorSuccess("compatible:"), c.Message)
+		}
+	}
+	if !hasBreaking {
+		return subcommands.ExitSuccess
+	}
+	if apidiff.IsMajorBump(baseTag, headVersion) {
+		log.Printf("the breaking changes are released in the major version %s\n", colorAccent(headVersion))
+		return subcommands.ExitSuccess
+	}
+	log.Println(colorFail("the breaking changes since " + baseTag + " require the major version bump"))
+	return subcommands.ExitFailure
+}
+
 func packages(f *flag.FlagSet) []string {
 	pkgs := f.Args()
 	if len(pkgs) == 0 {
@@ -27,6 +27,7 @@ func Swipe() {
 				),
 			),
 			Logging(),
+			Tracing(),
 			Instrumenting("api", "api"),
 		),
 	)
@@ -0,0 +1,13 @@
+//+build swipe
+
+package user
+
+import (
+	. "github.com/swipe-io/swipe/pkg/swipe"
+)
+
+func Swipe() {
+	Build(
+		Enum((*Status)(nil), EnumTrimPrefix("Status")),
+	)
+}
@@ -61,6 +61,14 @@ type User struct {
 	LastSeen  time.Time  `json:"last_seen"`
 	Photo     []byte     `json:"photo"`
 	Profile   *Profile   `json:"profile"`
+	Status    Status     `json:"status"`
 	CreatedAt *time.Time `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 }
+
+type Status int
+
+const (
+	StatusActive Status = iota + 1
+	StatusBlocked
+)
@@ -1,6 +1,6 @@
 module github.com/swipe-io/swipe
 
-go 1.13
+go 1.24.0
 
 require (
 	github.com/achiku/varfmt v0.0.0-20160708124000-f820e1efecee
@@ -10,15 +10,23 @@ require (
 	github.com/google/subcommands v1.2.0
 	github.com/gookit/color v1.2.5
 	github.com/iancoleman/strcase v0.0.0-20191112232945-16388991a334
-	github.com/kr/pretty v0.2.0 // indirect
 	github.com/lib/pq v1.8.0
 	github.com/pborman/uuid v1.2.0
 	github.com/pquerna/ffjson v0.0.0-20190930134022-aa0246cd15f7
 	github.com/spaolacci/murmur3 v1.1.0
-	github.com/stretchr/testify v1.5.1 // indirect
 	github.com/valyala/fasthttp v1.12.0
-	golang.org/x/mod v0.3.0
-	golang.org/x/tools v0.0.0-20200519015757-0d0afa43d58a
-	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
+	golang.org/x/mod v0.29.0
+	golang.org/x/tools v0.38.0
 	gopkg.in/yaml.v3 v3.0.0-20200615113413-eeeca48fe776
 )
+
+require (
+	github.com/go-logfmt/logfmt v0.5.0 // indirect
+	github.com/google/uuid v1.0.0 // indirect
+	github.com/klauspost/compress v1.10.4 // indirect
+	github.com/kr/pretty v0.2.0 // indirect
+	github.com/stretchr/testify v1.5.1 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	golang.org/x/sync v0.17.0 // indirect
+	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
+)
@@ -0,0 +1,350 @@
+package apidiff
+
+import (
+	"fmt"
+	stdtypes "go/types"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/structtag"
+
+	"github.com/swipe-io/swipe/pkg/domain/model"
+	"github.com/swipe-io/swipe/pkg/types"
+)
+
+// Field is the method parameter, the method result or the struct field encoded to JSON.
+type Field struct {
+	Name string
+	Type string
+}
+
+// Method is the service method as it is exposed by the transport.
+type Method struct {
+	Params     []Field
+	Results    []Field
+	HTTPMethod string
+	Path       string
+	QueryVars  map[string]string
+	HeaderVars map[string]string
+	Errors     map[string]int64
+}
+
+// Service is the service exposed by the transport.
+type Service struct {
+	Name    string
+	Methods map[string]Method
+}
+
+// API is the services and the struct types used by the methods, the types are compared by the name,
+// so the types are comparable between the different loads of the packages.
+type API struct {
+	Services map[string]Service
+	Types    map[string][]Field
+}
+
+// Change of the API.
+type Change struct {
+	Breaking bool
+	Message  string
+}
+
+var pathVarRegexp = regexp.MustCompile(`\{[^}:]+(:[^}]+)?\}`)
+
+// Describe returns the API of the service options.
+func Describe(services []model.ServiceOption) API {
+	api := API{
+		Services: map[string]Service{},
+		Types:    map[string][]Field{},
+	}
+	for _, o := range services {
+		s := Service{
+			Name:    fmt.Sprintf("%s (%s)", o.ID, o.Transport.Prefix),
+			Methods: map[string]Method{},
+		}
+		for _, m := range o.Methods {
+			mopt := o.Transport.MethodOptions[m.Name]
+			dm := Method{
+				QueryVars:  mopt.QueryVars,
+				HeaderVars: mopt.HeaderVars,
+				Errors:     map[string]int64{},
+			}
+			if o.Transport.JsonRPC.Enable {
+				dm.Path = m.LcName
+			} else {
+				dm.HTTPMethod = mopt.MethodName
+				if dm.HTTPMethod == "" {
+					dm.HTTPMethod = "GET"
+				}
+				// the path variable names are not sent, only the patterns are compared.
+				dm.Path = pathVarRegexp.ReplaceAllString(mopt.Path, "{$1}")
+			}
+			for _, p := range m.Params {
+				if types.IsContext(p.Type()) {
+					continue
+				}
+				dm.Params = append(dm.Params, Field{Name: p.Name(), Type: api.typeString(p.Type())})
+			}
+			for _, r := range m.Results {
+				f := Field{Type: api.typeString(r.Type())}
+				// the several results are encoded by the names, the single result is encoded as is.
+				if len(m.Results) > 1 {
+					f.Name = r.Name()
+				}
+				dm.Results = append(dm.Results, f)
+			}
+			for _, e := range m.Errors {
+				dm.Errors[e.Named.Obj().Name()] = e.Code
+			}
+			s.Methods[m.Name] = dm
+		}
+		api.Services[o.Transport.Prefix+o.ID] = s
+	}
+	return api
+}
+
+// typeString returns the type name and adds the struct types to the API.
+func (a API) typeString(t stdtypes.Type) string {
+	ts := stdtypes.TypeString(t, (*stdtypes.Package).Name)
+	a.addType(t)
+	return ts
+}
+
+func (a API) addType(t stdtypes.Type) {
+	switch v := t.(type) {
+	case *stdtypes.Pointer:
+		a.addType(v.Elem())
+	case *stdtypes.Slice:
+		a.addType(v.Elem())
+	case *stdtypes.Array:
+		a.addType(v.Elem())
+	case *stdtypes.Map:
+		a.addType(v.Key())
+		a.addType(v.Elem())
+	case *stdtypes.Chan:
+		a.addType(v.Elem())
+	case *stdtypes.Named:
+		st, ok := v.Underlying().(*stdtypes.Struct)
+		if !ok || v.Obj().Pkg() == nil {
+			return
+		}
+		name := stdtypes.TypeString(v, (*stdtypes.Package).Name)
+		if _, ok := a.Types[name]; ok {
+			return
+		}
+		// the type is added before the fields are walked, so the recursive types are added once.
+		a.Types[name] = nil
+		a.Types[name] = a.fields(st)
+	}
+}
+
+// fields returns the fields of the struct as they are encoded by encoding/json.
+func (a API) fields(st *stdtypes.Struct) (fields []Field) {
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		name := f.Name()
+		if tags, err := structtag.Parse(st.Tag(i)); err == nil {
+			if tag, err := tags.Get("json"); err == nil {
+				if tag.Name == "-" && len(tag.Options) == 0 {
+					continue
+				}
+				if tag.Name != "" {
+					name = tag.Name
+				}
+			}
+		}
+		if f.Embedded() && name == f.Name() {
+			t := f.Type()
+			if ptr, ok := t.(*stdtypes.Pointer); ok {
+				t = ptr.Elem()
+			}
+			if est, ok := t.Underlying().(*stdtypes.Struct); ok {
+				fields = append(fields, a.fields(est)...)
+				continue
+			}
+		}
+		if !f.Exported() {
+			continue
+		}
+		fields = append(fields, Field{Name: name, Type: a.typeString(f.Type())})
+	}
+	return fields
+}
+
+// Compare returns the changes of the head API from the base API.
+func Compare(base, head API) (changes []Change) {
+	breaking := func(format string, args ...interface{}) {
+		changes = append(changes, Change{Breaking: true, Message: fmt.Sprintf(format, args...)})
+	}
+	compatible := func(format string, args ...interface{}) {
+		changes = append(changes, Change{Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, id := range sortedKeys(base.Services, head.Services) {
+		bs, inBase := base.Services[id]
+		hs, inHead := head.Services[id]
+		switch {
+		case !inHead:
+			breaking("service %s removed", bs.Name)
+			continue
+		case !inBase:
+			compatible("service %s added", hs.Name)
+			continue
+		}
+		for _, name := range sortedKeys(bs.Methods, hs.Methods) {
+			bm, inBase := bs.Methods[name]
+			hm, inHead := hs.Methods[name]
+			method := hs.Name + " " + name
+			switch {
+			case !inHead:
+				breaking("%s: method removed", method)
+				continue
+			case !inBase:
+				compatible("%s: method added", method)
+				continue
+			}
+			if p, h := formatFields(bm.Params), formatFields(hm.Params); p != h {
+				breaking("%s: params changed from (%s) to (%s)", method, p, h)
+			}
+			if r, h := formatFields(bm.Results), formatFields(hm.Results); r != h {
+				breaking("%s: results changed from (%s) to (%s)", method, r, h)
+			}
+			if bm.HTTPMethod != hm.HTTPMethod || bm.Path != hm.Path {
+				breaking("%s: route changed from %s to %s", method, strings.TrimSpace(bm.HTTPMethod+" "+bm.Path), strings.TrimSpace(hm.HTTPMethod+" "+hm.Path))
+			}
+			if b, h := formatVars(bm.QueryVars), formatVars(hm.QueryVars); b != h {
+				breaking("%s: query vars changed from [%s] to [%s]", method, b, h)
+			}
+			if b, h := formatVars(bm.HeaderVars), formatVars(hm.HeaderVars); b != h {
+				breaking("%s: header vars changed from [%s] to [%s]", method, b, h)
+			}
+			for _, e := range sortedKeys(bm.Errors, hm.Errors) {
+				bc, inBase := bm.Errors[e]
+				hc, inHead := hm.Errors[e]
+				switch {
+				case !inHead:
+					compatible("%s: error %s removed", method, e)
+				case !inBase:
+					compatible("%s: error %s added with the code %d", method, e, hc)
+				case bc != hc:
+					breaking("%s: error %s code changed from %d to %d", method, e, bc, hc)
+				}
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(base.Types, head.Types) {
+		bf, inBase := base.Types[name]
+		hf, inHead := head.Types[name]
+		if !inBase || !inHead {
+			// the added and removed types are reported as the changed params and results.
+			continue
+		}
+		baseFields := fieldMap(bf)
+		headFields := fieldMap(hf)
+		for _, field := range sortedKeys(baseFields, headFields) {
+			bt, inBase := baseFields[field]
+			ht, inHead := headFields[field]
+			switch {
+			case !inHead:
+				breaking("type %s: field %s removed", name, field)
+			case !inBase:
+				compatible("type %s: field %s added", name, field)
+			case bt != ht:
+				breaking("type %s: field %s type changed from %s to %s", name, field, bt, ht)
+			}
+		}
+	}
+	return changes
+}
+
+// IsMajorBump reports whether the head version is the next major version of the base version,
+// the minor version is the major version before 1.0.0.
+func IsMajorBump(base, head string) bool {
+	b, ok := parseVersion(base)
+	if !ok {
+		return false
+	}
+	h, ok := parseVersion(head)
+	if !ok {
+		return false
+	}
+	if b[0] == 0 && h[0] == 0 {
+		return h[1] > b[1]
+	}
+	return h[0] > b[0]
+}
+
+var versionRegexp = regexp.MustCompile(`^v?([0-9]+)\.([0-9]+)\.([0-9]+)`)
+
+func parseVersion(v string) (result [3]int, ok bool) {
+	m := versionRegexp.FindStringSubmatch(v)
+	if m == nil {
+		return result, false
+	}
+	for i := range result {
+		result[i], _ = strconv.Atoi(m[i+1])
+	}
+	return result, true
+}
+
+func formatFields(fields []Field) string {
+	parts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		parts = append(parts, strings.TrimSpace(f.Name+" "+f.Type))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatVars(vars map[string]string) string {
+	parts := make([]string, 0, len(vars))
+	for k, v := range vars {
+		parts = append(parts, k+"="+v)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, " ")
+}
+
+func fieldMap(fields []Field) map[string]string {
+	m := make(map[string]string, len(fields))
+	for _, f := range fields {
+		m[f.Name] = f.Type
+	}
+	return m
+}
+
+// sortedKeys returns the sorted keys of the both maps.
+func sortedKeys(maps ...interface{}) []string {
+	keys := map[string]struct{}{}
+	for _, m := range maps {
+		switch m := m.(type) {
+		case map[string]Service:
+			for k := range m {
+				keys[k] = struct{}{}
+			}
+		case map[string]Method:
+			for k := range m {
+				keys[k] = struct{}{}
+			}
+		case map[string]int64:
+			for k := range m {
+				keys[k] = struct{}{}
+			}
+		case map[string][]Field:
+			for k := range m {
+				keys[k] = struct{}{}
+			}
+		case map[string]string:
+			for k := range m {
+				keys[k] = struct{}{}
+			}
+		}
+	}
+	result := make([]string, 0, len(keys))
+	for k := range keys {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
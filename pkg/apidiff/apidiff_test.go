@@ -0,0 +1,118 @@
+package apidiff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsMajorBump(t *testing.T) {
+	tests := []struct {
+		base, head string
+		want       bool
+	}{
+		{base: "v1.2.3", head: "v2.0.0", want: true},
+		{base: "v1.2.3", head: "v1.3.0", want: false},
+		{base: "1.2.3", head: "v2.0.0", want: true},
+		{base: "v0.1.0", head: "v0.2.0", want: true},
+		{base: "v0.1.0", head: "v0.1.5", want: false},
+		{base: "v0.9.0", head: "v1.0.0", want: true},
+		{base: "v2.0.0", head: "v1.9.0", want: false},
+		{base: "v1.0.0", head: "latest", want: false},
+		{base: "release", head: "v2.0.0", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.base+"-"+tt.head, func(t *testing.T) {
+			if got := IsMajorBump(tt.base, tt.head); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	method := func(change func(m *Method)) Method {
+		m := Method{
+			Params:     []Field{{Name: "id", Type: "int"}},
+			Results:    []Field{{Type: "*api.User"}},
+			HTTPMethod: "GET",
+			Path:       "/users/{}",
+			QueryVars:  map[string]string{"q": "query"},
+			Errors:     map[string]int64{"ErrNotFound": 404},
+		}
+		if change != nil {
+			change(&m)
+		}
+		return m
+	}
+	api := func(change func(m *Method), types map[string][]Field) API {
+		if types == nil {
+			types = map[string][]Field{"api.User": {{Name: "name", Type: "string"}}}
+		}
+		return API{
+			Services: map[string]Service{"REST": {Name: "Service (REST)", Methods: map[string]Method{"Get": method(change)}}},
+			Types:    types,
+		}
+	}
+
+	tests := []struct {
+		name string
+		head API
+		want []Change
+	}{
+		{
+			name: "same",
+			head: api(nil, nil),
+		},
+		{
+			name: "service removed",
+			head: API{Services: map[string]Service{}, Types: map[string][]Field{}},
+			want: []Change{{Breaking: true, Message: "service Service (REST) removed"}},
+		},
+		{
+			name: "method added",
+			head: API{
+				Services: map[string]Service{"REST": {Name: "Service (REST)", Methods: map[string]Method{"Get": method(nil), "List": {}}}},
+				Types:    map[string][]Field{"api.User": {{Name: "name", Type: "string"}}},
+			},
+			want: []Change{{Message: "Service (REST) List: method added"}},
+		},
+		{
+			name: "params",
+			head: api(func(m *Method) { m.Params = []Field{{Name: "id", Type: "string"}} }, nil),
+			want: []Change{{Breaking: true, Message: "Service (REST) Get: params changed from (id int) to (id string)"}},
+		},
+		{
+			name: "route",
+			head: api(func(m *Method) { m.HTTPMethod = "POST" }, nil),
+			want: []Change{{Breaking: true, Message: "Service (REST) Get: route changed from GET /users/{} to POST /users/{}"}},
+		},
+		{
+			name: "query vars",
+			head: api(func(m *Method) { m.QueryVars = map[string]string{"q": "search"} }, nil),
+			want: []Change{{Breaking: true, Message: "Service (REST) Get: query vars changed from [q=query] to [q=search]"}},
+		},
+		{
+			name: "errors",
+			head: api(func(m *Method) { m.Errors = map[string]int64{"ErrNotFound": 410, "ErrConflict": 409} }, nil),
+			want: []Change{
+				{Message: "Service (REST) Get: error ErrConflict added with the code 409"},
+				{Breaking: true, Message: "Service (REST) Get: error ErrNotFound code changed from 404 to 410"},
+			},
+		},
+		{
+			name: "fields",
+			head: api(nil, map[string][]Field{"api.User": {{Name: "name", Type: "[]string"}, {Name: "email", Type: "string"}}}),
+			want: []Change{
+				{Message: "type api.User: field email added"},
+				{Breaking: true, Message: "type api.User: field name type changed from string to []string"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Compare(api(nil, nil), tt.head); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,9 +3,9 @@ package astloader
 import (
 	"context"
 	"go/ast"
+	"go/constant"
 	"go/token"
 	stdtypes "go/types"
-	"strconv"
 	stdstrings "strings"
 
 	"github.com/swipe-io/swipe/pkg/domain/model"
@@ -88,11 +88,7 @@ func (l *Loader) Process() (data Data, errs []error) {
 							data.GraphTypes.Add(&graph.Node{Object: obj})
 						}
 					case token.CONST:
-						var (
-							iotaValue int
-							iotaIncr  int
-							enums     []model.Enum
-						)
+						var enums []model.Enum
 						if len(v.Specs) < 1 {
 							continue
 						}
@@ -108,33 +104,24 @@ func (l *Loader) Process() (data Data, errs []error) {
 							if named, ok := ti.(*stdtypes.Named); ok && !named.Obj().Exported() {
 								continue
 							}
-							if b, ok := ti.Underlying().(*stdtypes.Basic); ok {
-								switch b.Info() {
-								case stdtypes.IsUnsigned | stdtypes.IsInteger, stdtypes.IsInteger:
-									for _, spec := range v.Specs {
-										vs := spec.(*ast.ValueSpec)
-										if len(vs.Values) == 1 {
-											iotaValue, iotaIncr = types.EvalInt(vs.Values[0])
-										} else {
-											iotaValue += iotaIncr
+							if b, ok := ti.Underlying().(*stdtypes.Basic); ok && b.Info()&(stdtypes.IsInteger|stdtypes.IsString) != 0 {
+								// the values are evaluated by the type checker, so the aliases of the consts have the same values.
+								for _, spec := range v.Specs {
+									vs := spec.(*ast.ValueSpec)
+									for _, name := range vs.Names {
+										c, ok := pkg.TypesInfo.Defs[name].(*stdtypes.Const)
+										if !ok || !stdtypes.Identical(c.Type(), ti) {
+											continue
+										}
+										value := c.Val().ExactString()
+										if c.Val().Kind() == constant.String {
+											value = constant.StringVal(c.Val())
 										}
 										enums = append(enums, model.Enum{
-											Name:  vs.Names[0].Name,
-											Value: strconv.Itoa(iotaValue),
+											Name:  name.Name,
+											Value: value,
 										})
 									}
-								case stdtypes.IsString:
-									for _, spec := range v.Specs {
-										vs := spec.(*ast.ValueSpec)
-										if len(vs.Values) == 1 {
-											lit := vs.Values[0].(*ast.BasicLit)
-											s, _ := strconv.Unquote(lit.Value)
-											enums = append(enums, model.Enum{
-												Name:  vs.Names[0].Name,
-												Value: s,
-											})
-										}
-									}
 								}
 							}
 							data.Enums.Set(ti, enums)
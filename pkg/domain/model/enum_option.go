@@ -0,0 +1,27 @@
+package model
+
+import (
+	"go/types"
+	"strings"
+)
+
+type EnumOption struct {
+	Type       *types.Named
+	Enums      []Enum
+	TrimPrefix string
+	JSONValue  bool
+}
+
+// IsString reports whether the const group has the string type.
+func (o EnumOption) IsString() bool {
+	b, ok := o.Type.Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsString != 0
+}
+
+// Text returns the text form of the const.
+func (o EnumOption) Text(e Enum) string {
+	if o.IsString() {
+		return e.Value
+	}
+	return strings.TrimPrefix(e.Name, o.TrimPrefix)
+}
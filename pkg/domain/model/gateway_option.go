@@ -13,9 +13,11 @@ type GatewayServiceOption struct {
 	Type          stdtypes.Type
 	TypeName      *stdtypes.Named
 	Iface         *stdtypes.Interface
+	Prefix        string
 	MethodOptions map[string]GatewayMethodOption
 }
 
 type GatewayOption struct {
 	Services []GatewayServiceOption
+	Openapi  OpenapiHTTPTransportOption
 }
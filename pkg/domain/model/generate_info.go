@@ -8,18 +8,20 @@ import (
 )
 
 type GenerateInfo struct {
-	Pkg         *packages.Package
-	BasePkgPath string
-	RootPath    string
-	Pkgs        []*packages.Package
-	CommentMap  *typeutil.Map
-	ReturnTypes map[uint32][]interface{}
-	BasePath    string
-	Version     string
-	GraphTypes  *graph.Graph
-	MapTypes    map[uint32]*DeclType
-	Enums       *typeutil.Map
-	GitTags     []git.Tag
+	Pkg            *packages.Package
+	BasePkgPath    string
+	RootPath       string
+	Pkgs           []*packages.Package
+	CommentMap     *typeutil.Map
+	ReturnTypes    map[uint32][]interface{}
+	BasePath       string
+	Version        string
+	GraphTypes     *graph.Graph
+	MapTypes       map[uint32]*DeclType
+	Enums          *typeutil.Map
+	EnumOptions    *typeutil.Map
+	ServiceOptions *typeutil.Map
+	GitTags        []git.Tag
 }
 
 type Enum struct {
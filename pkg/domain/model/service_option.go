@@ -2,6 +2,7 @@ package model
 
 import (
 	"container/list"
+	"go/ast"
 	stdtypes "go/types"
 )
 
@@ -24,9 +25,33 @@ type DeclType struct {
 }
 
 type InstrumentingServiceOption struct {
-	Enable    bool
-	Namespace string
-	Subsystem string
+	Enable      bool
+	Namespace   string
+	Subsystem   string
+	Backend     string
+	Buckets     []ast.Expr
+	Labels      map[string]string
+	LegacyNames bool
+}
+
+type LoggingMethodOption struct {
+	Skip          map[string]struct{}
+	Redact        map[string]struct{}
+	MaxSize       int
+	SlowThreshold ast.Expr
+}
+
+type LoggingServiceOption struct {
+	Enable               bool
+	Backend              string
+	SuccessLevel         string
+	MethodOptions        map[string]LoggingMethodOption
+	DefaultMethodOptions LoggingMethodOption
+}
+
+type TracingServiceOption struct {
+	Enable bool
+	Redact map[string]struct{}
 }
 
 type ServiceReadme struct {
@@ -47,6 +72,7 @@ type ServiceMethod struct {
 	ResultsNamed bool
 	Errors       map[uint32]*ErrorHTTPTransportOption
 	T            stdtypes.Type
+	StreamElem   stdtypes.Type
 }
 
 type ServiceOption struct {
@@ -54,7 +80,8 @@ type ServiceOption struct {
 	RawID         string
 	Transport     TransportOption
 	Instrumenting InstrumentingServiceOption
-	Logging       bool
+	Logging       LoggingServiceOption
+	Tracing       TracingServiceOption
 	Methods       []ServiceMethod
 	Type          stdtypes.Type
 	TypeName      *stdtypes.Named
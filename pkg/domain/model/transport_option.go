@@ -5,6 +5,7 @@ import (
 	stdtypes "go/types"
 
 	"github.com/swipe-io/swipe/pkg/openapi"
+	"github.com/swipe-io/swipe/pkg/openrpc"
 )
 
 type ReqRespFunc struct {
@@ -23,22 +24,88 @@ type OpenapiMethodOption struct {
 type OpenapiHTTPTransportOption struct {
 	Enable        bool
 	Output        string
+	Format        string
+	Version       string
+	Split         bool
+	Serve         string
 	Servers       []openapi.Server
 	Info          openapi.Info
 	Methods       map[string]*OpenapiMethodOption
 	DefaultMethod OpenapiMethodOption
 }
 
+type OpenRPCHTTPTransportOption struct {
+	Enable   bool
+	Output   string
+	Servers  []openrpc.Server
+	Info     openrpc.Info
+	Discover bool
+}
+
 type WrapResponseHTTPTransportOption struct {
 	Enable bool
 	Name   string
 }
 
-type JsonRPCHTTPTransportOption struct {
+type WebSocketJsonRPCTransportOption struct {
 	Enable bool
 	Path   string
 }
 
+type JsonRPCHTTPTransportOption struct {
+	Enable    bool
+	Path      string
+	WebSocket WebSocketJsonRPCTransportOption
+}
+
+type AuthHTTPTransportOption struct {
+	Enable        bool
+	KeyFunc       ast.Expr
+	ClaimsFactory ast.Expr
+}
+
+type PrincipalHTTPTransportOption struct {
+	Enable    bool
+	Extractor ast.Expr
+}
+
+type RateLimitHTTPTransportOption struct {
+	Enable bool
+	RPS    ast.Expr
+	Burst  ast.Expr
+}
+
+type CircuitBreakerHTTPTransportOption struct {
+	Enable      bool
+	MaxRequests ast.Expr
+	Interval    ast.Expr
+	Timeout     ast.Expr
+	Failures    ast.Expr
+}
+
+type TimeoutHTTPTransportOption struct {
+	Enable   bool
+	Duration ast.Expr
+}
+
+type RetryHTTPTransportOption struct {
+	Enable  bool
+	Max     ast.Expr
+	Backoff ast.Expr
+}
+
+type AccessLogHTTPTransportOption struct {
+	Enable bool
+}
+
+type HTTPMetricsHTTPTransportOption struct {
+	Enable bool
+}
+
+type PanicRecoveryHTTPTransportOption struct {
+	Enable bool
+}
+
 type MethodHTTPTransportOption struct {
 	MethodName         string
 	Expr               ast.Expr
@@ -51,6 +118,15 @@ type MethodHTTPTransportOption struct {
 	ServerResponseFunc ReqRespFunc
 	ClientRequestFunc  ReqRespFunc
 	ClientResponseFunc ReqRespFunc
+	Public             bool
+	Permissions        []string
+	Roles              []string
+	RateLimit          RateLimitHTTPTransportOption
+	CircuitBreaker     CircuitBreakerHTTPTransportOption
+	Timeout            TimeoutHTTPTransportOption
+	Retry              RetryHTTPTransportOption
+	Idempotent         bool
+	Notification       bool
 }
 
 type ErrorHTTPTransportOption struct {
@@ -70,8 +146,14 @@ type TransportOption struct {
 	ServerDisabled       bool
 	Client               ClientHTTPTransportOption
 	Openapi              OpenapiHTTPTransportOption
+	OpenRPC              OpenRPCHTTPTransportOption
 	MarkdownDoc          MarkdownDocHTTPTransportOption
 	FastHTTP             bool
+	Auth                 AuthHTTPTransportOption
+	Principal            PrincipalHTTPTransportOption
+	AccessLog            AccessLogHTTPTransportOption
+	HTTPMetrics          HTTPMetricsHTTPTransportOption
+	PanicRecovery        PanicRecoveryHTTPTransportOption
 	JsonRPC              JsonRPCHTTPTransportOption
 	MethodOptions        map[string]MethodHTTPTransportOption
 	DefaultMethodOptions MethodHTTPTransportOption
@@ -6,12 +6,13 @@ import (
 	"fmt"
 	"go/ast"
 	"go/build"
-	"go/token"
 	stdtypes "go/types"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"golang.org/x/tools/go/types/typeutil"
+
 	"github.com/swipe-io/swipe/pkg/astloader"
 	"github.com/swipe-io/swipe/pkg/domain/model"
 	"github.com/swipe-io/swipe/pkg/file"
@@ -27,6 +28,18 @@ type importerer interface {
 	SetImporter(*importer.Importer)
 }
 
+// buildCall is a parsed Build call.
+type buildCall struct {
+	info   model.GenerateInfo
+	name   string
+	option interface{}
+}
+
+// filer is a generator that writes the additional files, the paths are relative to the output directory.
+type filer interface {
+	Files() map[string][]byte
+}
+
 type Result struct {
 	PkgPath    string
 	OutputPath string
@@ -41,122 +54,86 @@ type Swipe struct {
 }
 
 func (s *Swipe) Generate() ([]Result, []error) {
-	astData, errs := s.loader.Process()
+	astData, builds, errs := s.parse()
 	if len(errs) > 0 {
 		return nil, errs
 	}
 
-	g := git.NewGIT()
 	r := registry.NewRegistry()
 
-	gitTags, _ := g.GetTags()
-
 	var result []Result
 	files := make(map[string]*file.File)
-	basePaths := map[string]struct{}{}
 
-	basePkgPath := strings.Split(astData.WorkDir, filepath.Join(build.Default.GOPATH, "src")+"/")[1]
+	importerFactories := map[string]*processor.ImporterFactory{}
 
-	for _, pkg := range astData.Pkgs {
-		importerFactory := processor.NewImporterFactory(pkg)
+	for _, bc := range builds {
+		pkg := bc.info.Pkg
+		importerFactory, ok := importerFactories[pkg.PkgPath]
+		if !ok {
+			importerFactory = processor.NewImporterFactory(pkg)
+			importerFactories[pkg.PkgPath] = importerFactory
+		}
 
-		basePath, err := s.detectBasePath(pkg.GoFiles)
+		p, err := r.Processor(bc.name, bc.info)
 		if err != nil {
 			return nil, []error{err}
 		}
+		if !p.SetOption(bc.option) {
+			return nil, []error{errors.New("option not suitable for processor: " + bc.name)}
+		}
+		for _, g := range p.Generators() {
+			if err := g.Prepare(s.ctx); err != nil {
+				return nil, []error{err}
+			}
+			outputDir := g.OutputDir()
+			if outputDir == "" {
+				outputDir = bc.info.BasePath
+			}
+			filename := g.Filename()
+			if filename == "" {
+				filename = "swipe_gen.go"
+			}
 
-		basePaths[basePath] = struct{}{}
+			genFilePath := filepath.Join(outputDir, filename)
 
-		for _, f := range pkg.Syntax {
-			for _, decl := range f.Decls {
-				switch decl := decl.(type) {
-				case *ast.FuncDecl:
-					call, err := s.findInjector(pkg.TypesInfo, decl)
-					if err != nil {
-						return nil, []error{err}
-					}
-					if call != nil {
-						opt, err := parser.NewParser(pkg).Parse(call.Args[0])
-						if err != nil {
-							return nil, []error{err}
-						}
-
-						info := model.GenerateInfo{
-							Pkg:         pkg,
-							BasePkgPath: basePkgPath,
-							RootPath:    astData.WorkDir,
-							Pkgs:        astData.Pkgs,
-							BasePath:    basePath,
-							Version:     s.version,
-							CommentMap:  astData.CommentMaps,
-							GraphTypes:  astData.GraphTypes,
-							Enums:       astData.Enums,
-							GitTags:     gitTags,
-						}
-						option := r.Option(opt.Name, info)
-						if option == nil {
-							return nil, []error{errors.New("unknown option:" + opt.Name)}
-						}
-						o, err := option.Parse(opt)
-						if err != nil {
-							return nil, []error{err}
-						}
-						p, err := r.Processor(opt.Name, info)
-						if err != nil {
-							return nil, []error{err}
-						}
-						if !p.SetOption(o) {
-							return nil, []error{errors.New("option not suitable for processor: " + opt.Name)}
-						}
-						for _, g := range p.Generators() {
-							if err := g.Prepare(s.ctx); err != nil {
-								return nil, []error{err}
-							}
-							outputDir := g.OutputDir()
-							if outputDir == "" {
-								outputDir = basePath
-							}
-							filename := g.Filename()
-							if filename == "" {
-								filename = "swipe_gen.go"
-							}
-
-							genFilePath := filepath.Join(outputDir, filename)
-
-							i := importerFactory.Instance(genFilePath)
-							if is, ok := g.(importerer); ok {
-								is.SetImporter(i)
-							}
-
-							if err := g.Process(s.ctx); err != nil {
-								return nil, []error{err}
-							}
-
-							f, ok := files[genFilePath]
-							if !ok {
-								f = &file.File{
-									PkgName:   pkg.Name,
-									PkgPath:   pkg.PkgPath,
-									OutputDir: outputDir,
-									Filename:  filename,
-									Version:   s.version,
-									Importer:  i,
-								}
-								files[genFilePath] = f
-							}
-
-							b := g.Bytes()
-							if len(b) > 0 {
-								_, _ = f.Write(b)
-							}
-						}
-
-						continue
-					}
-				case *ast.GenDecl:
-					if decl.Tok == token.IMPORT {
-						continue
+			i := importerFactory.Instance(genFilePath)
+			if is, ok := g.(importerer); ok {
+				is.SetImporter(i)
+			}
+
+			if err := g.Process(s.ctx); err != nil {
+				return nil, []error{err}
+			}
+
+			f, ok := files[genFilePath]
+			if !ok {
+				f = &file.File{
+					PkgName:   pkg.Name,
+					PkgPath:   pkg.PkgPath,
+					OutputDir: outputDir,
+					Filename:  filename,
+					Version:   s.version,
+					Importer:  i,
+				}
+				files[genFilePath] = f
+			}
+
+			b := g.Bytes()
+			if len(b) > 0 {
+				_, _ = f.Write(b)
+			}
+
+			if fl, ok := g.(filer); ok {
+				for name, b := range fl.Files() {
+					filePath := filepath.Join(outputDir, name)
+					files[filePath] = &file.File{
+						PkgName:   pkg.Name,
+						PkgPath:   pkg.PkgPath,
+						OutputDir: filepath.Dir(filePath),
+						Filename:  filepath.Base(filePath),
+						Version:   s.version,
 					}
+					_, _ = files[filePath].Write(b)
 				}
 			}
 		}
@@ -186,6 +163,105 @@ func (s *Swipe) Generate() ([]Result, []error) {
 	return result, nil
 }
 
+// parse parses the Build calls of the packages, the options are parsed before the generation,
+// so the generators can use the enum and service options declared in the other packages.
+func (s *Swipe) parse() (astloader.Data, []buildCall, []error) {
+	astData, errs := s.loader.Process()
+	if len(errs) > 0 {
+		return astData, nil, errs
+	}
+
+	g := git.NewGIT()
+	r := registry.NewRegistry()
+
+	gitTags, _ := g.GetTags()
+
+	var basePkgPath string
+	if parts := strings.SplitN(astData.WorkDir, filepath.Join(build.Default.GOPATH, "src")+"/", 2); len(parts) == 2 {
+		basePkgPath = parts[1]
+	}
+
+	var builds []buildCall
+
+	enumOptions := new(typeutil.Map)
+	serviceOptions := new(typeutil.Map)
+
+	for _, pkg := range astData.Pkgs {
+		basePath, err := s.detectBasePath(pkg.GoFiles)
+		if err != nil {
+			return astData, nil, []error{err}
+		}
+
+		for _, f := range pkg.Syntax {
+			for _, decl := range f.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok {
+					continue
+				}
+				call, err := s.findInjector(pkg.TypesInfo, fn)
+				if err != nil {
+					return astData, nil, []error{err}
+				}
+				if call == nil {
+					continue
+				}
+				opt, err := parser.NewParser(pkg).Parse(call.Args[0])
+				if err != nil {
+					return astData, nil, []error{err}
+				}
+
+				info := model.GenerateInfo{
+					Pkg:            pkg,
+					BasePkgPath:    basePkgPath,
+					RootPath:       astData.WorkDir,
+					Pkgs:           astData.Pkgs,
+					BasePath:       basePath,
+					Version:        s.version,
+					CommentMap:     astData.CommentMaps,
+					GraphTypes:     astData.GraphTypes,
+					Enums:          astData.Enums,
+					EnumOptions:    enumOptions,
+					ServiceOptions: serviceOptions,
+					GitTags:        gitTags,
+				}
+				option := r.Option(opt.Name, info)
+				if option == nil {
+					return astData, nil, []error{errors.New("unknown option:" + opt.Name)}
+				}
+				o, err := option.Parse(opt)
+				if err != nil {
+					return astData, nil, []error{err}
+				}
+				switch o := o.(type) {
+				case model.EnumOption:
+					enumOptions.Set(o.Type, o)
+				case model.ServiceOption:
+					serviceOpts, _ := serviceOptions.At(o.Type).([]model.ServiceOption)
+					serviceOptions.Set(o.Type, append(serviceOpts, o))
+				}
+				builds = append(builds, buildCall{info: info, name: opt.Name, option: o})
+			}
+		}
+	}
+
+	return astData, builds, nil
+}
+
+// Services returns the Service options of the packages without the generation.
+func (s *Swipe) Services() ([]model.ServiceOption, []error) {
+	_, builds, errs := s.parse()
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	var result []model.ServiceOption
+	for _, bc := range builds {
+		if o, ok := bc.option.(model.ServiceOption); ok {
+			result = append(result, o)
+		}
+	}
+	return result, nil
+}
+
 func (s *Swipe) findInjector(info *stdtypes.Info, fn *ast.FuncDecl) (*ast.CallExpr, error) {
 	if fn.Body == nil {
 		return nil, nil
@@ -0,0 +1,129 @@
+package gen
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/swipe-io/swipe/pkg/astloader"
+)
+
+// TestGenerate generates the code of the testdata cases and runs the tests of the cases
+// against the generated code, the cases share one module where the swipe module is replaced with the working tree.
+func TestGenerate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the generated code is compiled by the go command")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go command is not found")
+	}
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := ioutil.ReadDir("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := copyModule(t, "testdata")
+	defer os.RemoveAll(dir)
+
+	env := append(os.Environ(), "GOFLAGS=-mod=mod")
+
+	runGo(t, dir, env, "mod", "edit", "-replace", "github.com/swipe-io/swipe="+root)
+
+	// each case is generated separately, the generation removes the generated files of the module,
+	// so the results are written after all cases are generated.
+	var (
+		cases   []string
+		results []Result
+	)
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		rs, errs := NewSwipe(context.Background(), "", astloader.NewLoader(dir, env, []string{"./" + name + "/..."})).Generate()
+		for _, err := range errs {
+			t.Fatalf("%s: %s", name, err)
+		}
+		cases = append(cases, name)
+		results = append(results, rs...)
+	}
+	for _, r := range results {
+		// the JS clients are formatted by prettier and are not compiled by the go command.
+		if filepath.Ext(r.OutputPath) == ".js" {
+			continue
+		}
+		for _, err := range r.Errs {
+			t.Fatalf("%s: %s", r.OutputPath, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(r.OutputPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(r.OutputPath, r.Content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range cases {
+		pattern := "./" + name + "/..."
+		t.Run(name, func(t *testing.T) {
+			// the modules that are not required by the testdata module, like the JSON RPC transport,
+			// are resolved by the go command, the case is skipped when they are not available.
+			if out, err := execGo(dir, env, "list", "-deps", "-test", pattern); err != nil {
+				if strings.Contains(string(out), "finding module for package") {
+					t.Skipf("the dependencies are not available:\n%s", out)
+				}
+				t.Fatalf("go list: %s\n%s", err, out)
+			}
+			runGo(t, dir, env, "vet", pattern)
+			runGo(t, dir, env, "test", "-count=1", pattern)
+		})
+	}
+}
+
+// copyModule copies the testdata module to the temporary directory, the directory is removed by the caller.
+func copyModule(t *testing.T, src string) string {
+	dir, err := ioutil.TempDir("", "swipe-"+filepath.Base(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(dir, rel), 0755)
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(filepath.Join(dir, rel), data, 0644)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func runGo(t *testing.T, dir string, env []string, args ...string) {
+	if out, err := execGo(dir, env, args...); err != nil {
+		t.Fatalf("go %s: %s\n%s", strings.Join(args, " "), err, out)
+	}
+}
+
+func execGo(dir string, env []string, args ...string) ([]byte, error) {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	return cmd.CombinedOutput()
+}
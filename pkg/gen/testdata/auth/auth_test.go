@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+func TestAuth(t *testing.T) {
+	h, err := MakeHandlerRESTAuth(service{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL+"/echo", "application/json", strings.NewReader(`{"text":"hello"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("status: got %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+	if !strings.Contains(string(body), "unauthorized") {
+		t.Fatalf("body: got %q, want the error message", body)
+	}
+
+	c, err := NewClientRESTAuth(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Echo(context.Background(), "hello"); err != (UnauthorizedError{}) {
+		t.Fatalf("error: got %#v, want UnauthorizedError", err)
+	}
+	if err := c.Ping(context.Background()); err != nil {
+		t.Fatalf("public method: %s", err)
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "user"}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err = NewClientRESTAuth(srv.URL, AuthClientTokenProvider(func(context.Context) (string, error) {
+		return token, nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, err := c.Echo(context.Background(), "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "hello" {
+		t.Fatalf("echo: got %q, want %q", text, "hello")
+	}
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+type Service interface {
+	Echo(ctx context.Context, text string) (string, error)
+	Ping(ctx context.Context) error
+}
+
+type service struct{}
+
+func (service) Echo(_ context.Context, text string) (string, error) {
+	return text, nil
+}
+
+func (service) Ping(context.Context) error {
+	return nil
+}
+
+func KeyFunc(token *jwt.Token) (interface{}, error) {
+	return []byte("secret"), nil
+}
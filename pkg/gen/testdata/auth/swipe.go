@@ -0,0 +1,22 @@
+//+build swipe
+
+package auth
+
+import (
+	"net/http"
+
+	"github.com/swipe-io/swipe/pkg/swipe"
+)
+
+func Swipe() {
+	swipe.Build(
+		swipe.Service((*Service)(nil),
+			swipe.Transport("http",
+				swipe.ClientEnable(),
+				swipe.Auth(KeyFunc),
+				swipe.MethodOptions(Service.Echo, swipe.Method(http.MethodPost)),
+				swipe.MethodOptions(Service.Ping, swipe.Public()),
+			),
+		),
+	)
+}
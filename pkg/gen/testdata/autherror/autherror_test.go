@@ -0,0 +1,24 @@
+package autherror
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthServiceError(t *testing.T) {
+	h, err := MakeHandlerRESTAutherror(service{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	c, err := NewClientRESTAutherror(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Delete(context.Background(), 1); err != (ErrDenied{}) {
+		t.Fatalf("error: got %#v, want ErrDenied", err)
+	}
+}
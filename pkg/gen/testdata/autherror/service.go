@@ -0,0 +1,32 @@
+package autherror
+
+import (
+	"context"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// ErrDenied is the service error with the same code as the authentication error.
+type ErrDenied struct{}
+
+func (ErrDenied) Error() string {
+	return "denied"
+}
+
+func (ErrDenied) StatusCode() int {
+	return 401
+}
+
+type Service interface {
+	Delete(ctx context.Context, id int) error
+}
+
+type service struct{}
+
+func (service) Delete(context.Context, int) error {
+	return ErrDenied{}
+}
+
+func KeyFunc(token *jwt.Token) (interface{}, error) {
+	return []byte("secret"), nil
+}
@@ -0,0 +1,21 @@
+//+build swipe
+
+package autherror
+
+import (
+	"net/http"
+
+	"github.com/swipe-io/swipe/pkg/swipe"
+)
+
+func Swipe() {
+	swipe.Build(
+		swipe.Service((*Service)(nil),
+			swipe.Transport("http",
+				swipe.ClientEnable(),
+				swipe.Auth(KeyFunc),
+				swipe.MethodOptions(Service.Delete, swipe.Method(http.MethodPost)),
+			),
+		),
+	)
+}
@@ -0,0 +1,49 @@
+package authorization
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+func newClient(t *testing.T, url, role string) Service {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"role": role}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewClientRESTAuthorization(url, AuthorizationClientTokenProvider(func(context.Context) (string, error) {
+		return token, nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestAuthorization(t *testing.T) {
+	h, err := MakeHandlerRESTAuthorization(service{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	c := newClient(t, srv.URL, "user")
+	if err := c.Delete(context.Background(), 1); err != (ForbiddenError{}) {
+		t.Fatalf("error: got %#v, want ForbiddenError", err)
+	}
+	// the method without the MethodOptions option has the roles of MethodDefaultOptions.
+	if err := c.Ping(context.Background()); err != (ForbiddenError{}) {
+		t.Fatalf("default options: got %#v, want ForbiddenError", err)
+	}
+
+	c = newClient(t, srv.URL, "admin")
+	if err := c.Delete(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
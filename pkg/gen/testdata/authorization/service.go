@@ -0,0 +1,41 @@
+package authorization
+
+import (
+	"context"
+
+	"github.com/dgrijalva/jwt-go"
+	kitjwt "github.com/go-kit/kit/auth/jwt"
+)
+
+type Service interface {
+	Delete(ctx context.Context, id int) error
+	Ping(ctx context.Context) error
+}
+
+type service struct{}
+
+func (service) Delete(context.Context, int) error {
+	return nil
+}
+
+func (service) Ping(context.Context) error {
+	return nil
+}
+
+func KeyFunc(token *jwt.Token) (interface{}, error) {
+	return []byte("secret"), nil
+}
+
+type Principal struct {
+	Role string
+}
+
+func (p Principal) HasRole(role string) bool {
+	return p.Role == role
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, error) {
+	claims, _ := ctx.Value(kitjwt.JWTClaimsContextKey).(jwt.MapClaims)
+	role, _ := claims["role"].(string)
+	return Principal{Role: role}, nil
+}
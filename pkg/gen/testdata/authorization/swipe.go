@@ -0,0 +1,23 @@
+//+build swipe
+
+package authorization
+
+import (
+	"net/http"
+
+	"github.com/swipe-io/swipe/pkg/swipe"
+)
+
+func Swipe() {
+	swipe.Build(
+		swipe.Service((*Service)(nil),
+			swipe.Transport("http",
+				swipe.ClientEnable(),
+				swipe.Auth(KeyFunc),
+				swipe.Principal(PrincipalFromContext),
+				swipe.MethodDefaultOptions(swipe.Roles("admin")),
+				swipe.MethodOptions(Service.Delete, swipe.Method(http.MethodPost)),
+			),
+		),
+	)
+}
@@ -0,0 +1,39 @@
+package circuitbreaker
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	h, err := MakeHandlerRESTCircuitbreaker(service{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	c, err := NewClientRESTCircuitbreaker(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the service errors are not counted as failures.
+	for i := 0; i < 5; i++ {
+		if _, err := c.Get(context.Background(), 0); err != (ErrNotFound{}) {
+			t.Fatalf("service error: got %#v, want ErrNotFound", err)
+		}
+	}
+	if _, err := c.Get(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	// the second consecutive server failure trips the circuit breaker.
+	for i := 0; i < 2; i++ {
+		if _, err := c.Get(context.Background(), -1); err == nil || err == (ServiceUnavailableError{}) {
+			t.Fatalf("server failure %d: got %#v, want the server error", i, err)
+		}
+	}
+	if _, err := c.Get(context.Background(), 1); err != (ServiceUnavailableError{}) {
+		t.Fatalf("open state: got %#v, want ServiceUnavailableError", err)
+	}
+}
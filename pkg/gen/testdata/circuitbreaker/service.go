@@ -0,0 +1,32 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+)
+
+type ErrNotFound struct{}
+
+func (ErrNotFound) Error() string {
+	return "not found"
+}
+
+func (ErrNotFound) StatusCode() int {
+	return 404
+}
+
+type Service interface {
+	Get(ctx context.Context, id int) (string, error)
+}
+
+type service struct{}
+
+func (service) Get(_ context.Context, id int) (string, error) {
+	switch {
+	case id == 0:
+		return "", ErrNotFound{}
+	case id < 0:
+		return "", errors.New("internal")
+	}
+	return "found", nil
+}
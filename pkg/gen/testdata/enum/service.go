@@ -0,0 +1,37 @@
+package enum
+
+import (
+	"context"
+)
+
+type Status int
+
+const (
+	StatusActive Status = iota + 1
+	StatusBlocked
+	// StatusLocked is the alias of StatusBlocked.
+	StatusLocked = StatusBlocked
+)
+
+// Kind has no Enum option, the values are not checked.
+type Kind string
+
+const (
+	KindAdmin Kind = "admin"
+	KindUser  Kind = "user"
+)
+
+type User struct {
+	Status Status `json:"status"`
+	Kind   Kind   `json:"kind"`
+}
+
+type Service interface {
+	Update(ctx context.Context, user User) (User, error)
+}
+
+type Impl struct{}
+
+func (Impl) Update(_ context.Context, user User) (User, error) {
+	return user, nil
+}
@@ -0,0 +1,16 @@
+//+build swipe
+
+package enum
+
+import (
+	"github.com/swipe-io/swipe/pkg/swipe"
+)
+
+func Swipe() {
+	swipe.Build(
+		swipe.Enum((*Status)(nil),
+			swipe.EnumTrimPrefix("Status"),
+			swipe.EnumJSONValue(),
+		),
+	)
+}
@@ -0,0 +1,39 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example.com/testdata/enum"
+)
+
+// TestEnumValidation checks only the values of the types with the Enum option are checked,
+// the unset value is accepted.
+func TestEnumValidation(t *testing.T) {
+	h, err := MakeHandlerRESTEnum(enum.Impl{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "const", body: `{"user":{"status":1,"kind":"admin"}}`, want: http.StatusOK},
+		{name: "alias", body: `{"user":{"status":2}}`, want: http.StatusOK},
+		{name: "unset", body: `{"user":{"kind":"user"}}`, want: http.StatusOK},
+		{name: "unknown kind", body: `{"user":{"status":1,"kind":"root"}}`, want: http.StatusOK},
+		{name: "unknown status", body: `{"user":{"status":7}}`, want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/update", strings.NewReader(tt.body)))
+			if w.Code != tt.want {
+				t.Fatalf("status: got %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+//+build swipe
+
+package transport
+
+import (
+	"net/http"
+
+	"example.com/testdata/enum"
+	"github.com/swipe-io/swipe/pkg/swipe"
+)
+
+func Swipe() {
+	swipe.Build(
+		swipe.Service((*enum.Service)(nil),
+			swipe.Transport("http",
+				swipe.ClientEnable(),
+				swipe.MethodOptions(enum.Service.Update,
+					swipe.Method(http.MethodPost),
+				),
+			),
+		),
+	)
+}
@@ -0,0 +1,32 @@
+package httpmetrics
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestExpvar checks the default HTTP metrics are created by the expvar backend.
+func TestExpvar(t *testing.T) {
+	h, err := MakeHandlerRESTHttpmetrics(service{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/get")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	v := expvar.Get("api_http_http_requests_total")
+	if v == nil {
+		t.Fatal("the HTTP requests counter is not published")
+	}
+	if v.String() != "1" {
+		t.Fatalf("requests: got %s, want 1", v)
+	}
+}
@@ -0,0 +1,15 @@
+package statsd
+
+import (
+	"context"
+)
+
+type Service interface {
+	Get(ctx context.Context) error
+}
+
+type service struct{}
+
+func (service) Get(context.Context) error {
+	return nil
+}
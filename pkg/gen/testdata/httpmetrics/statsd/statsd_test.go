@@ -0,0 +1,21 @@
+package statsd
+
+import (
+	"testing"
+
+	"github.com/go-kit/kit/metrics/discard"
+)
+
+// TestHTTPMetricsRequired checks the handler is not created without the HTTP metrics of the statsd backend.
+func TestHTTPMetricsRequired(t *testing.T) {
+	if _, err := MakeHandlerRESTHttpmetrics(service{}); err == nil {
+		t.Fatal("the handler is created without the HTTP metrics")
+	}
+	_, err := MakeHandlerRESTHttpmetrics(service{},
+		HttpmetricsServerHTTPMetrics(discard.NewCounter(), discard.NewHistogram(), discard.NewHistogram()),
+		HttpmetricsServerPanicCounter(discard.NewCounter()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
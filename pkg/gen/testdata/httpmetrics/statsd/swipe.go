@@ -0,0 +1,21 @@
+//+build swipe
+
+package statsd
+
+import (
+	"github.com/swipe-io/swipe/pkg/swipe"
+)
+
+func Swipe() {
+	swipe.Build(
+		swipe.Service((*Service)(nil),
+			swipe.Transport("http",
+				swipe.ClientEnable(),
+				swipe.HTTPMetrics(),
+			),
+			swipe.Instrumenting("api", "http",
+				swipe.MetricsBackend("statsd"),
+			),
+		),
+	)
+}
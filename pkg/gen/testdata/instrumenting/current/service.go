@@ -0,0 +1,19 @@
+package current
+
+import (
+	"context"
+)
+
+type Service interface {
+	Get(ctx context.Context) error
+}
+
+type service struct{}
+
+func (service) Get(context.Context) error {
+	return nil
+}
+
+func NewService() Service {
+	return service{}
+}
@@ -0,0 +1,16 @@
+//+build swipe
+
+package current
+
+import (
+	"github.com/swipe-io/swipe/pkg/swipe"
+)
+
+func Swipe() {
+	swipe.Build(
+		swipe.Service((*Service)(nil),
+			swipe.Transport("http", swipe.ClientEnable()),
+			swipe.Instrumenting("current", "api"),
+		),
+	)
+}
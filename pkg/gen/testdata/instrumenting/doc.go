@@ -0,0 +1,2 @@
+// Package instrumenting tests the default metrics of the legacy and the current names and the statsd metrics.
+package instrumenting
@@ -0,0 +1,80 @@
+package instrumenting
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	kitstatsd "github.com/go-kit/kit/metrics/statsd"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"example.com/testdata/instrumenting/current"
+	"example.com/testdata/instrumenting/legacy"
+	"example.com/testdata/instrumenting/statsd"
+)
+
+// TestMetrics checks the names and the labels of the default metrics.
+func TestMetrics(t *testing.T) {
+	if err := legacy.NewInstrumentingMiddlewareInstrumenting(legacy.NewService(), nil, nil).Get(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := current.NewInstrumentingMiddlewareInstrumenting(current.NewService(), nil, nil, nil).Get(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string][]string{}
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			var labels []string
+			for _, l := range m.GetLabel() {
+				labels = append(labels, l.GetName())
+			}
+			sort.Strings(labels)
+			got[f.GetName()] = labels
+		}
+	}
+	want := map[string][]string{
+		"legacy_api_request_count":                {"method"},
+		"legacy_api_request_latency_microseconds": {"method"},
+		"current_api_requests_total":              {"error", "method"},
+		"current_api_request_duration_seconds":    {"error", "method"},
+		"current_api_requests_in_flight":          {"method"},
+	}
+	for name, labels := range want {
+		if !reflect.DeepEqual(got[name], labels) {
+			t.Errorf("%s: got labels %v, want %v", name, got[name], labels)
+		}
+	}
+	if _, ok := got["legacy_api_requests_in_flight"]; ok {
+		t.Error("the legacy metrics have the requests in flight gauge")
+	}
+}
+
+// TestStatsdMetrics checks the method is put into the names of the statsd metrics.
+func TestStatsdMetrics(t *testing.T) {
+	provider := kitstatsd.New("", log.NewNopLogger())
+	if err := statsd.NewInstrumentingMiddlewareInstrumenting(statsd.NewService(), provider, nil, nil, nil).Get(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err := provider.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{
+		"statsd.api.Get.requests_total:",
+		"statsd.api.Get.request_duration_seconds:",
+		"statsd.api.Get.requests_in_flight:",
+	} {
+		if !strings.Contains(buf.String(), name) {
+			t.Errorf("%s: not found in %q", name, buf.String())
+		}
+	}
+}
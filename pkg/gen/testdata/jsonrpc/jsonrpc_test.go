@@ -0,0 +1,110 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// recordClient records the bodies of the requests sent by the client.
+type recordClient struct {
+	bodies [][]byte
+}
+
+func (c *recordClient) Do(req *http.Request) (*http.Response, error) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.bodies = append(c.bodies, body)
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return http.DefaultClient.Do(req)
+}
+
+func newServer(t *testing.T) (*httptest.Server, chan string) {
+	messages := make(chan string, 1)
+	h, err := MakeHandlerJSONRPCJsonrpc(service{messages: messages})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return httptest.NewServer(h), messages
+}
+
+// TestBatch checks the batch is sent as one request by the client of the HTTPClient option
+// and the results are returned by the ids of the calls.
+func TestBatch(t *testing.T) {
+	srv, messages := newServer(t)
+	defer srv.Close()
+
+	rc := &recordClient{}
+	b, err := NewBatchJSONRPCJsonrpc(srv.URL+"/rpc", JsonrpcHTTPClient(rc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	add1 := b.Add(1, 2)
+	add2 := b.Add(3, 4)
+	notify := b.Notify("batch")
+	if _, err := add1(); err == nil {
+		t.Fatal("add: want the error before Do")
+	}
+	if err := b.Do(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(rc.bodies) != 1 {
+		t.Fatalf("requests: got %d, want 1", len(rc.bodies))
+	}
+	if sum, err := add1(); err != nil || sum != 3 {
+		t.Fatalf("add: got %d, %v, want 3", sum, err)
+	}
+	if sum, err := add2(); err != nil || sum != 7 {
+		t.Fatalf("add: got %d, %v, want 7", sum, err)
+	}
+	if err := notify(); err != nil {
+		t.Fatal(err)
+	}
+	if m := <-messages; m != "batch" {
+		t.Fatalf("notify: got %q, want %q", m, "batch")
+	}
+}
+
+// TestNotification checks the notification is sent without the id by the client of the HTTPClient option.
+func TestNotification(t *testing.T) {
+	srv, messages := newServer(t)
+	defer srv.Close()
+
+	rc := &recordClient{}
+	c, err := NewClientJSONRPCJsonrpc(srv.URL+"/rpc", JsonrpcHTTPClient(rc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Notify(context.Background(), "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if m := <-messages; m != "hello" {
+		t.Fatalf("notify: got %q, want %q", m, "hello")
+	}
+	if len(rc.bodies) != 1 {
+		t.Fatalf("requests: got %d, want 1", len(rc.bodies))
+	}
+	var request map[string]json.RawMessage
+	if err := json.Unmarshal(rc.bodies[0], &request); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := request["id"]; ok {
+		t.Fatalf("notification: got the id in %s", rc.bodies[0])
+	}
+	if string(request["jsonrpc"]) != `"2.0"` {
+		t.Fatalf("notification: got the version %s, want \"2.0\"", request["jsonrpc"])
+	}
+
+	if sum, err := c.Add(context.Background(), 2, 2); err != nil || sum != 4 {
+		t.Fatalf("add: got %d, %v, want 4", sum, err)
+	}
+	if len(rc.bodies) != 2 {
+		t.Fatalf("requests: got %d, want 2", len(rc.bodies))
+	}
+}
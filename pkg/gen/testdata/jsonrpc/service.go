@@ -0,0 +1,23 @@
+package jsonrpc
+
+import (
+	"context"
+)
+
+type Service interface {
+	Add(ctx context.Context, a, b int) (int, error)
+	Notify(ctx context.Context, message string) error
+}
+
+type service struct {
+	messages chan string
+}
+
+func (service) Add(_ context.Context, a, b int) (int, error) {
+	return a + b, nil
+}
+
+func (s service) Notify(_ context.Context, message string) error {
+	s.messages <- message
+	return nil
+}
@@ -0,0 +1,61 @@
+package logging
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+func TestLoggingMiddleware(t *testing.T) {
+	var keyvals []interface{}
+	logger := log.LoggerFunc(func(kv ...interface{}) error {
+		keyvals = kv
+		return nil
+	})
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	user := User{ID: ID{1, 2, 3, 4}, Created: created, Password: "secret"}
+
+	if err := NewLoggingMiddlewareLogging(service{}, logger).Create(context.Background(), user); err != nil {
+		t.Fatal(err)
+	}
+	var got interface{}
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] == "user" {
+			got = keyvals[i+1]
+		}
+	}
+	want := map[string]interface{}{
+		"ID":       "01020304",
+		"Created":  created.String(),
+		"Password": "[REDACTED]",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("user: got %#v, want %#v", got, want)
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	testCases := []struct {
+		name    string
+		v       interface{}
+		maxSize int
+		want    interface{}
+	}{
+		{name: "stringer", v: ID{1, 2, 3, 4}, want: "01020304"},
+		{name: "stringer pointer", v: &ID{1, 2, 3, 4}, want: "01020304"},
+		{name: "stringer max size", v: ID{1, 2, 3, 4}, maxSize: 2, want: "01...(8 bytes)"},
+		{name: "time", v: created, want: created.String()},
+		{name: "slice", v: []ID{{1, 2, 3, 4}}, want: []interface{}{"01020304"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := logValue(tc.v, tc.maxSize); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package logging
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+)
+
+type ID [4]byte
+
+func (id ID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+type User struct {
+	ID       ID
+	Created  time.Time
+	Password string `log:"redact"`
+}
+
+type Service interface {
+	Create(ctx context.Context, user User) error
+}
+
+type service struct{}
+
+func (service) Create(context.Context, User) error {
+	return nil
+}
@@ -0,0 +1,42 @@
+package slog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestLoggingMiddleware(t *testing.T) {
+	testCases := []struct {
+		name      string
+		wantLevel string
+	}{
+		{name: "alice", wantLevel: "INFO"},
+		{name: "root", wantLevel: "ERROR"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+			_ = NewLoggingMiddlewareLogging(service{}, logger).Create(context.Background(), User{Name: tc.name, Password: "secret"})
+
+			var entry struct {
+				Level string
+				Msg   string
+				User  map[string]interface{}
+			}
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatal(err)
+			}
+			if entry.Level != tc.wantLevel || entry.Msg != "Create" {
+				t.Fatalf("entry: got %s %q, want %s %q", entry.Level, entry.Msg, tc.wantLevel, "Create")
+			}
+			if entry.User["Password"] != "[REDACTED]" {
+				t.Fatalf("user: got %v", entry.User)
+			}
+		})
+	}
+}
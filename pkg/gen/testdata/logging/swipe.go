@@ -0,0 +1,16 @@
+//+build swipe
+
+package logging
+
+import (
+	"github.com/swipe-io/swipe/pkg/swipe"
+)
+
+func Swipe() {
+	swipe.Build(
+		swipe.Service((*Service)(nil),
+			swipe.Transport("http", swipe.ClientEnable()),
+			swipe.Logging(),
+		),
+	)
+}
@@ -0,0 +1,26 @@
+package zap
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrExists = errors.New("exists")
+
+type User struct {
+	Name     string
+	Password string `log:"redact"`
+}
+
+type Service interface {
+	Create(ctx context.Context, user User) error
+}
+
+type service struct{}
+
+func (service) Create(_ context.Context, user User) error {
+	if user.Name == "root" {
+		return ErrExists
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+//+build swipe
+
+package zap
+
+import (
+	"github.com/swipe-io/swipe/pkg/swipe"
+)
+
+func Swipe() {
+	swipe.Build(
+		swipe.Service((*Service)(nil),
+			swipe.Transport("http", swipe.ClientEnable()),
+			swipe.Logging(
+				swipe.LoggerBackend("zap"),
+			),
+		),
+	)
+}
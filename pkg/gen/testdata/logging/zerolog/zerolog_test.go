@@ -0,0 +1,43 @@
+package zerolog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestLoggingMiddleware(t *testing.T) {
+	testCases := []struct {
+		name      string
+		wantLevel string
+	}{
+		{name: "alice", wantLevel: "info"},
+		{name: "root", wantLevel: "error"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := zerolog.New(&buf)
+
+			_ = NewLoggingMiddlewareLogging(service{}, logger).Create(context.Background(), User{Name: tc.name, Password: "secret"})
+
+			var entry struct {
+				Level   string
+				Message string
+				User    map[string]interface{}
+			}
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatal(err)
+			}
+			if entry.Level != tc.wantLevel || entry.Message != "Create" {
+				t.Fatalf("entry: got %s %q, want %s %q", entry.Level, entry.Message, tc.wantLevel, "Create")
+			}
+			if entry.User["Password"] != "[REDACTED]" {
+				t.Fatalf("user: got %v", entry.User)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package openapiimport
+
+import (
+	"context"
+)
+
+type service struct{}
+
+func (service) ListPets(context.Context, int32, string) ([]Pet, error) {
+	return nil, nil
+}
+
+func (service) CreatePet(context.Context, Kind, string, interface{}) (Pet, error) {
+	return Pet{}, nil
+}
+
+func (service) GetPet(context.Context, int64) (Pet, error) {
+	return Pet{}, nil
+}
+
+func (service) UploadPhoto(context.Context, int64) error {
+	return nil
+}
@@ -0,0 +1,20 @@
+package openapiimport
+
+type Pet struct {
+	NewPet
+	ID  int64   `json:"id"`
+	Tag *string `json:"tag"` // The tag of the pet.
+}
+
+type NewPet struct {
+	Kind  Kind        `json:"kind,omitempty"`
+	Name  string      `json:"name" validate:"min=1" example:"Rex"`
+	Owner interface{} `json:"owner,omitempty"`
+}
+
+type Kind string
+
+const (
+	KindCat Kind = "cat"
+	KindDog Kind = "dog"
+)
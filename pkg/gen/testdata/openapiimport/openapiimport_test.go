@@ -0,0 +1,83 @@
+package openapiimport
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The service.go, model.go and swipe.go files are imported from the openapi.yaml document by the
+// swipe import-openapi command.
+
+type document struct {
+	Paths map[string]map[string]struct {
+		Parameters []struct {
+			In   string `yaml:"in"`
+			Name string `yaml:"name"`
+		} `yaml:"parameters"`
+		Responses map[string]struct {
+			Content map[string]struct {
+				Schema struct {
+					Ref string `yaml:"$ref"`
+				} `yaml:"schema"`
+			} `yaml:"content"`
+		} `yaml:"responses"`
+	} `yaml:"paths"`
+	Components struct {
+		Schemas map[string]interface{} `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+func readDocument(t *testing.T, name string) document {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// TestImportRoundTrip checks the document generated from the imported service has the operations, the parameters,
+// and the response schemas of the source document.
+func TestImportRoundTrip(t *testing.T) {
+	if _, err := MakeHandlerRESTOpenapiimport(service{}); err != nil {
+		t.Fatal(err)
+	}
+	source := readDocument(t, "openapi.yaml")
+	generated := readDocument(t, "openapi_rest_gen.yaml")
+
+	for pathStr, operations := range source.Paths {
+		for method, o := range operations {
+			g, ok := generated.Paths[pathStr][method]
+			if !ok {
+				t.Errorf("%s %s: the operation is not generated", method, pathStr)
+				continue
+			}
+			params := map[string]bool{}
+			for _, p := range g.Parameters {
+				params[p.In+" "+p.Name] = true
+			}
+			for _, p := range o.Parameters {
+				// the cookie parameters are not imported.
+				if p.In != "cookie" && !params[p.In+" "+p.Name] {
+					t.Errorf("%s %s: the %s parameter %q is not generated", method, pathStr, p.In, p.Name)
+				}
+			}
+			for code, r := range o.Responses {
+				ref := r.Content["application/json"].Schema.Ref
+				// the first success response is generated as the 200 response.
+				if ref != "" && code[0] == '2' && g.Responses["200"].Content["application/json"].Schema.Ref != ref {
+					t.Errorf("%s %s: the %s response schema %s is not generated", method, pathStr, code, ref)
+				}
+			}
+		}
+	}
+	// the Pet schema is the component in the both documents.
+	if _, ok := generated.Components.Schemas["Pet"]; !ok {
+		t.Errorf("the Pet component schema is not generated")
+	}
+}
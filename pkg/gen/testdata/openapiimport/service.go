@@ -0,0 +1,13 @@
+package openapiimport
+
+import (
+	"context"
+)
+
+type Service interface {
+	// List the pets.
+	ListPets(ctx context.Context, limit int32, filter string) ([]Pet, error)
+	CreatePet(ctx context.Context, kind Kind, name string, owner interface{}) (Pet, error)
+	GetPet(ctx context.Context, petId int64) (Pet, error)
+	UploadPhoto(ctx context.Context, petId int64) error
+}
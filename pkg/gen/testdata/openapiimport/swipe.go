@@ -0,0 +1,47 @@
+//go:build swipe
+// +build swipe
+
+package openapiimport
+
+import (
+	"net/http"
+
+	"github.com/swipe-io/swipe/pkg/swipe"
+)
+
+func Swipe() {
+	swipe.Build(
+		swipe.Service((*Service)(nil),
+			swipe.Transport("http",
+				swipe.ClientEnable(),
+				swipe.Openapi(
+					swipe.OpenapiInfo("Pets", "", "1.0.0"),
+					swipe.OpenapiFormat("yaml"),
+				),
+				swipe.MethodOptions(Service.ListPets,
+					swipe.Path("/pets"),
+					swipe.Method(http.MethodGet),
+					swipe.QueryVars([]string{"limit", "limit", "filter", "filter"}),
+				),
+				swipe.MethodOptions(Service.CreatePet,
+					swipe.Path("/pets"),
+					swipe.Method(http.MethodPost),
+				),
+				swipe.MethodOptions(Service.GetPet,
+					swipe.Path("/pets/{petId}"),
+					swipe.Method(http.MethodGet),
+				),
+				swipe.MethodOptions(Service.UploadPhoto,
+					swipe.Path("/pets/{petId}/photo"),
+					swipe.Method(http.MethodPut),
+				),
+			),
+		),
+	)
+}
+
+func SwipeKind() {
+	swipe.Build(
+		swipe.Enum((*Kind)(nil)),
+	)
+}
@@ -0,0 +1,41 @@
+package openapiserve
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestOpenapiServe checks the docs page, the Swagger UI files and the document are served by the handler.
+func TestOpenapiServe(t *testing.T) {
+	h, err := MakeHandlerRESTOpenapiserve(service{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path        string
+		contentType string
+		contains    string
+	}{
+		{path: "/docs", contentType: "text/html", contains: `url: "/docs/openapi.json"`},
+		{path: "/docs/openapi.json", contentType: "application/json", contains: `"summary":"Get"`},
+		{path: "/docs/swagger-ui-bundle.js", contentType: "javascript", contains: "SwaggerUIBundle"},
+		{path: "/docs/swagger-ui.css", contentType: "text/css", contains: ".swagger-ui"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("status: got %d, want %d", w.Code, http.StatusOK)
+			}
+			if ct := w.Header().Get("Content-Type"); !strings.Contains(ct, tt.contentType) {
+				t.Fatalf("content type: got %q, want %q", ct, tt.contentType)
+			}
+			if !strings.Contains(w.Body.String(), tt.contains) {
+				t.Fatalf("body does not contain %q", tt.contains)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package openapiserve
+
+import (
+	"context"
+)
+
+type Service interface {
+	Get(ctx context.Context, id int) (string, error)
+}
+
+type service struct{}
+
+func (service) Get(context.Context, int) (string, error) {
+	return "", nil
+}
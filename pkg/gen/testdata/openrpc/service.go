@@ -0,0 +1,25 @@
+package openrpc
+
+import (
+	"context"
+)
+
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type Service interface {
+	Get(ctx context.Context, id int) (User, error)
+	Rename(ctx context.Context, id int, name string) error
+}
+
+type service struct{}
+
+func (service) Get(_ context.Context, id int) (User, error) {
+	return User{ID: id}, nil
+}
+
+func (service) Rename(context.Context, int, string) error {
+	return nil
+}
@@ -0,0 +1,22 @@
+//+build swipe
+
+package openrpc
+
+import (
+	"github.com/swipe-io/swipe/pkg/swipe"
+)
+
+func Swipe() {
+	swipe.Build(
+		swipe.Service((*Service)(nil),
+			swipe.Transport("http",
+				swipe.ClientEnable(),
+				swipe.JSONRPC(swipe.JSONRPCPath("/rpc")),
+				swipe.OpenRPC(
+					swipe.OpenRPCInfo("OpenRPC", "", "v1.0.0"),
+					swipe.OpenRPCDiscover(),
+				),
+			),
+		),
+	)
+}
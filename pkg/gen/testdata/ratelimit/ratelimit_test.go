@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	h, err := MakeHandlerRESTRatelimit(service{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return httptest.NewServer(h)
+}
+
+// TestRateLimitServiceError checks the service error with the same code is returned
+// when the rate limit of the server or the client is exceeded.
+func TestRateLimitServiceError(t *testing.T) {
+	srv := newServer(t)
+	defer srv.Close()
+
+	c, err := NewClientRESTRatelimit(srv.URL, RatelimitGetClientRateLimit(1000, 1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(context.Background(), 1); err != (ErrBusy{}) {
+		t.Fatalf("server limit: got %#v, want ErrBusy", err)
+	}
+
+	c, err = NewClientRESTRatelimit(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Get(context.Background(), 1)
+	if _, err := c.Get(context.Background(), 1); err != (ErrBusy{}) {
+		t.Fatalf("client limit: got %#v, want ErrBusy", err)
+	}
+}
+
+// TestCircuitBreakerServiceError checks the service error with the same code is returned
+// when the circuit breaker is open.
+func TestCircuitBreakerServiceError(t *testing.T) {
+	srv := newServer(t)
+	defer srv.Close()
+
+	c, err := NewClientRESTRatelimit(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the second consecutive failure trips the circuit breaker.
+	for i := 0; i < 2; i++ {
+		if _, err := c.Fetch(context.Background(), -1); err != (ErrUnavailable{}) {
+			t.Fatalf("failure %d: got %#v, want ErrUnavailable", i, err)
+		}
+	}
+	if _, err := c.Fetch(context.Background(), 1); err != (ErrUnavailable{}) {
+		t.Fatalf("open state: got %#v, want ErrUnavailable", err)
+	}
+}
@@ -0,0 +1,48 @@
+package ratelimit
+
+import (
+	"context"
+)
+
+// ErrBusy is the service error with the same code as the rate limit error.
+type ErrBusy struct{}
+
+func (ErrBusy) Error() string {
+	return "busy"
+}
+
+func (ErrBusy) StatusCode() int {
+	return 429
+}
+
+// ErrUnavailable is the service error with the same code as the circuit breaker error.
+type ErrUnavailable struct{}
+
+func (ErrUnavailable) Error() string {
+	return "unavailable"
+}
+
+func (ErrUnavailable) StatusCode() int {
+	return 503
+}
+
+type Service interface {
+	Get(ctx context.Context, id int) (int, error)
+	Fetch(ctx context.Context, id int) (int, error)
+}
+
+type service struct{}
+
+func (service) Get(_ context.Context, id int) (int, error) {
+	if id < 0 {
+		return 0, ErrBusy{}
+	}
+	return id, nil
+}
+
+func (service) Fetch(_ context.Context, id int) (int, error) {
+	if id < 0 {
+		return 0, ErrUnavailable{}
+	}
+	return id, nil
+}
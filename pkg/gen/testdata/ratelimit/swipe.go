@@ -0,0 +1,27 @@
+//+build swipe
+
+package ratelimit
+
+import (
+	"net/http"
+
+	"github.com/swipe-io/swipe/pkg/swipe"
+)
+
+func Swipe() {
+	swipe.Build(
+		swipe.Service((*Service)(nil),
+			swipe.Transport("http",
+				swipe.ClientEnable(),
+				swipe.MethodOptions(Service.Get,
+					swipe.Method(http.MethodPost),
+					swipe.RateLimit(1, 1),
+				),
+				swipe.MethodOptions(Service.Fetch,
+					swipe.Method(http.MethodPost),
+					swipe.CircuitBreaker(swipe.CircuitBreakerFailures(1)),
+				),
+			),
+		),
+	)
+}
@@ -0,0 +1,49 @@
+package backend
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/metrics/statsd"
+)
+
+// TestPanicCounterRequired checks the handler is not created without the panic counter of the statsd backend.
+func TestPanicCounterRequired(t *testing.T) {
+	if _, err := MakeHandlerRESTRecovery(service{}); err == nil {
+		t.Fatal("the handler is created without the panic counter")
+	}
+}
+
+// TestPanicLoggerBackend checks the panic is logged with the slog logger and counted by the statsd provider.
+func TestPanicLoggerBackend(t *testing.T) {
+	var logs bytes.Buffer
+	provider := statsd.New("", log.NewNopLogger())
+
+	h, err := MakeHandlerRESTRecovery(service{},
+		RecoveryServerPanicLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		RecoveryServerMetricsProvider(provider),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/get", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status: got %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	if !strings.Contains(logs.String(), "method=Get") || !strings.Contains(logs.String(), "panic=get") {
+		t.Fatalf("log: got %q", logs.String())
+	}
+	var metrics bytes.Buffer
+	if _, err := provider.WriteTo(&metrics); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(metrics.String(), "api.http.panics_total:1.000000|c") {
+		t.Fatalf("metrics: got %q", metrics.String())
+	}
+}
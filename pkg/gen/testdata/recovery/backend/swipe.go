@@ -0,0 +1,23 @@
+//+build swipe
+
+package backend
+
+import (
+	"github.com/swipe-io/swipe/pkg/swipe"
+)
+
+func Swipe() {
+	swipe.Build(
+		swipe.Service((*Service)(nil),
+			swipe.Transport("http",
+				swipe.ClientEnable(),
+			),
+			swipe.Logging(
+				swipe.LoggerBackend("slog"),
+			),
+			swipe.Instrumenting("api", "http",
+				swipe.MetricsBackend("statsd"),
+			),
+		),
+	)
+}
@@ -0,0 +1,22 @@
+package recovery
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+)
+
+// TestRecoveryHandler checks the panic of the request decoder is converted to the 500 response.
+func TestRecoveryHandler(t *testing.T) {
+	h, err := MakeHandlerRESTRecovery(service{}, RecoveryServerPanicLogger(log.NewNopLogger()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/get", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status: got %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+}
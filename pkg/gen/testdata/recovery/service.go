@@ -0,0 +1,20 @@
+package recovery
+
+import (
+	"context"
+	"net/http"
+)
+
+type Service interface {
+	Get(ctx context.Context, id int) (string, error)
+}
+
+type service struct{}
+
+func (service) Get(context.Context, int) (string, error) {
+	return "", nil
+}
+
+func decodeGetRequest(context.Context, *http.Request) (interface{}, error) {
+	panic("decode")
+}
@@ -0,0 +1,20 @@
+//+build swipe
+
+package recovery
+
+import (
+	"github.com/swipe-io/swipe/pkg/swipe"
+)
+
+func Swipe() {
+	swipe.Build(
+		swipe.Service((*Service)(nil),
+			swipe.Transport("http",
+				swipe.ClientEnable(),
+				swipe.MethodOptions(Service.Get,
+					swipe.ServerDecodeRequestFunc(decodeGetRequest),
+				),
+			),
+		),
+	)
+}
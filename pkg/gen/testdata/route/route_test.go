@@ -0,0 +1,30 @@
+package route
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestDefaultMethod checks the client uses the GET method of the server route,
+// if the method with the params has no Method option.
+func TestDefaultMethod(t *testing.T) {
+	h, err := MakeHandlerRESTRoute(service{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	c, err := NewClientRESTRoute(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := c.Get(context.Background(), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "7" {
+		t.Fatalf("get: got %q, want %q", id, "7")
+	}
+}
@@ -0,0 +1,16 @@
+package route
+
+import (
+	"context"
+	"strconv"
+)
+
+type Service interface {
+	Get(ctx context.Context, id int) (string, error)
+}
+
+type service struct{}
+
+func (service) Get(_ context.Context, id int) (string, error) {
+	return strconv.Itoa(id), nil
+}
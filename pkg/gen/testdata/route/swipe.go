@@ -0,0 +1,20 @@
+//+build swipe
+
+package route
+
+import (
+	"github.com/swipe-io/swipe/pkg/swipe"
+)
+
+func Swipe() {
+	swipe.Build(
+		swipe.Service((*Service)(nil),
+			swipe.Transport("http",
+				swipe.ClientEnable(),
+				swipe.MethodOptions(Service.Get,
+					swipe.QueryVars([]string{"id", "id"}),
+				),
+			),
+		),
+	)
+}
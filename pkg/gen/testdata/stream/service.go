@@ -0,0 +1,30 @@
+package stream
+
+import (
+	"context"
+)
+
+type Event struct {
+	ID int `json:"id"`
+}
+
+type Service interface {
+	Watch(ctx context.Context) (<-chan Event, error)
+}
+
+// service sends the first event and blocks until the call is canceled.
+type service struct{}
+
+func (service) Watch(ctx context.Context) (<-chan Event, error) {
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		select {
+		case events <- Event{ID: 1}:
+		case <-ctx.Done():
+			return
+		}
+		<-ctx.Done()
+	}()
+	return events, nil
+}
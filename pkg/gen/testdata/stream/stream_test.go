@@ -0,0 +1,41 @@
+package stream
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestStreamErrors checks the client reports the broken stream to the errors channel.
+func TestStreamErrors(t *testing.T) {
+	h, err := MakeHandlerRESTStream(service{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	errs := make(chan error, 1)
+	c, err := NewClientRESTStream(srv.URL, StreamWatchClientStreamErrors(errs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := c.Watch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := <-events; e.ID != 1 {
+		t.Fatalf("event: got %d, want 1", e.ID)
+	}
+	srv.CloseClientConnections()
+	for range events {
+	}
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Fatal("stream error: got nil")
+		}
+	default:
+		t.Fatal("stream error: not reported")
+	}
+}
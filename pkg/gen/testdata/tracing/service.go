@@ -0,0 +1,36 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrInvalidPassword = errors.New("invalid password")
+
+type User struct {
+	Name     string
+	Password string `log:"redact"`
+}
+
+type Service interface {
+	Login(ctx context.Context, name, password string, attempts int) (string, error)
+	Update(ctx context.Context, user User) error
+	Ping() error
+}
+
+type service struct{}
+
+func (service) Login(_ context.Context, name, password string, _ int) (string, error) {
+	if password != "secret" {
+		return "", ErrInvalidPassword
+	}
+	return "token:" + name, nil
+}
+
+func (service) Update(context.Context, User) error {
+	return nil
+}
+
+func (service) Ping() error {
+	return nil
+}
@@ -0,0 +1,89 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	testCases := []struct {
+		name       string
+		password   string
+		wantStatus codes.Code
+		wantDesc   string
+	}{
+		{name: "ok", password: "secret", wantStatus: codes.Unset},
+		{name: "error", password: "wrong", wantStatus: codes.Error, wantDesc: ErrInvalidPassword.Error()},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+			s := NewTracingMiddlewareTracing(service{}, provider.Tracer("test"))
+			_, err := s.Login(context.Background(), "alice", tc.password, 3)
+			if (err != nil) != (tc.wantStatus == codes.Error) {
+				t.Fatalf("error: got %v", err)
+			}
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("spans: got %d, want 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name != "Tracing.Login" {
+				t.Errorf("name: got %q, want %q", span.Name, "Tracing.Login")
+			}
+			want := map[attribute.Key]attribute.Value{
+				"name":     attribute.StringValue("alice"),
+				"password": attribute.StringValue("[REDACTED]"),
+				"attempts": attribute.Int64Value(3),
+			}
+			got := map[attribute.Key]attribute.Value{}
+			for _, kv := range span.Attributes {
+				got[kv.Key] = kv.Value
+			}
+			for k, v := range want {
+				if got[k] != v {
+					t.Errorf("attribute %s: got %v, want %v", k, got[k].Emit(), v.Emit())
+				}
+			}
+			if span.Status.Code != tc.wantStatus || span.Status.Description != tc.wantDesc {
+				t.Errorf("status: got %v %q, want %v %q", span.Status.Code, span.Status.Description, tc.wantStatus, tc.wantDesc)
+			}
+			if tc.wantStatus == codes.Error && len(span.Events) == 0 {
+				t.Error("the error is not recorded")
+			}
+		})
+	}
+}
+
+// TestTracingSkip checks the struct params are not added as the attributes and the methods without the context are not traced.
+func TestTracingSkip(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	s := NewTracingMiddlewareTracing(service{}, provider.Tracer("test"))
+	if err := s.Update(context.Background(), User{Name: "alice", Password: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Ping(); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("spans: got %d, want 1", len(spans))
+	}
+	if spans[0].Name != "Tracing.Update" {
+		t.Errorf("name: got %q, want %q", spans[0].Name, "Tracing.Update")
+	}
+	if len(spans[0].Attributes) != 0 {
+		t.Errorf("attributes: got %v, want none", spans[0].Attributes)
+	}
+}
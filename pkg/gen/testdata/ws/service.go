@@ -0,0 +1,31 @@
+package ws
+
+import (
+	"context"
+)
+
+type Service interface {
+	Echo(ctx context.Context, text string) (string, error)
+	Count(ctx context.Context, n int) (<-chan int, error)
+}
+
+type service struct{}
+
+func (service) Echo(_ context.Context, text string) (string, error) {
+	return text, nil
+}
+
+func (service) Count(ctx context.Context, n int) (<-chan int, error) {
+	events := make(chan int)
+	go func() {
+		defer close(events)
+		for i := 0; i < n; i++ {
+			select {
+			case events <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
@@ -0,0 +1,18 @@
+//+build swipe
+
+package ws
+
+import (
+	"github.com/swipe-io/swipe/pkg/swipe"
+)
+
+func Swipe() {
+	swipe.Build(
+		swipe.Service((*Service)(nil),
+			swipe.Transport("http",
+				swipe.ClientEnable(),
+				swipe.JSONRPC(swipe.JSONRPCPath("/rpc"), swipe.WebSocket("/ws")),
+			),
+		),
+	)
+}
@@ -0,0 +1,109 @@
+package ws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func newServer(t *testing.T, opts ...WsServerOption) *httptest.Server {
+	h, err := MakeHandlerJSONRPCWs(service{}, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return httptest.NewServer(h)
+}
+
+func wsURL(srv *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+}
+
+// TestWebSocketClient checks the client calls the method and receives the stream over the WebSocket connection.
+func TestWebSocketClient(t *testing.T) {
+	srv := newServer(t)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the server accepts only the same origin requests, the client sends no origin.
+	c, err := NewWebSocketClientJSONRPCWs(ctx, srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, err := c.Echo(ctx, "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "hello" {
+		t.Fatalf("echo: got %q, want %q", text, "hello")
+	}
+	events, err := c.Count(ctx, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []int
+	for i := range events {
+		got = append(got, i)
+	}
+	if len(got) != 3 || got[0] != 0 || got[2] != 2 {
+		t.Fatalf("count: got %v, want [0 1 2]", got)
+	}
+}
+
+// TestCheckOrigin checks the upgrade of the other origin is rejected unless the CheckOrigin option allows it.
+func TestCheckOrigin(t *testing.T) {
+	header := http.Header{"Origin": []string{"http://example.org"}}
+
+	srv := newServer(t)
+	defer srv.Close()
+	if conn, _, err := websocket.DefaultDialer.Dial(wsURL(srv), header); err == nil {
+		conn.Close()
+		t.Fatal("dial: want the error for the other origin")
+	}
+
+	srv = newServer(t, WsServerWebSocketCheckOrigin(func(*http.Request) bool { return true }))
+	defer srv.Close()
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL(srv), header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+}
+
+// TestReadLimit checks the connection is closed when the message exceeds the read limit.
+func TestReadLimit(t *testing.T) {
+	srv := newServer(t, WsServerWebSocketLimits(64, 1))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL(srv), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":1,"method":"echo","params":{"text":"a"}}`)); err != nil {
+		t.Fatal(err)
+	}
+	var resp struct {
+		Result string `json:"result"`
+	}
+	if err := conn.ReadJSON(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Result != "a" {
+		t.Fatalf("echo: got %q, want %q", resp.Result, "a")
+	}
+
+	long := `{"jsonrpc":"2.0","id":2,"method":"echo","params":{"text":"` + strings.Repeat("a", 64) + `"}}`
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(long)); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.ReadJSON(&resp); err == nil {
+		t.Fatal("read: want the error after the message exceeds the read limit")
+	}
+}
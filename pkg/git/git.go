@@ -133,7 +133,7 @@ func (g *GIT) GetTags() ([]Tag, error) {
 	out, err := g.exec("for-each-ref",
 		Args(
 			"--format",
-			"%(refname)"+separator+"%(subject)"+separator+"%(taggerdate:iso-strict)"+separator+"%(*authordate:iso-strict)"+separator+"%(objectname)",
+			"%(refname)"+separator+"%(subject)"+separator+"%(taggerdate:iso-strict)"+separator+"%(*authordate:iso-strict)"+separator+"%(objectname)"+separator+"%(authordate:iso-strict)",
 			"refs/tags",
 		),
 	)
@@ -144,13 +144,18 @@ func (g *GIT) GetTags() ([]Tag, error) {
 	var tags []Tag
 	for _, line := range lines {
 		tokens := strings.Split(line, separator)
-		if len(tokens) != 5 {
+		if len(tokens) != 6 {
 			continue
 		}
 		name := strings.Replace(tokens[0], "refs/tags/", "", 1)
 		subject := strings.TrimSpace(tokens[1])
 		hash := strings.TrimSpace(tokens[4])
-		date, err := parseDate(tokens[3])
+		dateStr := tokens[3]
+		if dateStr == "" {
+			// the lightweight tag refers to the commit, so the commit is not dereferenced.
+			dateStr = tokens[5]
+		}
+		date, err := parseDate(dateStr)
 		if err != nil {
 			return nil, err
 		}
@@ -167,6 +172,43 @@ func (g *GIT) GetTags() ([]Tag, error) {
 	return tags, nil
 }
 
+// HeadTags returns the names of the tags that point at HEAD.
+func (g *GIT) HeadTags() ([]string, error) {
+	out, err := g.exec("tag", Args("--points-at", "HEAD"))
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(out), nil
+}
+
+// PreviousTag returns the nearest tag reachable from the parent of HEAD, so the tags that point at HEAD are skipped.
+func (g *GIT) PreviousTag() (string, error) {
+	return g.exec("describe", Args("--tags", "--abbrev=0", "HEAD^"))
+}
+
+// IsCommit reports whether the revision names the commit, the package patterns are not revisions.
+func (g *GIT) IsCommit(rev string) bool {
+	_, err := g.exec("rev-parse", Args("--verify", "--quiet", rev+"^{commit}"))
+	return err == nil
+}
+
+// TopLevel returns the root directory of the working tree.
+func (g *GIT) TopLevel() (string, error) {
+	return g.exec("rev-parse", Args("--show-toplevel"))
+}
+
+// AddWorktree checks out the commit to the detached working tree in the path.
+func (g *GIT) AddWorktree(path, commit string) error {
+	_, err := g.exec("worktree", Args("add", "--detach", path, commit))
+	return err
+}
+
+// RemoveWorktree removes the working tree in the path.
+func (g *GIT) RemoveWorktree(path string) error {
+	_, err := g.exec("worktree", Args("remove", "--force", path))
+	return err
+}
+
 func parseDate(dateStr string) (time.Time, error) {
 	date, err := time.Parse(time.RFC3339, dateStr)
 	if err != nil {
@@ -0,0 +1,129 @@
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// newTestRepo creates the repository with the commits and the tags in the temporary directory
+// and changes the working directory to it, the returned function restores the working directory.
+func newTestRepo(t *testing.T, steps [][]string) func() {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir, err := ioutil.TempDir("", "swipe-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		_ = os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	cleanup := func() {
+		_ = os.Chdir(wd)
+		_ = os.RemoveAll(dir)
+	}
+	env := append(os.Environ(),
+		"GIT_AUTHOR_NAME=swipe", "GIT_AUTHOR_EMAIL=swipe@example.com",
+		"GIT_COMMITTER_NAME=swipe", "GIT_COMMITTER_EMAIL=swipe@example.com",
+		"GIT_CONFIG_NOSYSTEM=1", "HOME="+dir,
+	)
+	for _, args := range append([][]string{{"init", "-q"}}, steps...) {
+		cmd := exec.Command("git", args...)
+		cmd.Env = env
+		if out, err := cmd.CombinedOutput(); err != nil {
+			cleanup()
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	return cleanup
+}
+
+func commit(subject string) []string {
+	return []string{"commit", "-q", "--allow-empty", "-m", subject}
+}
+
+func TestGetTags(t *testing.T) {
+	defer newTestRepo(t, [][]string{
+		commit("first"),
+		{"tag", "-a", "-m", "annotated", "v1.0.0"},
+		commit("second"),
+		{"tag", "v1.1.0"},
+	})()
+	tags, err := NewGIT().GetTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag.Date.IsZero() {
+			t.Errorf("%s: the date is zero", tag.Name)
+		}
+		names = append(names, tag.Name)
+	}
+	sort.Strings(names)
+	if want := []string{"v1.0.0", "v1.1.0"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("got %v, want %v", names, want)
+	}
+}
+
+func TestPreviousTag(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps [][]string
+		want  string
+	}{
+		{
+			name:  "head tag skipped",
+			steps: [][]string{commit("first"), {"tag", "v1.0.0"}, commit("second"), {"tag", "v2.0.0"}},
+			want:  "v1.0.0",
+		},
+		{
+			name:  "untagged head",
+			steps: [][]string{commit("first"), {"tag", "-a", "-m", "annotated", "v1.0.0"}, commit("second"), commit("third")},
+			want:  "v1.0.0",
+		},
+		{
+			name:  "no tags",
+			steps: [][]string{commit("first"), commit("second")},
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer newTestRepo(t, tt.steps)()
+			got, _ := NewGIT().PreviousTag()
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCommit(t *testing.T) {
+	defer newTestRepo(t, [][]string{
+		commit("first"),
+		{"tag", "v1.0.0"},
+		{"branch", "release"},
+	})()
+	g := NewGIT()
+	for _, rev := range []string{"v1.0.0", "release", "HEAD"} {
+		if !g.IsCommit(rev) {
+			t.Errorf("%s: is not a commit", rev)
+		}
+	}
+	for _, rev := range []string{"github.com/x/y/...", "./...", "v2.0.0"} {
+		if g.IsCommit(rev) {
+			t.Errorf("%s: is a commit", rev)
+		}
+	}
+}
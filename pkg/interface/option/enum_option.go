@@ -0,0 +1,64 @@
+package option
+
+import (
+	"fmt"
+	stdtypes "go/types"
+
+	"github.com/swipe-io/swipe/pkg/domain/model"
+	"github.com/swipe-io/swipe/pkg/errors"
+	"github.com/swipe-io/swipe/pkg/parser"
+	"github.com/swipe-io/swipe/pkg/usecase/option"
+)
+
+type enumOption struct {
+	info model.GenerateInfo
+}
+
+func (g *enumOption) Parse(option *parser.Option) (interface{}, error) {
+	o := model.EnumOption{}
+
+	enumOpt := parser.MustOption(option.At("enum"))
+	enumPtr, ok := enumOpt.Value.Type().(*stdtypes.Pointer)
+	if !ok {
+		return nil, errors.NotePosition(enumOpt.Position,
+			fmt.Errorf("the Enum option is required must be a pointer to a named type; found %s", stdtypes.TypeString(enumOpt.Value.Type(), nil)))
+	}
+	named, ok := enumPtr.Elem().(*stdtypes.Named)
+	if !ok {
+		return nil, errors.NotePosition(enumOpt.Position,
+			fmt.Errorf("the Enum option is required must be a pointer to a named type; found %s", stdtypes.TypeString(enumOpt.Value.Type(), nil)))
+	}
+	if named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != g.info.Pkg.PkgPath {
+		return nil, errors.NotePosition(enumOpt.Position,
+			fmt.Errorf("the Enum option must be declared in the package of the %s type", stdtypes.TypeString(named, nil)))
+	}
+	var enums []model.Enum
+	if g.info.Enums != nil {
+		enums, _ = g.info.Enums.At(named).([]model.Enum)
+	}
+	if len(enums) == 0 {
+		return nil, errors.NotePosition(enumOpt.Position,
+			fmt.Errorf("the %s type has no const group", stdtypes.TypeString(named, nil)))
+	}
+	o.Type = named
+	// the consts with the same value are aliases, only the first one has the text form.
+	values := map[string]bool{}
+	for _, e := range enums {
+		if !values[e.Value] {
+			values[e.Value] = true
+			o.Enums = append(o.Enums, e)
+		}
+	}
+
+	if trimPrefixOpt, ok := option.At("EnumTrimPrefix"); ok {
+		o.TrimPrefix = trimPrefixOpt.Value.String()
+	}
+	if _, ok := option.At("EnumJSONValue"); ok {
+		o.JSONValue = true
+	}
+	return o, nil
+}
+
+func NewEnumOption(info model.GenerateInfo) option.Option {
+	return &enumOption{info: info}
+}
@@ -76,6 +76,11 @@ func (g *gatewayOption) Parse(option *parser.Option) (interface{}, error) {
 		so.TypeName = typeName
 		so.Iface = iface
 
+		so.Prefix = "/" + strcase.ToKebab(so.ID)
+		if prefixOpt, ok := serviceOpt.At("GatewayServicePrefix"); ok {
+			so.Prefix = "/" + stdstrings.Trim(prefixOpt.Value.String(), "/")
+		}
+
 		if methodOpt, ok := serviceOpt.At("GatewayServiceMethod"); ok {
 			mo, err := g.parseMethodOption(iface, methodOpt)
 			if err != nil {
@@ -85,6 +90,13 @@ func (g *gatewayOption) Parse(option *parser.Option) (interface{}, error) {
 		}
 		o.Services = append(o.Services, so)
 	}
+	if openapiDocOpt, ok := option.At("GatewayOpenapi"); ok {
+		openapiOpt, err := getOpenapiOption(openapiDocOpt)
+		if err != nil {
+			return nil, err
+		}
+		o.Openapi = openapiOpt
+	}
 	return o, nil
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/swipe-io/swipe/pkg/errors"
 	"github.com/swipe-io/swipe/pkg/graph"
 	"github.com/swipe-io/swipe/pkg/openapi"
+	"github.com/swipe-io/swipe/pkg/openrpc"
 	"github.com/swipe-io/swipe/pkg/parser"
 	"github.com/swipe-io/swipe/pkg/strings"
 	"github.com/swipe-io/swipe/pkg/types"
@@ -129,6 +130,10 @@ func (g *serviceOption) Parse(option *parser.Option) (interface{}, error) {
 		sig := m.Type().(*stdtypes.Signature)
 		comments, _ := g.info.CommentMap.At(m.Type()).([]string)
 
+		if _, ok := o.Transport.MethodOptions[m.Name()]; !ok && o.Transport.MethodOptions != nil {
+			o.Transport.MethodOptions[m.Name()] = o.Transport.DefaultMethodOptions
+		}
+
 		sm := model.ServiceMethod{
 			Type:     m,
 			T:        m.Type(),
@@ -184,11 +189,98 @@ func (g *serviceOption) Parse(option *parser.Option) (interface{}, error) {
 		for j := 0; j < sig.Results().Len()-resultOffset; j++ {
 			sm.Results = append(sm.Results, sig.Results().At(j))
 		}
+		for _, r := range sm.Results {
+			ch, ok := r.Type().(*stdtypes.Chan)
+			if !ok {
+				continue
+			}
+			switch {
+			case ch.Dir() != stdtypes.RecvOnly:
+				return nil, errors.NotePosition(serviceOpt.Position,
+					fmt.Errorf("the %s method channel result must be receive-only", m.Name()))
+			case len(sm.Results) > 1:
+				return nil, errors.NotePosition(serviceOpt.Position,
+					fmt.Errorf("the %s method channel result cannot be combined with other results", m.Name()))
+			case o.Transport.JsonRPC.Enable && !o.Transport.JsonRPC.WebSocket.Enable:
+				return nil, errors.NotePosition(serviceOpt.Position,
+					fmt.Errorf("the %s method channel result requires the WebSocket option for JSON RPC", m.Name()))
+			case o.Transport.FastHTTP:
+				return nil, errors.NotePosition(serviceOpt.Position,
+					fmt.Errorf("the %s method channel result is not supported by fasthttp", m.Name()))
+			case o.Transport.MethodOptions[m.Name()].Timeout.Enable:
+				return nil, errors.NotePosition(serviceOpt.Position,
+					fmt.Errorf("the Timeout option cannot be used with the %s method channel result", m.Name()))
+			}
+			sm.StreamElem = ch.Elem()
+		}
+		if o.Transport.MethodOptions[m.Name()].Notification {
+			switch {
+			case !o.Transport.JsonRPC.Enable:
+				return nil, errors.NotePosition(serviceOpt.Position,
+					fmt.Errorf("the Notification option of the %s method is supported only by JSON RPC", m.Name()))
+			case o.Transport.FastHTTP:
+				return nil, errors.NotePosition(serviceOpt.Position,
+					fmt.Errorf("the Notification option of the %s method is not supported by fasthttp", m.Name()))
+			case sm.StreamElem != nil:
+				return nil, errors.NotePosition(serviceOpt.Position,
+					fmt.Errorf("the Notification option cannot be used with the %s method channel result", m.Name()))
+			}
+		}
 		o.Methods = append(o.Methods, sm)
 	}
 
-	if _, ok := option.At("Logging"); ok {
-		o.Logging = true
+	if loggingOpt, ok := option.At("Logging"); ok {
+		o.Logging.Enable = true
+		o.Logging.Backend = "kit"
+		o.Logging.SuccessLevel = "info"
+		o.Logging.MethodOptions = map[string]model.LoggingMethodOption{}
+
+		if backendOpt, ok := loggingOpt.At("LoggerBackend"); ok {
+			o.Logging.Backend = backendOpt.Value.String()
+			switch o.Logging.Backend {
+			default:
+				return nil, errors.NotePosition(backendOpt.Position, fmt.Errorf("unknown logger backend %q, available: kit, zap, zerolog, slog", o.Logging.Backend))
+			case "kit", "zap", "zerolog", "slog":
+			}
+		}
+		if levelOpt, ok := loggingOpt.At("LogSuccessLevel"); ok {
+			o.Logging.SuccessLevel = levelOpt.Value.String()
+			switch o.Logging.SuccessLevel {
+			default:
+				return nil, errors.NotePosition(levelOpt.Position, fmt.Errorf("unknown log level %q, available: debug, info", o.Logging.SuccessLevel))
+			case "debug", "info":
+			}
+		}
+		o.Logging.DefaultMethodOptions = getLoggingMethodOptions(loggingOpt, model.LoggingMethodOption{})
+
+		if methods, ok := loggingOpt.Slice("LogMethodOptions"); ok {
+			for _, methodOpt := range methods {
+				signOpt := parser.MustOption(methodOpt.At("signature"))
+				fnSel, ok := signOpt.Value.Expr().(*ast.SelectorExpr)
+				if !ok {
+					return nil, errors.NotePosition(signOpt.Position, fmt.Errorf("the signature must be selector"))
+				}
+				baseMethodOpts, ok := o.Logging.MethodOptions[fnSel.Sel.Name]
+				if !ok {
+					baseMethodOpts = o.Logging.DefaultMethodOptions
+				}
+				o.Logging.MethodOptions[fnSel.Sel.Name] = getLoggingMethodOptions(methodOpt, baseMethodOpts)
+			}
+		}
+	}
+
+	if tracingOpt, ok := option.At("Tracing"); ok {
+		o.Tracing.Enable = true
+		o.Tracing.Redact = map[string]struct{}{}
+		if redactOpts, ok := tracingOpt.Slice("TracingRedact"); ok {
+			for _, redactOpt := range redactOpts {
+				if paramsOpt, ok := redactOpt.Slice("params"); ok {
+					for _, v := range paramsOpt {
+						o.Tracing.Redact[v.Value.String()] = struct{}{}
+					}
+				}
+			}
+		}
 	}
 
 	if instrumentingOpt, ok := option.At("Instrumenting"); ok {
@@ -199,6 +291,37 @@ func (g *serviceOption) Parse(option *parser.Option) (interface{}, error) {
 		if subsystem, ok := instrumentingOpt.At("subsystem"); ok {
 			o.Instrumenting.Subsystem = subsystem.Value.String()
 		}
+		o.Instrumenting.Backend = "prometheus"
+		if backendOpt, ok := instrumentingOpt.At("MetricsBackend"); ok {
+			o.Instrumenting.Backend = backendOpt.Value.String()
+			switch o.Instrumenting.Backend {
+			default:
+				return nil, errors.NotePosition(backendOpt.Position, fmt.Errorf("unknown metrics backend %q, available: prometheus, statsd, dogstatsd, expvar, none", o.Instrumenting.Backend))
+			case "prometheus", "statsd", "dogstatsd", "expvar", "none":
+			}
+		}
+		if bucketsOpt, ok := instrumentingOpt.At("InstrumentingBuckets"); ok {
+			if values, ok := bucketsOpt.Slice("buckets"); ok {
+				for _, v := range values {
+					o.Instrumenting.Buckets = append(o.Instrumenting.Buckets, v.Value.Expr())
+				}
+			}
+		}
+		o.Instrumenting.Labels = map[string]string{}
+		if labelOpts, ok := instrumentingOpt.Slice("InstrumentingLabel"); ok {
+			for _, labelOpt := range labelOpts {
+				switch o.Instrumenting.Backend {
+				case "statsd", "expvar":
+					return nil, errors.NotePosition(labelOpt.Position, fmt.Errorf("the %s metrics backend has no labels, remove the InstrumentingLabel option", o.Instrumenting.Backend))
+				}
+				nameOpt := parser.MustOption(labelOpt.At("name"))
+				valueOpt := parser.MustOption(labelOpt.At("value"))
+				o.Instrumenting.Labels[nameOpt.Value.String()] = valueOpt.Value.String()
+			}
+		}
+		if _, ok := instrumentingOpt.At("InstrumentingLegacyNames"); ok {
+			o.Instrumenting.LegacyNames = true
+		}
 	}
 
 	return o, nil
@@ -246,6 +369,9 @@ func (g *serviceOption) loadTransport(opt *parser.Option) (option model.Transpor
 		Openapi: model.OpenapiHTTPTransportOption{
 			Methods: map[string]*model.OpenapiMethodOption{},
 		},
+		PanicRecovery: model.PanicRecoveryHTTPTransportOption{
+			Enable: true,
+		},
 	}
 	if v, ok := opt.At("MarkdownDoc"); ok {
 		option.MarkdownDoc.Enable = true
@@ -257,74 +383,73 @@ func (g *serviceOption) loadTransport(opt *parser.Option) (option model.Transpor
 	if _, ok := opt.At("ServerDisabled"); ok {
 		option.ServerDisabled = true
 	}
+	if _, ok := opt.At("AccessLog"); ok {
+		option.AccessLog.Enable = true
+	}
+	if _, ok := opt.At("HTTPMetrics"); ok {
+		option.HTTPMetrics.Enable = true
+	}
+	if _, ok := opt.At("PanicRecoveryDisabled"); ok {
+		option.PanicRecovery.Enable = false
+	}
 	if openapiDocOpt, ok := opt.At("Openapi"); ok {
-		option.Openapi.Enable = true
-		if v, ok := openapiDocOpt.At("OpenapiOutput"); ok {
-			option.Openapi.Output = v.Value.String()
+		if option.Openapi, err = getOpenapiOption(openapiDocOpt); err != nil {
+			return option, err
 		}
-		if v, ok := openapiDocOpt.At("OpenapiInfo"); ok {
-			option.Openapi.Info = openapi.Info{
+	}
+	if openrpcDocOpt, ok := opt.At("OpenRPC"); ok {
+		option.OpenRPC.Enable = true
+		if v, ok := openrpcDocOpt.At("OpenRPCOutput"); ok {
+			option.OpenRPC.Output = v.Value.String()
+		}
+		if v, ok := openrpcDocOpt.At("OpenRPCInfo"); ok {
+			option.OpenRPC.Info = openrpc.Info{
 				Title:       parser.MustOption(v.At("title")).Value.String(),
 				Description: parser.MustOption(v.At("description")).Value.String(),
 				Version:     parser.MustOption(v.At("version")).Value.String(),
 			}
 		}
-		if v, ok := openapiDocOpt.At("OpenapiContact"); ok {
-			option.Openapi.Info.Contact = &openapi.Contact{
-				Name:  parser.MustOption(v.At("name")).Value.String(),
-				Email: parser.MustOption(v.At("email")).Value.String(),
-				URL:   parser.MustOption(v.At("url")).Value.String(),
-			}
-		}
-		if v, ok := openapiDocOpt.At("OpenapiLicence"); ok {
-			option.Openapi.Info.License = &openapi.License{
-				Name: parser.MustOption(v.At("name")).Value.String(),
-				URL:  parser.MustOption(v.At("url")).Value.String(),
-			}
-		}
-		if s, ok := openapiDocOpt.Slice("OpenapiServer"); ok {
+		if s, ok := openrpcDocOpt.Slice("OpenRPCServer"); ok {
 			for _, v := range s {
-				option.Openapi.Servers = append(option.Openapi.Servers, openapi.Server{
-					Description: parser.MustOption(v.At("description")).Value.String(),
-					URL:         parser.MustOption(v.At("url")).Value.String(),
+				option.OpenRPC.Servers = append(option.OpenRPC.Servers, openrpc.Server{
+					Name: parser.MustOption(v.At("name")).Value.String(),
+					URL:  parser.MustOption(v.At("url")).Value.String(),
 				})
 			}
 		}
-		if openapiTags, ok := openapiDocOpt.Slice("OpenapiTags"); ok {
-			for _, openapiTagsOpt := range openapiTags {
-				var methods []string
-				if methodsOpt, ok := openapiTagsOpt.At("methods"); ok {
-					for _, expr := range methodsOpt.Value.ExprSlice() {
-						fnSel, ok := expr.(*ast.SelectorExpr)
-						if !ok {
-							return option, errors.NotePosition(methodsOpt.Position, fmt.Errorf("the %s value must be func selector", methodsOpt.Name))
-						}
-						methods = append(methods, fnSel.Sel.Name)
-						if _, ok := option.Openapi.Methods[fnSel.Sel.Name]; !ok {
-							option.Openapi.Methods[fnSel.Sel.Name] = &model.OpenapiMethodOption{}
-						}
-					}
-				}
-				if tagsOpt, ok := openapiTagsOpt.At("tags"); ok {
-					if len(methods) > 0 {
-						for _, method := range methods {
-							option.Openapi.Methods[method].Tags = append(option.Openapi.Methods[method].Tags, tagsOpt.Value.StringSlice()...)
-						}
-					} else {
-						option.Openapi.DefaultMethod.Tags = append(option.Openapi.DefaultMethod.Tags, tagsOpt.Value.StringSlice()...)
-					}
-				}
-			}
+		if _, ok := openrpcDocOpt.At("OpenRPCDiscover"); ok {
+			option.OpenRPC.Discover = true
 		}
-		if option.Openapi.Output == "" {
-			option.Openapi.Output = "./"
+		if option.OpenRPC.Output == "" {
+			option.OpenRPC.Output = "./"
+		}
+	}
+	if authOpt, ok := opt.At("Auth"); ok {
+		option.Auth.Enable = true
+		option.Auth.KeyFunc = parser.MustOption(authOpt.At("keyFunc")).Value.Expr()
+		if v, ok := authOpt.At("AuthClaimsFactory"); ok {
+			option.Auth.ClaimsFactory = v.Value.Expr()
 		}
 	}
+	if principalOpt, ok := opt.At("Principal"); ok {
+		option.Principal.Enable = true
+		option.Principal.Extractor = principalOpt.Value.Expr()
+	}
 	if jsonRpcOpt, ok := opt.At("JSONRPC"); ok {
 		option.JsonRPC.Enable = true
 		if path, ok := jsonRpcOpt.At("JSONRPCPath"); ok {
 			option.JsonRPC.Path = path.Value.String()
 		}
+		if webSocketOpt, ok := jsonRpcOpt.At("WebSocket"); ok {
+			if fastHTTP {
+				return option, errors.NotePosition(webSocketOpt.Position, fmt.Errorf("the WebSocket option is not supported by fasthttp"))
+			}
+			option.JsonRPC.WebSocket.Enable = true
+			option.JsonRPC.WebSocket.Path = webSocketOpt.Value.String()
+		}
+	}
+	if openrpcDocOpt, ok := opt.At("OpenRPC"); ok && !option.JsonRPC.Enable {
+		return option, errors.NotePosition(openrpcDocOpt.Position, fmt.Errorf("the OpenRPC option requires the JSONRPC option"))
 	}
 	if methodDefaultOpt, ok := opt.At("MethodDefaultOptions"); ok {
 		defaultMethodOptions, err := getMethodOptions(methodDefaultOpt, model.MethodHTTPTransportOption{})
@@ -341,7 +466,10 @@ func (g *serviceOption) loadTransport(opt *parser.Option) (option model.Transpor
 			if !ok {
 				return option, errors.NotePosition(signOpt.Position, fmt.Errorf("the signature must be selector"))
 			}
-			baseMethodOpts := option.MethodOptions[fnSel.Sel.Name]
+			baseMethodOpts, ok := option.MethodOptions[fnSel.Sel.Name]
+			if !ok {
+				baseMethodOpts = option.DefaultMethodOptions
+			}
 			mopt, err := getMethodOptions(methodOpt, baseMethodOpts)
 			if err != nil {
 				return option, err
@@ -350,6 +478,18 @@ func (g *serviceOption) loadTransport(opt *parser.Option) (option model.Transpor
 		}
 	}
 
+	if !option.Principal.Enable {
+		mopts := []model.MethodHTTPTransportOption{option.DefaultMethodOptions}
+		for _, mopt := range option.MethodOptions {
+			mopts = append(mopts, mopt)
+		}
+		for _, mopt := range mopts {
+			if len(mopt.Permissions) > 0 || len(mopt.Roles) > 0 {
+				return option, errors.NotePosition(opt.Position, fmt.Errorf("the Require and Roles options require the Principal option"))
+			}
+		}
+	}
+
 	option.Prefix = "REST"
 	if option.JsonRPC.Enable {
 		option.Prefix = "JSONRPC"
@@ -364,6 +504,135 @@ func NewServiceOption(info model.GenerateInfo) option.Option {
 	}
 }
 
+// getOpenapiOption returns the openapi doc option, it is shared by the Service and Gateway options.
+func getOpenapiOption(openapiDocOpt *parser.Option) (model.OpenapiHTTPTransportOption, error) {
+	o := model.OpenapiHTTPTransportOption{
+		Enable:  true,
+		Methods: map[string]*model.OpenapiMethodOption{},
+	}
+	if v, ok := openapiDocOpt.At("OpenapiOutput"); ok {
+		o.Output = v.Value.String()
+	}
+	if v, ok := openapiDocOpt.At("OpenapiInfo"); ok {
+		o.Info = openapi.Info{
+			Title:       parser.MustOption(v.At("title")).Value.String(),
+			Description: parser.MustOption(v.At("description")).Value.String(),
+			Version:     parser.MustOption(v.At("version")).Value.String(),
+		}
+	}
+	if v, ok := openapiDocOpt.At("OpenapiContact"); ok {
+		o.Info.Contact = &openapi.Contact{
+			Name:  parser.MustOption(v.At("name")).Value.String(),
+			Email: parser.MustOption(v.At("email")).Value.String(),
+			URL:   parser.MustOption(v.At("url")).Value.String(),
+		}
+	}
+	if v, ok := openapiDocOpt.At("OpenapiLicence"); ok {
+		o.Info.License = &openapi.License{
+			Name: parser.MustOption(v.At("name")).Value.String(),
+			URL:  parser.MustOption(v.At("url")).Value.String(),
+		}
+	}
+	if s, ok := openapiDocOpt.Slice("OpenapiServer"); ok {
+		for _, v := range s {
+			o.Servers = append(o.Servers, openapi.Server{
+				Description: parser.MustOption(v.At("description")).Value.String(),
+				URL:         parser.MustOption(v.At("url")).Value.String(),
+			})
+		}
+	}
+	if openapiTags, ok := openapiDocOpt.Slice("OpenapiTags"); ok {
+		for _, openapiTagsOpt := range openapiTags {
+			var methods []string
+			if methodsOpt, ok := openapiTagsOpt.At("methods"); ok {
+				for _, expr := range methodsOpt.Value.ExprSlice() {
+					fnSel, ok := expr.(*ast.SelectorExpr)
+					if !ok {
+						return o, errors.NotePosition(methodsOpt.Position, fmt.Errorf("the %s value must be func selector", methodsOpt.Name))
+					}
+					methods = append(methods, fnSel.Sel.Name)
+					if _, ok := o.Methods[fnSel.Sel.Name]; !ok {
+						o.Methods[fnSel.Sel.Name] = &model.OpenapiMethodOption{}
+					}
+				}
+			}
+			if tagsOpt, ok := openapiTagsOpt.At("tags"); ok {
+				if len(methods) > 0 {
+					for _, method := range methods {
+						o.Methods[method].Tags = append(o.Methods[method].Tags, tagsOpt.Value.StringSlice()...)
+					}
+				} else {
+					o.DefaultMethod.Tags = append(o.DefaultMethod.Tags, tagsOpt.Value.StringSlice()...)
+				}
+			}
+		}
+	}
+	if o.Output == "" {
+		o.Output = "./"
+	}
+	o.Format = "json"
+	if v, ok := openapiDocOpt.At("OpenapiFormat"); ok {
+		o.Format = v.Value.String()
+		if o.Format != "json" && o.Format != "yaml" {
+			return o, errors.NotePosition(v.Position, fmt.Errorf("the OpenapiFormat value must be \"json\" or \"yaml\"; found %q", o.Format))
+		}
+	}
+	o.Version = "3.0"
+	if v, ok := openapiDocOpt.At("OpenapiVersion"); ok {
+		o.Version = v.Value.String()
+		if o.Version != "3.0" && o.Version != "3.1" {
+			return o, errors.NotePosition(v.Position, fmt.Errorf("the OpenapiVersion value must be \"3.0\" or \"3.1\"; found %q", o.Version))
+		}
+	}
+	if _, ok := openapiDocOpt.At("OpenapiSplit"); ok {
+		o.Split = true
+	}
+	if v, ok := openapiDocOpt.At("OpenapiServe"); ok {
+		o.Serve = "/" + stdstrings.Trim(v.Value.String(), "/")
+	}
+	return o, nil
+}
+
+func getLoggingMethodOptions(opt *parser.Option, baseOpts model.LoggingMethodOption) model.LoggingMethodOption {
+	mopt := model.LoggingMethodOption{
+		Skip:          map[string]struct{}{},
+		Redact:        map[string]struct{}{},
+		MaxSize:       baseOpts.MaxSize,
+		SlowThreshold: baseOpts.SlowThreshold,
+	}
+	for name := range baseOpts.Skip {
+		mopt.Skip[name] = struct{}{}
+	}
+	for name := range baseOpts.Redact {
+		mopt.Redact[name] = struct{}{}
+	}
+	if skipOpts, ok := opt.Slice("LogSkip"); ok {
+		for _, skipOpt := range skipOpts {
+			if namesOpt, ok := skipOpt.Slice("names"); ok {
+				for _, v := range namesOpt {
+					mopt.Skip[v.Value.String()] = struct{}{}
+				}
+			}
+		}
+	}
+	if redactOpts, ok := opt.Slice("LogRedact"); ok {
+		for _, redactOpt := range redactOpts {
+			if namesOpt, ok := redactOpt.Slice("names"); ok {
+				for _, v := range namesOpt {
+					mopt.Redact[v.Value.String()] = struct{}{}
+				}
+			}
+		}
+	}
+	if maxSizeOpt, ok := opt.At("LogMaxSize"); ok {
+		mopt.MaxSize = maxSizeOpt.Value.Int()
+	}
+	if slowThresholdOpt, ok := opt.At("LogSlowThreshold"); ok {
+		mopt.SlowThreshold = slowThresholdOpt.Value.Expr()
+	}
+	return mopt
+}
+
 func getMethodOptions(methodOpt *parser.Option, baseMethodOpts model.MethodHTTPTransportOption) (model.MethodHTTPTransportOption, error) {
 	if wrapResponseOpt, ok := methodOpt.At("WrapResponse"); ok {
 		baseMethodOpts.WrapResponse.Enable = true
@@ -421,6 +690,62 @@ func getMethodOptions(methodOpt *parser.Option, baseMethodOpts model.MethodHTTPT
 			baseMethodOpts.QueryVars[values[i]] = values[i+1]
 		}
 	}
+	if rateLimitOpt, ok := methodOpt.At("RateLimit"); ok {
+		baseMethodOpts.RateLimit.Enable = true
+		baseMethodOpts.RateLimit.RPS = parser.MustOption(rateLimitOpt.At("rps")).Value.Expr()
+		baseMethodOpts.RateLimit.Burst = parser.MustOption(rateLimitOpt.At("burst")).Value.Expr()
+	}
+	if circuitBreakerOpt, ok := methodOpt.At("CircuitBreaker"); ok {
+		baseMethodOpts.CircuitBreaker.Enable = true
+		if v, ok := circuitBreakerOpt.At("CircuitBreakerMaxRequests"); ok {
+			baseMethodOpts.CircuitBreaker.MaxRequests = v.Value.Expr()
+		}
+		if v, ok := circuitBreakerOpt.At("CircuitBreakerInterval"); ok {
+			baseMethodOpts.CircuitBreaker.Interval = v.Value.Expr()
+		}
+		if v, ok := circuitBreakerOpt.At("CircuitBreakerTimeout"); ok {
+			baseMethodOpts.CircuitBreaker.Timeout = v.Value.Expr()
+		}
+		if v, ok := circuitBreakerOpt.At("CircuitBreakerFailures"); ok {
+			baseMethodOpts.CircuitBreaker.Failures = v.Value.Expr()
+		}
+	}
+	if timeoutOpt, ok := methodOpt.At("Timeout"); ok {
+		baseMethodOpts.Timeout.Enable = true
+		baseMethodOpts.Timeout.Duration = timeoutOpt.Value.Expr()
+	}
+	if retryOpt, ok := methodOpt.At("Retry"); ok {
+		baseMethodOpts.Retry.Enable = true
+		baseMethodOpts.Retry.Max = parser.MustOption(retryOpt.At("max")).Value.Expr()
+		baseMethodOpts.Retry.Backoff = parser.MustOption(retryOpt.At("backoff")).Value.Expr()
+	}
+	if _, ok := methodOpt.At("Idempotent"); ok {
+		baseMethodOpts.Idempotent = true
+	}
+	if _, ok := methodOpt.At("Notification"); ok {
+		baseMethodOpts.Notification = true
+	}
+	if requireOpts, ok := methodOpt.Slice("Require"); ok {
+		for _, requireOpt := range requireOpts {
+			if permissionsOpt, ok := requireOpt.Slice("permissions"); ok {
+				for _, v := range permissionsOpt {
+					baseMethodOpts.Permissions = append(baseMethodOpts.Permissions[:len(baseMethodOpts.Permissions):len(baseMethodOpts.Permissions)], v.Value.String())
+				}
+			}
+		}
+	}
+	if rolesOpts, ok := methodOpt.Slice("Roles"); ok {
+		for _, rolesOpt := range rolesOpts {
+			if rolesOpt, ok := rolesOpt.Slice("roles"); ok {
+				for _, v := range rolesOpt {
+					baseMethodOpts.Roles = append(baseMethodOpts.Roles[:len(baseMethodOpts.Roles):len(baseMethodOpts.Roles)], v.Value.String())
+				}
+			}
+		}
+	}
+	if _, ok := methodOpt.At("Public"); ok {
+		baseMethodOpts.Public = true
+	}
 	if headerVars, ok := methodOpt.At("HeaderVars"); ok {
 		baseMethodOpts.HeaderVars = map[string]string{}
 		values := headerVars.Value.StringSlice()
@@ -26,23 +26,35 @@ type ExternalDocs struct {
 }
 
 type Tag struct {
-	Name         string       `yaml:"name,omitempty" json:"name,omitempty"`
-	Description  string       `yaml:"description,omitempty" json:"description,omitempty"`
-	ExternalDocs ExternalDocs `yaml:"externalDocs,omitempty" json:"externalDocs,omitempty"`
+	Name         string        `yaml:"name,omitempty" json:"name,omitempty"`
+	Description  string        `yaml:"description,omitempty" json:"description,omitempty"`
+	ExternalDocs *ExternalDocs `yaml:"externalDocs,omitempty" json:"externalDocs,omitempty"`
 }
 
 type Properties map[string]*Schema
 
 type Schema struct {
-	Description string      `yaml:"description,omitempty" json:"description,omitempty"`
-	Ref         string      `yaml:"$ref,omitempty" json:"$ref,omitempty"`
-	Type        string      `yaml:"type,omitempty" json:"type,omitempty"`
-	Format      string      `yaml:"format,omitempty" json:"format,omitempty"`
-	Properties  Properties  `yaml:"properties,omitempty" json:"properties,omitempty"`
-	Items       *Schema     `yaml:"items,omitempty" json:"items,omitempty"`
-	AnyOf       []Schema    `yaml:"anyOf,omitempty" json:"anyOf,omitempty"`
-	Enum        []string    `yaml:"enum,omitempty" json:"enum,omitempty"`
-	Example     interface{} `yaml:"example,omitempty" json:"example,omitempty"`
+	Description          string        `yaml:"description,omitempty" json:"description,omitempty"`
+	Ref                  string        `yaml:"$ref,omitempty" json:"$ref,omitempty"`
+	Type                 string        `yaml:"type,omitempty" json:"type,omitempty"`
+	Format               string        `yaml:"format,omitempty" json:"format,omitempty"`
+	Nullable             bool          `yaml:"nullable,omitempty" json:"nullable,omitempty"`
+	Required             []string      `yaml:"required,omitempty" json:"required,omitempty"`
+	Properties           Properties    `yaml:"properties,omitempty" json:"properties,omitempty"`
+	AdditionalProperties *Schema       `yaml:"additionalProperties,omitempty" json:"additionalProperties,omitempty"`
+	Items                *Schema       `yaml:"items,omitempty" json:"items,omitempty"`
+	AllOf                []Schema      `yaml:"allOf,omitempty" json:"allOf,omitempty"`
+	AnyOf                []Schema      `yaml:"anyOf,omitempty" json:"anyOf,omitempty"`
+	Enum                 []interface{} `yaml:"enum,omitempty" json:"enum,omitempty"`
+	EnumVarNames         []string      `yaml:"x-enum-varnames,omitempty" json:"x-enum-varnames,omitempty"`
+	Minimum              *float64      `yaml:"minimum,omitempty" json:"minimum,omitempty"`
+	Maximum              *float64      `yaml:"maximum,omitempty" json:"maximum,omitempty"`
+	MinLength            *uint64       `yaml:"minLength,omitempty" json:"minLength,omitempty"`
+	MaxLength            *uint64       `yaml:"maxLength,omitempty" json:"maxLength,omitempty"`
+	MinItems             *uint64       `yaml:"minItems,omitempty" json:"minItems,omitempty"`
+	MaxItems             *uint64       `yaml:"maxItems,omitempty" json:"maxItems,omitempty"`
+	Example              interface{}   `yaml:"example,omitempty" json:"example,omitempty"`
+	Examples             []interface{} `yaml:"examples,omitempty" json:"examples,omitempty"`
 }
 
 type Parameter struct {
@@ -73,22 +85,30 @@ type RequestBody struct {
 	Required    bool    `yaml:"required,omitempty" json:"required,omitempty"`
 }
 
+type Permissions struct {
+	Require []string `yaml:"require,omitempty" json:"require,omitempty"`
+	Roles   []string `yaml:"roles,omitempty" json:"roles,omitempty"`
+}
+
 type Operation struct {
-	Tags        []string     `yaml:"tags,omitempty" json:"tags,omitempty"`
-	Summary     string       `yaml:"summary,omitempty" json:"summary,omitempty"`
-	Description string       `yaml:"description,omitempty" json:"description,omitempty"`
-	OperationID string       `yaml:"operationId,omitempty" json:"operationId,omitempty"`
-	Consumes    []string     `yaml:"consumes,omitempty" json:"consumes,omitempty"`
-	Produces    []string     `yaml:"produces,omitempty" json:"produces,omitempty"`
-	Parameters  []Parameter  `yaml:"parameters,omitempty" json:"parameters,omitempty"`
-	RequestBody *RequestBody `yaml:"requestBody,omitempty" json:"requestBody,omitempty"`
-	Responses   Responses    `yaml:"responses,omitempty" json:"responses,omitempty"`
+	Tags        []string              `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Summary     string                `yaml:"summary,omitempty" json:"summary,omitempty"`
+	Description string                `yaml:"description,omitempty" json:"description,omitempty"`
+	OperationID string                `yaml:"operationId,omitempty" json:"operationId,omitempty"`
+	Consumes    []string              `yaml:"consumes,omitempty" json:"consumes,omitempty"`
+	Produces    []string              `yaml:"produces,omitempty" json:"produces,omitempty"`
+	Parameters  []Parameter           `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	RequestBody *RequestBody          `yaml:"requestBody,omitempty" json:"requestBody,omitempty"`
+	Responses   Responses             `yaml:"responses,omitempty" json:"responses,omitempty"`
+	Security    []SecurityRequirement `yaml:"security,omitempty" json:"security,omitempty"`
+	Permissions *Permissions          `yaml:"x-permissions,omitempty" json:"x-permissions,omitempty"`
 }
 
 type Path struct {
 	Ref         string     `yaml:"$ref,omitempty" json:"$ref,omitempty"`
 	Summary     string     `yaml:"summary,omitempty" json:"summary,omitempty"`
 	Description string     `yaml:"description,omitempty" json:"description,omitempty"`
+	Servers     []Server   `yaml:"servers,omitempty" json:"servers,omitempty"`
 	Get         *Operation `yaml:"get,omitempty" json:"get,omitempty"`
 	Post        *Operation `yaml:"post,omitempty" json:"post,omitempty"`
 	Patch       *Operation `yaml:"patch,omitempty" json:"patch,omitempty"`
@@ -110,8 +130,22 @@ type Server struct {
 
 type Schemas map[string]*Schema
 
+type SecurityScheme struct {
+	Type         string `yaml:"type,omitempty" json:"type,omitempty"`
+	Description  string `yaml:"description,omitempty" json:"description,omitempty"`
+	Name         string `yaml:"name,omitempty" json:"name,omitempty"`
+	In           string `yaml:"in,omitempty" json:"in,omitempty"`
+	Scheme       string `yaml:"scheme,omitempty" json:"scheme,omitempty"`
+	BearerFormat string `yaml:"bearerFormat,omitempty" json:"bearerFormat,omitempty"`
+}
+
+type SecuritySchemes map[string]*SecurityScheme
+
+type SecurityRequirement map[string][]string
+
 type Components struct {
-	Schemas Schemas `yaml:"schemas,omitempty" json:"schemas,omitempty"`
+	Schemas         Schemas         `yaml:"schemas,omitempty" json:"schemas,omitempty"`
+	SecuritySchemes SecuritySchemes `yaml:"securitySchemes,omitempty" json:"securitySchemes,omitempty"`
 }
 
 type OpenAPI struct {
@@ -45,6 +45,15 @@ func (j *Components) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 		}
 		buf.WriteByte(',')
 	}
+	if len(j.SecuritySchemes) != 0 {
+		buf.WriteString(`"securitySchemes":`)
+		/* Falling back. type=openapi.SecuritySchemes kind=map */
+		err = buf.Encode(j.SecuritySchemes)
+		if err != nil {
+			return err
+		}
+		buf.WriteByte(',')
+	}
 	buf.Rewind(1)
 	buf.WriteByte('}')
 	return nil
@@ -55,10 +64,14 @@ const (
 	ffjtComponentsnosuchkey
 
 	ffjtComponentsSchemas
+
+	ffjtComponentsSecuritySchemes
 )
 
 var ffjKeyComponentsSchemas = []byte("schemas")
 
+var ffjKeyComponentsSecuritySchemes = []byte("securitySchemes")
+
 // UnmarshalJSON umarshall json - template of ffjson
 func (j *Components) UnmarshalJSON(input []byte) error {
 	fs := fflib.NewFFLexer(input)
@@ -126,10 +139,21 @@ mainparse:
 						currentKey = ffjtComponentsSchemas
 						state = fflib.FFParse_want_colon
 						goto mainparse
+
+					} else if bytes.Equal(ffjKeyComponentsSecuritySchemes, kn) {
+						currentKey = ffjtComponentsSecuritySchemes
+						state = fflib.FFParse_want_colon
+						goto mainparse
 					}
 
 				}
 
+				if fflib.EqualFoldRight(ffjKeyComponentsSecuritySchemes, kn) {
+					currentKey = ffjtComponentsSecuritySchemes
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
 				if fflib.EqualFoldRight(ffjKeyComponentsSchemas, kn) {
 					currentKey = ffjtComponentsSchemas
 					state = fflib.FFParse_want_colon
@@ -156,6 +180,9 @@ mainparse:
 				case ffjtComponentsSchemas:
 					goto handle_Schemas
 
+				case ffjtComponentsSecuritySchemes:
+					goto handle_SecuritySchemes
+
 				case ffjtComponentsnosuchkey:
 					err = fs.SkipField(tok)
 					if err != nil {
@@ -275,6 +302,111 @@ handle_Schemas:
 	state = fflib.FFParse_after_value
 	goto mainparse
 
+handle_SecuritySchemes:
+
+	/* handler: j.SecuritySchemes type=openapi.SecuritySchemes kind=map quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_left_bracket && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for SecuritySchemes", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+			j.SecuritySchemes = nil
+		} else {
+
+			j.SecuritySchemes = make(map[string]*SecurityScheme, 0)
+
+			wantVal := true
+
+			for {
+
+				var k string
+
+				var tmpJSecuritySchemes *SecurityScheme
+
+				tok = fs.Scan()
+				if tok == fflib.FFTok_error {
+					goto tokerror
+				}
+				if tok == fflib.FFTok_right_bracket {
+					break
+				}
+
+				if tok == fflib.FFTok_comma {
+					if wantVal == true {
+						// TODO(pquerna): this isn't an ideal error message, this handles
+						// things like [,,,] as an array value.
+						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+					}
+					continue
+				} else {
+					wantVal = true
+				}
+
+				/* handler: k type=string kind=string quoted=false*/
+
+				{
+
+					{
+						if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+							return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+						}
+					}
+
+					if tok == fflib.FFTok_null {
+
+					} else {
+
+						outBuf := fs.Output.Bytes()
+
+						k = string(string(outBuf))
+
+					}
+				}
+
+				// Expect ':' after key
+				tok = fs.Scan()
+				if tok != fflib.FFTok_colon {
+					return fs.WrapErr(fmt.Errorf("wanted colon token, but got token: %v", tok))
+				}
+
+				tok = fs.Scan()
+				/* handler: tmpJSecuritySchemes type=*openapi.SecurityScheme kind=ptr quoted=false*/
+
+				{
+					if tok == fflib.FFTok_null {
+
+						tmpJSecuritySchemes = nil
+
+					} else {
+
+						if tmpJSecuritySchemes == nil {
+							tmpJSecuritySchemes = new(SecurityScheme)
+						}
+
+						err = tmpJSecuritySchemes.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+						if err != nil {
+							return err
+						}
+					}
+					state = fflib.FFParse_after_value
+				}
+
+				j.SecuritySchemes[k] = tmpJSecuritySchemes
+
+				wantVal = false
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
 wantedvalue:
 	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
 wrongtokenerror:
@@ -2692,6 +2824,41 @@ func (j *Operation) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 		}
 		buf.WriteByte(',')
 	}
+	if len(j.Security) != 0 {
+		buf.WriteString(`"security":`)
+		if j.Security != nil {
+			buf.WriteString(`[`)
+			for i, v := range j.Security {
+				if i != 0 {
+					buf.WriteString(`,`)
+				}
+				/* Falling back. type=openapi.SecurityRequirement kind=map */
+				err = buf.Encode(v)
+				if err != nil {
+					return err
+				}
+			}
+			buf.WriteString(`]`)
+		} else {
+			buf.WriteString(`null`)
+		}
+		buf.WriteByte(',')
+	}
+	if j.Permissions != nil {
+		if true {
+			buf.WriteString(`"x-permissions":`)
+
+			{
+
+				err = j.Permissions.MarshalJSONBuf(buf)
+				if err != nil {
+					return err
+				}
+
+			}
+			buf.WriteByte(',')
+		}
+	}
 	buf.Rewind(1)
 	buf.WriteByte('}')
 	return nil
@@ -2718,6 +2885,10 @@ const (
 	ffjtOperationRequestBody
 
 	ffjtOperationResponses
+
+	ffjtOperationSecurity
+
+	ffjtOperationPermissions
 )
 
 var ffjKeyOperationTags = []byte("tags")
@@ -2738,6 +2909,10 @@ var ffjKeyOperationRequestBody = []byte("requestBody")
 
 var ffjKeyOperationResponses = []byte("responses")
 
+var ffjKeyOperationSecurity = []byte("security")
+
+var ffjKeyOperationPermissions = []byte("x-permissions")
+
 // UnmarshalJSON umarshall json - template of ffjson
 func (j *Operation) UnmarshalJSON(input []byte) error {
 	fs := fflib.NewFFLexer(input)
@@ -2855,6 +3030,11 @@ mainparse:
 						currentKey = ffjtOperationSummary
 						state = fflib.FFParse_want_colon
 						goto mainparse
+
+					} else if bytes.Equal(ffjKeyOperationSecurity, kn) {
+						currentKey = ffjtOperationSecurity
+						state = fflib.FFParse_want_colon
+						goto mainparse
 					}
 
 				case 't':
@@ -2865,6 +3045,26 @@ mainparse:
 						goto mainparse
 					}
 
+				case 'x':
+
+					if bytes.Equal(ffjKeyOperationPermissions, kn) {
+						currentKey = ffjtOperationPermissions
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				}
+
+				if fflib.EqualFoldRight(ffjKeyOperationPermissions, kn) {
+					currentKey = ffjtOperationPermissions
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeyOperationSecurity, kn) {
+					currentKey = ffjtOperationSecurity
+					state = fflib.FFParse_want_colon
+					goto mainparse
 				}
 
 				if fflib.EqualFoldRight(ffjKeyOperationResponses, kn) {
@@ -2965,6 +3165,12 @@ mainparse:
 				case ffjtOperationResponses:
 					goto handle_Responses
 
+				case ffjtOperationSecurity:
+					goto handle_Security
+
+				case ffjtOperationPermissions:
+					goto handle_Permissions
+
 				case ffjtOperationnosuchkey:
 					err = fs.SkipField(tok)
 					if err != nil {
@@ -3472,6 +3678,52 @@ handle_Responses:
 	state = fflib.FFParse_after_value
 	goto mainparse
 
+handle_Security:
+
+	/* handler: j.Security type=[]openapi.SecurityRequirement kind=slice quoted=false*/
+
+	{
+		/* Falling back. type=[]openapi.SecurityRequirement kind=slice */
+		tbuf, err := fs.CaptureField(tok)
+		if err != nil {
+			return fs.WrapErr(err)
+		}
+
+		err = json.Unmarshal(tbuf, &j.Security)
+		if err != nil {
+			return fs.WrapErr(err)
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Permissions:
+
+	/* handler: j.Permissions type=openapi.Permissions kind=struct quoted=false*/
+
+	{
+		if tok == fflib.FFTok_null {
+
+			j.Permissions = nil
+
+		} else {
+
+			if j.Permissions == nil {
+				j.Permissions = new(Permissions)
+			}
+
+			err = j.Permissions.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+			if err != nil {
+				return err
+			}
+		}
+		state = fflib.FFParse_after_value
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
 wantedvalue:
 	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
 wrongtokenerror:
@@ -4011,6 +4263,30 @@ func (j *Path) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 		fflib.WriteJsonString(buf, string(j.Description))
 		buf.WriteByte(',')
 	}
+	if len(j.Servers) != 0 {
+		buf.WriteString(`"servers":`)
+		if j.Servers != nil {
+			buf.WriteString(`[`)
+			for i, v := range j.Servers {
+				if i != 0 {
+					buf.WriteString(`,`)
+				}
+
+				{
+
+					err = v.MarshalJSONBuf(buf)
+					if err != nil {
+						return err
+					}
+
+				}
+			}
+			buf.WriteString(`]`)
+		} else {
+			buf.WriteString(`null`)
+		}
+		buf.WriteByte(',')
+	}
 	if j.Get != nil {
 		if true {
 			buf.WriteString(`"get":`)
@@ -4101,6 +4377,8 @@ const (
 
 	ffjtPathDescription
 
+	ffjtPathServers
+
 	ffjtPathGet
 
 	ffjtPathPost
@@ -4118,6 +4396,8 @@ var ffjKeyPathSummary = []byte("summary")
 
 var ffjKeyPathDescription = []byte("description")
 
+var ffjKeyPathServers = []byte("servers")
+
 var ffjKeyPathGet = []byte("get")
 
 var ffjKeyPathPost = []byte("post")
@@ -4242,6 +4522,11 @@ mainparse:
 						currentKey = ffjtPathSummary
 						state = fflib.FFParse_want_colon
 						goto mainparse
+
+					} else if bytes.Equal(ffjKeyPathServers, kn) {
+						currentKey = ffjtPathServers
+						state = fflib.FFParse_want_colon
+						goto mainparse
 					}
 
 				}
@@ -4276,6 +4561,12 @@ mainparse:
 					goto mainparse
 				}
 
+				if fflib.EqualFoldRight(ffjKeyPathServers, kn) {
+					currentKey = ffjtPathServers
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
 				if fflib.EqualFoldRight(ffjKeyPathDescription, kn) {
 					currentKey = ffjtPathDescription
 					state = fflib.FFParse_want_colon
@@ -4320,6 +4611,9 @@ mainparse:
 				case ffjtPathDescription:
 					goto handle_Description
 
+				case ffjtPathServers:
+					goto handle_Servers
+
 				case ffjtPathGet:
 					goto handle_Get
 
@@ -4427,33 +4721,101 @@ handle_Description:
 	state = fflib.FFParse_after_value
 	goto mainparse
 
-handle_Get:
+handle_Servers:
 
-	/* handler: j.Get type=openapi.Operation kind=struct quoted=false*/
+	/* handler: j.Servers type=[]openapi.Server kind=slice quoted=false*/
 
 	{
-		if tok == fflib.FFTok_null {
 
-			j.Get = nil
+		{
+			if tok != fflib.FFTok_left_brace && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for ", tok))
+			}
+		}
 
+		if tok == fflib.FFTok_null {
+			j.Servers = nil
 		} else {
 
-			if j.Get == nil {
-				j.Get = new(Operation)
-			}
+			j.Servers = []Server{}
 
-			err = j.Get.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
-			if err != nil {
-				return err
-			}
-		}
-		state = fflib.FFParse_after_value
-	}
+			wantVal := true
 
-	state = fflib.FFParse_after_value
-	goto mainparse
+			for {
 
-handle_Post:
+				var tmpJServers Server
+
+				tok = fs.Scan()
+				if tok == fflib.FFTok_error {
+					goto tokerror
+				}
+				if tok == fflib.FFTok_right_brace {
+					break
+				}
+
+				if tok == fflib.FFTok_comma {
+					if wantVal == true {
+						// TODO(pquerna): this isn't an ideal error message, this handles
+						// things like [,,,] as an array value.
+						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+					}
+					continue
+				} else {
+					wantVal = true
+				}
+
+				/* handler: tmpJServers type=openapi.Server kind=struct quoted=false*/
+
+				{
+					if tok == fflib.FFTok_null {
+
+					} else {
+
+						err = tmpJServers.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+						if err != nil {
+							return err
+						}
+					}
+					state = fflib.FFParse_after_value
+				}
+
+				j.Servers = append(j.Servers, tmpJServers)
+
+				wantVal = false
+			}
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Get:
+
+	/* handler: j.Get type=openapi.Operation kind=struct quoted=false*/
+
+	{
+		if tok == fflib.FFTok_null {
+
+			j.Get = nil
+
+		} else {
+
+			if j.Get == nil {
+				j.Get = new(Operation)
+			}
+
+			err = j.Get.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+			if err != nil {
+				return err
+			}
+		}
+		state = fflib.FFParse_after_value
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Post:
 
 	/* handler: j.Post type=openapi.Operation kind=struct quoted=false*/
 
@@ -4576,7 +4938,7 @@ done:
 }
 
 // MarshalJSON marshal bytes to json - template
-func (j *RequestBody) MarshalJSON() ([]byte, error) {
+func (j *Permissions) MarshalJSON() ([]byte, error) {
 	var buf fflib.Buffer
 	if j == nil {
 		buf.WriteString("null")
@@ -4590,7 +4952,7 @@ func (j *RequestBody) MarshalJSON() ([]byte, error) {
 }
 
 // MarshalJSONBuf marshal buff to json - template
-func (j *RequestBody) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
+func (j *Permissions) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 	if j == nil {
 		buf.WriteString("null")
 		return nil
@@ -4600,25 +4962,35 @@ func (j *RequestBody) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 	_ = obj
 	_ = err
 	buf.WriteString(`{ `)
-	if len(j.Description) != 0 {
-		buf.WriteString(`"description":`)
-		fflib.WriteJsonString(buf, string(j.Description))
-		buf.WriteByte(',')
-	}
-	if len(j.Content) != 0 {
-		buf.WriteString(`"content":`)
-		/* Falling back. type=openapi.Content kind=map */
-		err = buf.Encode(j.Content)
-		if err != nil {
-			return err
+	if len(j.Require) != 0 {
+		buf.WriteString(`"require":`)
+		if j.Require != nil {
+			buf.WriteString(`[`)
+			for i, v := range j.Require {
+				if i != 0 {
+					buf.WriteString(`,`)
+				}
+				fflib.WriteJsonString(buf, string(v))
+			}
+			buf.WriteString(`]`)
+		} else {
+			buf.WriteString(`null`)
 		}
 		buf.WriteByte(',')
 	}
-	if j.Required != false {
-		if j.Required {
-			buf.WriteString(`"required":true`)
+	if len(j.Roles) != 0 {
+		buf.WriteString(`"roles":`)
+		if j.Roles != nil {
+			buf.WriteString(`[`)
+			for i, v := range j.Roles {
+				if i != 0 {
+					buf.WriteString(`,`)
+				}
+				fflib.WriteJsonString(buf, string(v))
+			}
+			buf.WriteString(`]`)
 		} else {
-			buf.WriteString(`"required":false`)
+			buf.WriteString(`null`)
 		}
 		buf.WriteByte(',')
 	}
@@ -4628,32 +5000,28 @@ func (j *RequestBody) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 }
 
 const (
-	ffjtRequestBodybase = iota
-	ffjtRequestBodynosuchkey
-
-	ffjtRequestBodyDescription
+	ffjtPermissionsbase = iota
+	ffjtPermissionsnosuchkey
 
-	ffjtRequestBodyContent
+	ffjtPermissionsRequire
 
-	ffjtRequestBodyRequired
+	ffjtPermissionsRoles
 )
 
-var ffjKeyRequestBodyDescription = []byte("description")
-
-var ffjKeyRequestBodyContent = []byte("content")
+var ffjKeyPermissionsRequire = []byte("require")
 
-var ffjKeyRequestBodyRequired = []byte("required")
+var ffjKeyPermissionsRoles = []byte("roles")
 
 // UnmarshalJSON umarshall json - template of ffjson
-func (j *RequestBody) UnmarshalJSON(input []byte) error {
+func (j *Permissions) UnmarshalJSON(input []byte) error {
 	fs := fflib.NewFFLexer(input)
 	return j.UnmarshalJSONFFLexer(fs, fflib.FFParse_map_start)
 }
 
 // UnmarshalJSONFFLexer fast json unmarshall - template ffjson
-func (j *RequestBody) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
+func (j *Permissions) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
 	var err error
-	currentKey := ffjtRequestBodybase
+	currentKey := ffjtPermissionsbase
 	_ = currentKey
 	tok := fflib.FFTok_init
 	wantedTok := fflib.FFTok_init
@@ -4699,57 +5067,40 @@ mainparse:
 			kn := fs.Output.Bytes()
 			if len(kn) <= 0 {
 				// "" case. hrm.
-				currentKey = ffjtRequestBodynosuchkey
+				currentKey = ffjtPermissionsnosuchkey
 				state = fflib.FFParse_want_colon
 				goto mainparse
 			} else {
 				switch kn[0] {
 
-				case 'c':
-
-					if bytes.Equal(ffjKeyRequestBodyContent, kn) {
-						currentKey = ffjtRequestBodyContent
-						state = fflib.FFParse_want_colon
-						goto mainparse
-					}
-
-				case 'd':
+				case 'r':
 
-					if bytes.Equal(ffjKeyRequestBodyDescription, kn) {
-						currentKey = ffjtRequestBodyDescription
+					if bytes.Equal(ffjKeyPermissionsRequire, kn) {
+						currentKey = ffjtPermissionsRequire
 						state = fflib.FFParse_want_colon
 						goto mainparse
-					}
-
-				case 'r':
 
-					if bytes.Equal(ffjKeyRequestBodyRequired, kn) {
-						currentKey = ffjtRequestBodyRequired
+					} else if bytes.Equal(ffjKeyPermissionsRoles, kn) {
+						currentKey = ffjtPermissionsRoles
 						state = fflib.FFParse_want_colon
 						goto mainparse
 					}
 
 				}
 
-				if fflib.SimpleLetterEqualFold(ffjKeyRequestBodyRequired, kn) {
-					currentKey = ffjtRequestBodyRequired
-					state = fflib.FFParse_want_colon
-					goto mainparse
-				}
-
-				if fflib.SimpleLetterEqualFold(ffjKeyRequestBodyContent, kn) {
-					currentKey = ffjtRequestBodyContent
+				if fflib.EqualFoldRight(ffjKeyPermissionsRoles, kn) {
+					currentKey = ffjtPermissionsRoles
 					state = fflib.FFParse_want_colon
 					goto mainparse
 				}
 
-				if fflib.EqualFoldRight(ffjKeyRequestBodyDescription, kn) {
-					currentKey = ffjtRequestBodyDescription
+				if fflib.SimpleLetterEqualFold(ffjKeyPermissionsRequire, kn) {
+					currentKey = ffjtPermissionsRequire
 					state = fflib.FFParse_want_colon
 					goto mainparse
 				}
 
-				currentKey = ffjtRequestBodynosuchkey
+				currentKey = ffjtPermissionsnosuchkey
 				state = fflib.FFParse_want_colon
 				goto mainparse
 			}
@@ -4766,16 +5117,13 @@ mainparse:
 			if tok == fflib.FFTok_left_brace || tok == fflib.FFTok_left_bracket || tok == fflib.FFTok_integer || tok == fflib.FFTok_double || tok == fflib.FFTok_string || tok == fflib.FFTok_bool || tok == fflib.FFTok_null {
 				switch currentKey {
 
-				case ffjtRequestBodyDescription:
-					goto handle_Description
-
-				case ffjtRequestBodyContent:
-					goto handle_Content
+				case ffjtPermissionsRequire:
+					goto handle_Require
 
-				case ffjtRequestBodyRequired:
-					goto handle_Required
+				case ffjtPermissionsRoles:
+					goto handle_Roles
 
-				case ffjtRequestBodynosuchkey:
+				case ffjtPermissionsnosuchkey:
 					err = fs.SkipField(tok)
 					if err != nil {
 						return fs.WrapErr(err)
@@ -4789,63 +5137,109 @@ mainparse:
 		}
 	}
 
-handle_Description:
+handle_Require:
 
-	/* handler: j.Description type=string kind=string quoted=false*/
+	/* handler: j.Require type=[]string kind=slice quoted=false*/
 
 	{
 
 		{
-			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
-				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			if tok != fflib.FFTok_left_brace && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for ", tok))
 			}
 		}
 
 		if tok == fflib.FFTok_null {
-
+			j.Require = nil
 		} else {
 
-			outBuf := fs.Output.Bytes()
+			j.Require = []string{}
 
-			j.Description = string(string(outBuf))
+			wantVal := true
+
+			for {
+
+				var tmpJRequire string
+
+				tok = fs.Scan()
+				if tok == fflib.FFTok_error {
+					goto tokerror
+				}
+				if tok == fflib.FFTok_right_brace {
+					break
+				}
+
+				if tok == fflib.FFTok_comma {
+					if wantVal == true {
+						// TODO(pquerna): this isn't an ideal error message, this handles
+						// things like [,,,] as an array value.
+						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+					}
+					continue
+				} else {
+					wantVal = true
+				}
+
+				/* handler: tmpJRequire type=string kind=string quoted=false*/
+
+				{
 
+					{
+						if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+							return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+						}
+					}
+
+					if tok == fflib.FFTok_null {
+
+					} else {
+
+						outBuf := fs.Output.Bytes()
+
+						tmpJRequire = string(string(outBuf))
+
+					}
+				}
+
+				j.Require = append(j.Require, tmpJRequire)
+
+				wantVal = false
+			}
 		}
 	}
 
 	state = fflib.FFParse_after_value
 	goto mainparse
 
-handle_Content:
+handle_Roles:
 
-	/* handler: j.Content type=openapi.Content kind=map quoted=false*/
+	/* handler: j.Roles type=[]string kind=slice quoted=false*/
 
 	{
 
 		{
-			if tok != fflib.FFTok_left_bracket && tok != fflib.FFTok_null {
-				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for Content", tok))
+			if tok != fflib.FFTok_left_brace && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for ", tok))
 			}
 		}
 
 		if tok == fflib.FFTok_null {
-			j.Content = nil
+			j.Roles = nil
 		} else {
 
-			j.Content = make(map[string]Media, 0)
+			j.Roles = []string{}
 
 			wantVal := true
 
 			for {
 
-				var k string
-
-				var tmpJContent Media
+				var tmpJRoles string
 
 				tok = fs.Scan()
 				if tok == fflib.FFTok_error {
 					goto tokerror
 				}
-				if tok == fflib.FFTok_right_bracket {
+				if tok == fflib.FFTok_right_brace {
 					break
 				}
 
@@ -4860,7 +5254,7 @@ handle_Content:
 					wantVal = true
 				}
 
-				/* handler: k type=string kind=string quoted=false*/
+				/* handler: tmpJRoles type=string kind=string quoted=false*/
 
 				{
 
@@ -4876,73 +5270,15 @@ handle_Content:
 
 						outBuf := fs.Output.Bytes()
 
-						k = string(string(outBuf))
-
-					}
-				}
-
-				// Expect ':' after key
-				tok = fs.Scan()
-				if tok != fflib.FFTok_colon {
-					return fs.WrapErr(fmt.Errorf("wanted colon token, but got token: %v", tok))
-				}
-
-				tok = fs.Scan()
-				/* handler: tmpJContent type=openapi.Media kind=struct quoted=false*/
-
-				{
-					if tok == fflib.FFTok_null {
-
-					} else {
+						tmpJRoles = string(string(outBuf))
 
-						err = tmpJContent.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
-						if err != nil {
-							return err
-						}
 					}
-					state = fflib.FFParse_after_value
 				}
 
-				j.Content[k] = tmpJContent
+				j.Roles = append(j.Roles, tmpJRoles)
 
 				wantVal = false
 			}
-
-		}
-	}
-
-	state = fflib.FFParse_after_value
-	goto mainparse
-
-handle_Required:
-
-	/* handler: j.Required type=bool kind=bool quoted=false*/
-
-	{
-		if tok != fflib.FFTok_bool && tok != fflib.FFTok_null {
-			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for bool", tok))
-		}
-	}
-
-	{
-		if tok == fflib.FFTok_null {
-
-		} else {
-			tmpb := fs.Output.Bytes()
-
-			if bytes.Compare([]byte{'t', 'r', 'u', 'e'}, tmpb) == 0 {
-
-				j.Required = true
-
-			} else if bytes.Compare([]byte{'f', 'a', 'l', 's', 'e'}, tmpb) == 0 {
-
-				j.Required = false
-
-			} else {
-				err = errors.New("unexpected bytes for true/false value")
-				return fs.WrapErr(err)
-			}
-
 		}
 	}
 
@@ -4968,7 +5304,7 @@ done:
 }
 
 // MarshalJSON marshal bytes to json - template
-func (j *Response) MarshalJSON() ([]byte, error) {
+func (j *RequestBody) MarshalJSON() ([]byte, error) {
 	var buf fflib.Buffer
 	if j == nil {
 		buf.WriteString("null")
@@ -4982,7 +5318,7 @@ func (j *Response) MarshalJSON() ([]byte, error) {
 }
 
 // MarshalJSONBuf marshal buff to json - template
-func (j *Response) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
+func (j *RequestBody) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 	if j == nil {
 		buf.WriteString("null")
 		return nil
@@ -5006,34 +5342,46 @@ func (j *Response) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 		}
 		buf.WriteByte(',')
 	}
+	if j.Required != false {
+		if j.Required {
+			buf.WriteString(`"required":true`)
+		} else {
+			buf.WriteString(`"required":false`)
+		}
+		buf.WriteByte(',')
+	}
 	buf.Rewind(1)
 	buf.WriteByte('}')
 	return nil
 }
 
 const (
-	ffjtResponsebase = iota
-	ffjtResponsenosuchkey
+	ffjtRequestBodybase = iota
+	ffjtRequestBodynosuchkey
 
-	ffjtResponseDescription
+	ffjtRequestBodyDescription
 
-	ffjtResponseContent
+	ffjtRequestBodyContent
+
+	ffjtRequestBodyRequired
 )
 
-var ffjKeyResponseDescription = []byte("description")
+var ffjKeyRequestBodyDescription = []byte("description")
 
-var ffjKeyResponseContent = []byte("content")
+var ffjKeyRequestBodyContent = []byte("content")
+
+var ffjKeyRequestBodyRequired = []byte("required")
 
 // UnmarshalJSON umarshall json - template of ffjson
-func (j *Response) UnmarshalJSON(input []byte) error {
+func (j *RequestBody) UnmarshalJSON(input []byte) error {
 	fs := fflib.NewFFLexer(input)
 	return j.UnmarshalJSONFFLexer(fs, fflib.FFParse_map_start)
 }
 
 // UnmarshalJSONFFLexer fast json unmarshall - template ffjson
-func (j *Response) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
+func (j *RequestBody) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
 	var err error
-	currentKey := ffjtResponsebase
+	currentKey := ffjtRequestBodybase
 	_ = currentKey
 	tok := fflib.FFTok_init
 	wantedTok := fflib.FFTok_init
@@ -5079,7 +5427,7 @@ mainparse:
 			kn := fs.Output.Bytes()
 			if len(kn) <= 0 {
 				// "" case. hrm.
-				currentKey = ffjtResponsenosuchkey
+				currentKey = ffjtRequestBodynosuchkey
 				state = fflib.FFParse_want_colon
 				goto mainparse
 			} else {
@@ -5087,35 +5435,49 @@ mainparse:
 
 				case 'c':
 
-					if bytes.Equal(ffjKeyResponseContent, kn) {
-						currentKey = ffjtResponseContent
+					if bytes.Equal(ffjKeyRequestBodyContent, kn) {
+						currentKey = ffjtRequestBodyContent
 						state = fflib.FFParse_want_colon
 						goto mainparse
 					}
 
 				case 'd':
 
-					if bytes.Equal(ffjKeyResponseDescription, kn) {
-						currentKey = ffjtResponseDescription
+					if bytes.Equal(ffjKeyRequestBodyDescription, kn) {
+						currentKey = ffjtRequestBodyDescription
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'r':
+
+					if bytes.Equal(ffjKeyRequestBodyRequired, kn) {
+						currentKey = ffjtRequestBodyRequired
 						state = fflib.FFParse_want_colon
 						goto mainparse
 					}
 
 				}
 
-				if fflib.SimpleLetterEqualFold(ffjKeyResponseContent, kn) {
-					currentKey = ffjtResponseContent
+				if fflib.SimpleLetterEqualFold(ffjKeyRequestBodyRequired, kn) {
+					currentKey = ffjtRequestBodyRequired
 					state = fflib.FFParse_want_colon
 					goto mainparse
 				}
 
-				if fflib.EqualFoldRight(ffjKeyResponseDescription, kn) {
-					currentKey = ffjtResponseDescription
+				if fflib.SimpleLetterEqualFold(ffjKeyRequestBodyContent, kn) {
+					currentKey = ffjtRequestBodyContent
 					state = fflib.FFParse_want_colon
 					goto mainparse
 				}
 
-				currentKey = ffjtResponsenosuchkey
+				if fflib.EqualFoldRight(ffjKeyRequestBodyDescription, kn) {
+					currentKey = ffjtRequestBodyDescription
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				currentKey = ffjtRequestBodynosuchkey
 				state = fflib.FFParse_want_colon
 				goto mainparse
 			}
@@ -5132,13 +5494,16 @@ mainparse:
 			if tok == fflib.FFTok_left_brace || tok == fflib.FFTok_left_bracket || tok == fflib.FFTok_integer || tok == fflib.FFTok_double || tok == fflib.FFTok_string || tok == fflib.FFTok_bool || tok == fflib.FFTok_null {
 				switch currentKey {
 
-				case ffjtResponseDescription:
+				case ffjtRequestBodyDescription:
 					goto handle_Description
 
-				case ffjtResponseContent:
+				case ffjtRequestBodyContent:
 					goto handle_Content
 
-				case ffjtResponsenosuchkey:
+				case ffjtRequestBodyRequired:
+					goto handle_Required
+
+				case ffjtRequestBodynosuchkey:
 					err = fs.SkipField(tok)
 					if err != nil {
 						return fs.WrapErr(err)
@@ -5277,6 +5642,41 @@ handle_Content:
 	state = fflib.FFParse_after_value
 	goto mainparse
 
+handle_Required:
+
+	/* handler: j.Required type=bool kind=bool quoted=false*/
+
+	{
+		if tok != fflib.FFTok_bool && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for bool", tok))
+		}
+	}
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+			tmpb := fs.Output.Bytes()
+
+			if bytes.Compare([]byte{'t', 'r', 'u', 'e'}, tmpb) == 0 {
+
+				j.Required = true
+
+			} else if bytes.Compare([]byte{'f', 'a', 'l', 's', 'e'}, tmpb) == 0 {
+
+				j.Required = false
+
+			} else {
+				err = errors.New("unexpected bytes for true/false value")
+				return fs.WrapErr(err)
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
 wantedvalue:
 	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
 wrongtokenerror:
@@ -5296,7 +5696,7 @@ done:
 }
 
 // MarshalJSON marshal bytes to json - template
-func (j *Schema) MarshalJSON() ([]byte, error) {
+func (j *Response) MarshalJSON() ([]byte, error) {
 	var buf fflib.Buffer
 	if j == nil {
 		buf.WriteString("null")
@@ -5310,7 +5710,7 @@ func (j *Schema) MarshalJSON() ([]byte, error) {
 }
 
 // MarshalJSONBuf marshal buff to json - template
-func (j *Schema) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
+func (j *Response) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 	if j == nil {
 		buf.WriteString("null")
 		return nil
@@ -5325,89 +5725,10 @@ func (j *Schema) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 		fflib.WriteJsonString(buf, string(j.Description))
 		buf.WriteByte(',')
 	}
-	if len(j.Ref) != 0 {
-		buf.WriteString(`"$ref":`)
-		fflib.WriteJsonString(buf, string(j.Ref))
-		buf.WriteByte(',')
-	}
-	if len(j.Type) != 0 {
-		buf.WriteString(`"type":`)
-		fflib.WriteJsonString(buf, string(j.Type))
-		buf.WriteByte(',')
-	}
-	if len(j.Format) != 0 {
-		buf.WriteString(`"format":`)
-		fflib.WriteJsonString(buf, string(j.Format))
-		buf.WriteByte(',')
-	}
-	if len(j.Properties) != 0 {
-		buf.WriteString(`"properties":`)
-		/* Falling back. type=openapi.Properties kind=map */
-		err = buf.Encode(j.Properties)
-		if err != nil {
-			return err
-		}
-		buf.WriteByte(',')
-	}
-	if j.Items != nil {
-		if true {
-			buf.WriteString(`"items":`)
-
-			{
-
-				err = j.Items.MarshalJSONBuf(buf)
-				if err != nil {
-					return err
-				}
-
-			}
-			buf.WriteByte(',')
-		}
-	}
-	if len(j.AnyOf) != 0 {
-		buf.WriteString(`"anyOf":`)
-		if j.AnyOf != nil {
-			buf.WriteString(`[`)
-			for i, v := range j.AnyOf {
-				if i != 0 {
-					buf.WriteString(`,`)
-				}
-
-				{
-
-					err = v.MarshalJSONBuf(buf)
-					if err != nil {
-						return err
-					}
-
-				}
-			}
-			buf.WriteString(`]`)
-		} else {
-			buf.WriteString(`null`)
-		}
-		buf.WriteByte(',')
-	}
-	if len(j.Enum) != 0 {
-		buf.WriteString(`"enum":`)
-		if j.Enum != nil {
-			buf.WriteString(`[`)
-			for i, v := range j.Enum {
-				if i != 0 {
-					buf.WriteString(`,`)
-				}
-				fflib.WriteJsonString(buf, string(v))
-			}
-			buf.WriteString(`]`)
-		} else {
-			buf.WriteString(`null`)
-		}
-		buf.WriteByte(',')
-	}
-	if j.Example != nil {
-		buf.WriteString(`"example":`)
-		/* Interface types must use runtime reflection. type=interface {} kind=interface */
-		err = buf.Encode(j.Example)
+	if len(j.Content) != 0 {
+		buf.WriteString(`"content":`)
+		/* Falling back. type=openapi.Content kind=map */
+		err = buf.Encode(j.Content)
 		if err != nil {
 			return err
 		}
@@ -5419,56 +5740,28 @@ func (j *Schema) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 }
 
 const (
-	ffjtSchemabase = iota
-	ffjtSchemanosuchkey
-
-	ffjtSchemaDescription
-
-	ffjtSchemaRef
+	ffjtResponsebase = iota
+	ffjtResponsenosuchkey
 
-	ffjtSchemaType
+	ffjtResponseDescription
 
-	ffjtSchemaFormat
+	ffjtResponseContent
+)
 
-	ffjtSchemaProperties
+var ffjKeyResponseDescription = []byte("description")
 
-	ffjtSchemaItems
+var ffjKeyResponseContent = []byte("content")
 
-	ffjtSchemaAnyOf
-
-	ffjtSchemaEnum
-
-	ffjtSchemaExample
-)
-
-var ffjKeySchemaDescription = []byte("description")
-
-var ffjKeySchemaRef = []byte("$ref")
-
-var ffjKeySchemaType = []byte("type")
-
-var ffjKeySchemaFormat = []byte("format")
-
-var ffjKeySchemaProperties = []byte("properties")
-
-var ffjKeySchemaItems = []byte("items")
-
-var ffjKeySchemaAnyOf = []byte("anyOf")
-
-var ffjKeySchemaEnum = []byte("enum")
-
-var ffjKeySchemaExample = []byte("example")
-
-// UnmarshalJSON umarshall json - template of ffjson
-func (j *Schema) UnmarshalJSON(input []byte) error {
-	fs := fflib.NewFFLexer(input)
-	return j.UnmarshalJSONFFLexer(fs, fflib.FFParse_map_start)
-}
+// UnmarshalJSON umarshall json - template of ffjson
+func (j *Response) UnmarshalJSON(input []byte) error {
+	fs := fflib.NewFFLexer(input)
+	return j.UnmarshalJSONFFLexer(fs, fflib.FFParse_map_start)
+}
 
 // UnmarshalJSONFFLexer fast json unmarshall - template ffjson
-func (j *Schema) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
+func (j *Response) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
 	var err error
-	currentKey := ffjtSchemabase
+	currentKey := ffjtResponsebase
 	_ = currentKey
 	tok := fflib.FFTok_init
 	wantedTok := fflib.FFTok_init
@@ -5514,138 +5807,43 @@ mainparse:
 			kn := fs.Output.Bytes()
 			if len(kn) <= 0 {
 				// "" case. hrm.
-				currentKey = ffjtSchemanosuchkey
+				currentKey = ffjtResponsenosuchkey
 				state = fflib.FFParse_want_colon
 				goto mainparse
 			} else {
 				switch kn[0] {
 
-				case '$':
-
-					if bytes.Equal(ffjKeySchemaRef, kn) {
-						currentKey = ffjtSchemaRef
-						state = fflib.FFParse_want_colon
-						goto mainparse
-					}
-
-				case 'a':
+				case 'c':
 
-					if bytes.Equal(ffjKeySchemaAnyOf, kn) {
-						currentKey = ffjtSchemaAnyOf
+					if bytes.Equal(ffjKeyResponseContent, kn) {
+						currentKey = ffjtResponseContent
 						state = fflib.FFParse_want_colon
 						goto mainparse
 					}
 
 				case 'd':
 
-					if bytes.Equal(ffjKeySchemaDescription, kn) {
-						currentKey = ffjtSchemaDescription
-						state = fflib.FFParse_want_colon
-						goto mainparse
-					}
-
-				case 'e':
-
-					if bytes.Equal(ffjKeySchemaEnum, kn) {
-						currentKey = ffjtSchemaEnum
-						state = fflib.FFParse_want_colon
-						goto mainparse
-
-					} else if bytes.Equal(ffjKeySchemaExample, kn) {
-						currentKey = ffjtSchemaExample
-						state = fflib.FFParse_want_colon
-						goto mainparse
-					}
-
-				case 'f':
-
-					if bytes.Equal(ffjKeySchemaFormat, kn) {
-						currentKey = ffjtSchemaFormat
-						state = fflib.FFParse_want_colon
-						goto mainparse
-					}
-
-				case 'i':
-
-					if bytes.Equal(ffjKeySchemaItems, kn) {
-						currentKey = ffjtSchemaItems
-						state = fflib.FFParse_want_colon
-						goto mainparse
-					}
-
-				case 'p':
-
-					if bytes.Equal(ffjKeySchemaProperties, kn) {
-						currentKey = ffjtSchemaProperties
-						state = fflib.FFParse_want_colon
-						goto mainparse
-					}
-
-				case 't':
-
-					if bytes.Equal(ffjKeySchemaType, kn) {
-						currentKey = ffjtSchemaType
+					if bytes.Equal(ffjKeyResponseDescription, kn) {
+						currentKey = ffjtResponseDescription
 						state = fflib.FFParse_want_colon
 						goto mainparse
 					}
 
 				}
 
-				if fflib.SimpleLetterEqualFold(ffjKeySchemaExample, kn) {
-					currentKey = ffjtSchemaExample
-					state = fflib.FFParse_want_colon
-					goto mainparse
-				}
-
-				if fflib.SimpleLetterEqualFold(ffjKeySchemaEnum, kn) {
-					currentKey = ffjtSchemaEnum
-					state = fflib.FFParse_want_colon
-					goto mainparse
-				}
-
-				if fflib.SimpleLetterEqualFold(ffjKeySchemaAnyOf, kn) {
-					currentKey = ffjtSchemaAnyOf
-					state = fflib.FFParse_want_colon
-					goto mainparse
-				}
-
-				if fflib.EqualFoldRight(ffjKeySchemaItems, kn) {
-					currentKey = ffjtSchemaItems
-					state = fflib.FFParse_want_colon
-					goto mainparse
-				}
-
-				if fflib.EqualFoldRight(ffjKeySchemaProperties, kn) {
-					currentKey = ffjtSchemaProperties
-					state = fflib.FFParse_want_colon
-					goto mainparse
-				}
-
-				if fflib.SimpleLetterEqualFold(ffjKeySchemaFormat, kn) {
-					currentKey = ffjtSchemaFormat
-					state = fflib.FFParse_want_colon
-					goto mainparse
-				}
-
-				if fflib.SimpleLetterEqualFold(ffjKeySchemaType, kn) {
-					currentKey = ffjtSchemaType
-					state = fflib.FFParse_want_colon
-					goto mainparse
-				}
-
-				if fflib.AsciiEqualFold(ffjKeySchemaRef, kn) {
-					currentKey = ffjtSchemaRef
+				if fflib.SimpleLetterEqualFold(ffjKeyResponseContent, kn) {
+					currentKey = ffjtResponseContent
 					state = fflib.FFParse_want_colon
 					goto mainparse
 				}
 
-				if fflib.EqualFoldRight(ffjKeySchemaDescription, kn) {
-					currentKey = ffjtSchemaDescription
+				if fflib.EqualFoldRight(ffjKeyResponseDescription, kn) {
+					currentKey = ffjtResponseDescription
 					state = fflib.FFParse_want_colon
 					goto mainparse
 				}
 
-				currentKey = ffjtSchemanosuchkey
+				currentKey = ffjtResponsenosuchkey
 				state = fflib.FFParse_want_colon
 				goto mainparse
 			}
@@ -5662,34 +5860,13 @@ mainparse:
 			if tok == fflib.FFTok_left_brace || tok == fflib.FFTok_left_bracket || tok == fflib.FFTok_integer || tok == fflib.FFTok_double || tok == fflib.FFTok_string || tok == fflib.FFTok_bool || tok == fflib.FFTok_null {
 				switch currentKey {
 
-				case ffjtSchemaDescription:
+				case ffjtResponseDescription:
 					goto handle_Description
 
-				case ffjtSchemaRef:
-					goto handle_Ref
-
-				case ffjtSchemaType:
-					goto handle_Type
-
-				case ffjtSchemaFormat:
-					goto handle_Format
-
-				case ffjtSchemaProperties:
-					goto handle_Properties
-
-				case ffjtSchemaItems:
-					goto handle_Items
-
-				case ffjtSchemaAnyOf:
-					goto handle_AnyOf
-
-				case ffjtSchemaEnum:
-					goto handle_Enum
-
-				case ffjtSchemaExample:
-					goto handle_Example
+				case ffjtResponseContent:
+					goto handle_Content
 
-				case ffjtSchemanosuchkey:
+				case ffjtResponsenosuchkey:
 					err = fs.SkipField(tok)
 					if err != nil {
 						return fs.WrapErr(err)
@@ -5729,66 +5906,990 @@ handle_Description:
 	state = fflib.FFParse_after_value
 	goto mainparse
 
-handle_Ref:
+handle_Content:
 
-	/* handler: j.Ref type=string kind=string quoted=false*/
+	/* handler: j.Content type=openapi.Content kind=map quoted=false*/
 
 	{
 
 		{
-			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
-				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			if tok != fflib.FFTok_left_bracket && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for Content", tok))
 			}
 		}
 
 		if tok == fflib.FFTok_null {
-
+			j.Content = nil
 		} else {
 
-			outBuf := fs.Output.Bytes()
+			j.Content = make(map[string]Media, 0)
 
-			j.Ref = string(string(outBuf))
+			wantVal := true
 
-		}
-	}
+			for {
 
-	state = fflib.FFParse_after_value
-	goto mainparse
+				var k string
 
-handle_Type:
+				var tmpJContent Media
 
-	/* handler: j.Type type=string kind=string quoted=false*/
+				tok = fs.Scan()
+				if tok == fflib.FFTok_error {
+					goto tokerror
+				}
+				if tok == fflib.FFTok_right_bracket {
+					break
+				}
 
-	{
+				if tok == fflib.FFTok_comma {
+					if wantVal == true {
+						// TODO(pquerna): this isn't an ideal error message, this handles
+						// things like [,,,] as an array value.
+						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+					}
+					continue
+				} else {
+					wantVal = true
+				}
 
-		{
-			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
-				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
-			}
-		}
+				/* handler: k type=string kind=string quoted=false*/
 
-		if tok == fflib.FFTok_null {
+				{
 
-		} else {
+					{
+						if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+							return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+						}
+					}
 
-			outBuf := fs.Output.Bytes()
+					if tok == fflib.FFTok_null {
 
-			j.Type = string(string(outBuf))
+					} else {
 
-		}
-	}
+						outBuf := fs.Output.Bytes()
 
-	state = fflib.FFParse_after_value
-	goto mainparse
+						k = string(string(outBuf))
 
-handle_Format:
+					}
+				}
 
-	/* handler: j.Format type=string kind=string quoted=false*/
+				// Expect ':' after key
+				tok = fs.Scan()
+				if tok != fflib.FFTok_colon {
+					return fs.WrapErr(fmt.Errorf("wanted colon token, but got token: %v", tok))
+				}
 
-	{
+				tok = fs.Scan()
+				/* handler: tmpJContent type=openapi.Media kind=struct quoted=false*/
 
-		{
-			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				{
+					if tok == fflib.FFTok_null {
+
+					} else {
+
+						err = tmpJContent.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+						if err != nil {
+							return err
+						}
+					}
+					state = fflib.FFParse_after_value
+				}
+
+				j.Content[k] = tmpJContent
+
+				wantVal = false
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+wantedvalue:
+	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+wrongtokenerror:
+	return fs.WrapErr(fmt.Errorf("ffjson: wanted token: %v, but got token: %v output=%s", wantedTok, tok, fs.Output.String()))
+tokerror:
+	if fs.BigError != nil {
+		return fs.WrapErr(fs.BigError)
+	}
+	err = fs.Error.ToError()
+	if err != nil {
+		return fs.WrapErr(err)
+	}
+	panic("ffjson-generated: unreachable, please report bug.")
+done:
+
+	return nil
+}
+
+// MarshalJSON marshal bytes to json - template
+func (j *Schema) MarshalJSON() ([]byte, error) {
+	var buf fflib.Buffer
+	if j == nil {
+		buf.WriteString("null")
+		return buf.Bytes(), nil
+	}
+	err := j.MarshalJSONBuf(&buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSONBuf marshal buff to json - template
+func (j *Schema) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
+	if j == nil {
+		buf.WriteString("null")
+		return nil
+	}
+	var err error
+	var obj []byte
+	_ = obj
+	_ = err
+	buf.WriteString(`{ `)
+	if len(j.Description) != 0 {
+		buf.WriteString(`"description":`)
+		fflib.WriteJsonString(buf, string(j.Description))
+		buf.WriteByte(',')
+	}
+	if len(j.Ref) != 0 {
+		buf.WriteString(`"$ref":`)
+		fflib.WriteJsonString(buf, string(j.Ref))
+		buf.WriteByte(',')
+	}
+	if len(j.Type) != 0 {
+		buf.WriteString(`"type":`)
+		fflib.WriteJsonString(buf, string(j.Type))
+		buf.WriteByte(',')
+	}
+	if len(j.Format) != 0 {
+		buf.WriteString(`"format":`)
+		fflib.WriteJsonString(buf, string(j.Format))
+		buf.WriteByte(',')
+	}
+	if j.Nullable != false {
+		if j.Nullable {
+			buf.WriteString(`"nullable":true`)
+		} else {
+			buf.WriteString(`"nullable":false`)
+		}
+		buf.WriteByte(',')
+	}
+	if len(j.Required) != 0 {
+		buf.WriteString(`"required":`)
+		if j.Required != nil {
+			buf.WriteString(`[`)
+			for i, v := range j.Required {
+				if i != 0 {
+					buf.WriteString(`,`)
+				}
+				fflib.WriteJsonString(buf, string(v))
+			}
+			buf.WriteString(`]`)
+		} else {
+			buf.WriteString(`null`)
+		}
+		buf.WriteByte(',')
+	}
+	if len(j.Properties) != 0 {
+		buf.WriteString(`"properties":`)
+		/* Falling back. type=openapi.Properties kind=map */
+		err = buf.Encode(j.Properties)
+		if err != nil {
+			return err
+		}
+		buf.WriteByte(',')
+	}
+	if j.AdditionalProperties != nil {
+		if true {
+			buf.WriteString(`"additionalProperties":`)
+
+			{
+
+				err = j.AdditionalProperties.MarshalJSONBuf(buf)
+				if err != nil {
+					return err
+				}
+
+			}
+			buf.WriteByte(',')
+		}
+	}
+	if j.Items != nil {
+		if true {
+			buf.WriteString(`"items":`)
+
+			{
+
+				err = j.Items.MarshalJSONBuf(buf)
+				if err != nil {
+					return err
+				}
+
+			}
+			buf.WriteByte(',')
+		}
+	}
+	if len(j.AllOf) != 0 {
+		buf.WriteString(`"allOf":`)
+		if j.AllOf != nil {
+			buf.WriteString(`[`)
+			for i, v := range j.AllOf {
+				if i != 0 {
+					buf.WriteString(`,`)
+				}
+
+				{
+
+					err = v.MarshalJSONBuf(buf)
+					if err != nil {
+						return err
+					}
+
+				}
+			}
+			buf.WriteString(`]`)
+		} else {
+			buf.WriteString(`null`)
+		}
+		buf.WriteByte(',')
+	}
+	if len(j.AnyOf) != 0 {
+		buf.WriteString(`"anyOf":`)
+		if j.AnyOf != nil {
+			buf.WriteString(`[`)
+			for i, v := range j.AnyOf {
+				if i != 0 {
+					buf.WriteString(`,`)
+				}
+
+				{
+
+					err = v.MarshalJSONBuf(buf)
+					if err != nil {
+						return err
+					}
+
+				}
+			}
+			buf.WriteString(`]`)
+		} else {
+			buf.WriteString(`null`)
+		}
+		buf.WriteByte(',')
+	}
+	if len(j.Enum) != 0 {
+		buf.WriteString(`"enum":`)
+		if j.Enum != nil {
+			buf.WriteString(`[`)
+			for i, v := range j.Enum {
+				if i != 0 {
+					buf.WriteString(`,`)
+				}
+				/* Interface types must use runtime reflection. type=interface {} kind=interface */
+				err = buf.Encode(v)
+				if err != nil {
+					return err
+				}
+			}
+			buf.WriteString(`]`)
+		} else {
+			buf.WriteString(`null`)
+		}
+		buf.WriteByte(',')
+	}
+	if len(j.EnumVarNames) != 0 {
+		buf.WriteString(`"x-enum-varnames":`)
+		if j.EnumVarNames != nil {
+			buf.WriteString(`[`)
+			for i, v := range j.EnumVarNames {
+				if i != 0 {
+					buf.WriteString(`,`)
+				}
+				fflib.WriteJsonString(buf, string(v))
+			}
+			buf.WriteString(`]`)
+		} else {
+			buf.WriteString(`null`)
+		}
+		buf.WriteByte(',')
+	}
+	if j.Minimum != nil {
+		if true {
+			buf.WriteString(`"minimum":`)
+			fflib.AppendFloat(buf, float64(*j.Minimum), 'g', -1, 64)
+			buf.WriteByte(',')
+		}
+	}
+	if j.Maximum != nil {
+		if true {
+			buf.WriteString(`"maximum":`)
+			fflib.AppendFloat(buf, float64(*j.Maximum), 'g', -1, 64)
+			buf.WriteByte(',')
+		}
+	}
+	if j.MinLength != nil {
+		if true {
+			buf.WriteString(`"minLength":`)
+			fflib.FormatBits2(buf, uint64(*j.MinLength), 10, false)
+			buf.WriteByte(',')
+		}
+	}
+	if j.MaxLength != nil {
+		if true {
+			buf.WriteString(`"maxLength":`)
+			fflib.FormatBits2(buf, uint64(*j.MaxLength), 10, false)
+			buf.WriteByte(',')
+		}
+	}
+	if j.MinItems != nil {
+		if true {
+			buf.WriteString(`"minItems":`)
+			fflib.FormatBits2(buf, uint64(*j.MinItems), 10, false)
+			buf.WriteByte(',')
+		}
+	}
+	if j.MaxItems != nil {
+		if true {
+			buf.WriteString(`"maxItems":`)
+			fflib.FormatBits2(buf, uint64(*j.MaxItems), 10, false)
+			buf.WriteByte(',')
+		}
+	}
+	if j.Example != nil {
+		buf.WriteString(`"example":`)
+		/* Interface types must use runtime reflection. type=interface {} kind=interface */
+		err = buf.Encode(j.Example)
+		if err != nil {
+			return err
+		}
+		buf.WriteByte(',')
+	}
+	if len(j.Examples) != 0 {
+		buf.WriteString(`"examples":`)
+		if j.Examples != nil {
+			buf.WriteString(`[`)
+			for i, v := range j.Examples {
+				if i != 0 {
+					buf.WriteString(`,`)
+				}
+				/* Interface types must use runtime reflection. type=interface {} kind=interface */
+				err = buf.Encode(v)
+				if err != nil {
+					return err
+				}
+			}
+			buf.WriteString(`]`)
+		} else {
+			buf.WriteString(`null`)
+		}
+		buf.WriteByte(',')
+	}
+	buf.Rewind(1)
+	buf.WriteByte('}')
+	return nil
+}
+
+const (
+	ffjtSchemabase = iota
+	ffjtSchemanosuchkey
+
+	ffjtSchemaDescription
+
+	ffjtSchemaRef
+
+	ffjtSchemaType
+
+	ffjtSchemaFormat
+
+	ffjtSchemaNullable
+
+	ffjtSchemaRequired
+
+	ffjtSchemaProperties
+
+	ffjtSchemaAdditionalProperties
+
+	ffjtSchemaItems
+
+	ffjtSchemaAllOf
+
+	ffjtSchemaAnyOf
+
+	ffjtSchemaEnum
+
+	ffjtSchemaEnumVarNames
+
+	ffjtSchemaMinimum
+
+	ffjtSchemaMaximum
+
+	ffjtSchemaMinLength
+
+	ffjtSchemaMaxLength
+
+	ffjtSchemaMinItems
+
+	ffjtSchemaMaxItems
+
+	ffjtSchemaExample
+
+	ffjtSchemaExamples
+)
+
+var ffjKeySchemaDescription = []byte("description")
+
+var ffjKeySchemaRef = []byte("$ref")
+
+var ffjKeySchemaType = []byte("type")
+
+var ffjKeySchemaFormat = []byte("format")
+
+var ffjKeySchemaNullable = []byte("nullable")
+
+var ffjKeySchemaRequired = []byte("required")
+
+var ffjKeySchemaProperties = []byte("properties")
+
+var ffjKeySchemaAdditionalProperties = []byte("additionalProperties")
+
+var ffjKeySchemaItems = []byte("items")
+
+var ffjKeySchemaAllOf = []byte("allOf")
+
+var ffjKeySchemaAnyOf = []byte("anyOf")
+
+var ffjKeySchemaEnum = []byte("enum")
+
+var ffjKeySchemaEnumVarNames = []byte("x-enum-varnames")
+
+var ffjKeySchemaMinimum = []byte("minimum")
+
+var ffjKeySchemaMaximum = []byte("maximum")
+
+var ffjKeySchemaMinLength = []byte("minLength")
+
+var ffjKeySchemaMaxLength = []byte("maxLength")
+
+var ffjKeySchemaMinItems = []byte("minItems")
+
+var ffjKeySchemaMaxItems = []byte("maxItems")
+
+var ffjKeySchemaExample = []byte("example")
+
+var ffjKeySchemaExamples = []byte("examples")
+
+// UnmarshalJSON umarshall json - template of ffjson
+func (j *Schema) UnmarshalJSON(input []byte) error {
+	fs := fflib.NewFFLexer(input)
+	return j.UnmarshalJSONFFLexer(fs, fflib.FFParse_map_start)
+}
+
+// UnmarshalJSONFFLexer fast json unmarshall - template ffjson
+func (j *Schema) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
+	var err error
+	currentKey := ffjtSchemabase
+	_ = currentKey
+	tok := fflib.FFTok_init
+	wantedTok := fflib.FFTok_init
+
+mainparse:
+	for {
+		tok = fs.Scan()
+		//	println(fmt.Sprintf("debug: tok: %v  state: %v", tok, state))
+		if tok == fflib.FFTok_error {
+			goto tokerror
+		}
+
+		switch state {
+
+		case fflib.FFParse_map_start:
+			if tok != fflib.FFTok_left_bracket {
+				wantedTok = fflib.FFTok_left_bracket
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_key
+			continue
+
+		case fflib.FFParse_after_value:
+			if tok == fflib.FFTok_comma {
+				state = fflib.FFParse_want_key
+			} else if tok == fflib.FFTok_right_bracket {
+				goto done
+			} else {
+				wantedTok = fflib.FFTok_comma
+				goto wrongtokenerror
+			}
+
+		case fflib.FFParse_want_key:
+			// json {} ended. goto exit. woo.
+			if tok == fflib.FFTok_right_bracket {
+				goto done
+			}
+			if tok != fflib.FFTok_string {
+				wantedTok = fflib.FFTok_string
+				goto wrongtokenerror
+			}
+
+			kn := fs.Output.Bytes()
+			if len(kn) <= 0 {
+				// "" case. hrm.
+				currentKey = ffjtSchemanosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			} else {
+				switch kn[0] {
+
+				case '$':
+
+					if bytes.Equal(ffjKeySchemaRef, kn) {
+						currentKey = ffjtSchemaRef
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'a':
+
+					if bytes.Equal(ffjKeySchemaAdditionalProperties, kn) {
+						currentKey = ffjtSchemaAdditionalProperties
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeySchemaAllOf, kn) {
+						currentKey = ffjtSchemaAllOf
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeySchemaAnyOf, kn) {
+						currentKey = ffjtSchemaAnyOf
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'd':
+
+					if bytes.Equal(ffjKeySchemaDescription, kn) {
+						currentKey = ffjtSchemaDescription
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'e':
+
+					if bytes.Equal(ffjKeySchemaEnum, kn) {
+						currentKey = ffjtSchemaEnum
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeySchemaExample, kn) {
+						currentKey = ffjtSchemaExample
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeySchemaExamples, kn) {
+						currentKey = ffjtSchemaExamples
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'f':
+
+					if bytes.Equal(ffjKeySchemaFormat, kn) {
+						currentKey = ffjtSchemaFormat
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'i':
+
+					if bytes.Equal(ffjKeySchemaItems, kn) {
+						currentKey = ffjtSchemaItems
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'm':
+
+					if bytes.Equal(ffjKeySchemaMinimum, kn) {
+						currentKey = ffjtSchemaMinimum
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeySchemaMaximum, kn) {
+						currentKey = ffjtSchemaMaximum
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeySchemaMinLength, kn) {
+						currentKey = ffjtSchemaMinLength
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeySchemaMaxLength, kn) {
+						currentKey = ffjtSchemaMaxLength
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeySchemaMinItems, kn) {
+						currentKey = ffjtSchemaMinItems
+						state = fflib.FFParse_want_colon
+						goto mainparse
+
+					} else if bytes.Equal(ffjKeySchemaMaxItems, kn) {
+						currentKey = ffjtSchemaMaxItems
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'n':
+
+					if bytes.Equal(ffjKeySchemaNullable, kn) {
+						currentKey = ffjtSchemaNullable
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'p':
+
+					if bytes.Equal(ffjKeySchemaProperties, kn) {
+						currentKey = ffjtSchemaProperties
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'r':
+
+					if bytes.Equal(ffjKeySchemaRequired, kn) {
+						currentKey = ffjtSchemaRequired
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 't':
+
+					if bytes.Equal(ffjKeySchemaType, kn) {
+						currentKey = ffjtSchemaType
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'x':
+
+					if bytes.Equal(ffjKeySchemaEnumVarNames, kn) {
+						currentKey = ffjtSchemaEnumVarNames
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				}
+
+				if fflib.EqualFoldRight(ffjKeySchemaExamples, kn) {
+					currentKey = ffjtSchemaExamples
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeySchemaExample, kn) {
+					currentKey = ffjtSchemaExample
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeySchemaMaxItems, kn) {
+					currentKey = ffjtSchemaMaxItems
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeySchemaMinItems, kn) {
+					currentKey = ffjtSchemaMinItems
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeySchemaMaxLength, kn) {
+					currentKey = ffjtSchemaMaxLength
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeySchemaMinLength, kn) {
+					currentKey = ffjtSchemaMinLength
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeySchemaMaximum, kn) {
+					currentKey = ffjtSchemaMaximum
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeySchemaMinimum, kn) {
+					currentKey = ffjtSchemaMinimum
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeySchemaEnumVarNames, kn) {
+					currentKey = ffjtSchemaEnumVarNames
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeySchemaEnum, kn) {
+					currentKey = ffjtSchemaEnum
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeySchemaAnyOf, kn) {
+					currentKey = ffjtSchemaAnyOf
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeySchemaAllOf, kn) {
+					currentKey = ffjtSchemaAllOf
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeySchemaItems, kn) {
+					currentKey = ffjtSchemaItems
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeySchemaAdditionalProperties, kn) {
+					currentKey = ffjtSchemaAdditionalProperties
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeySchemaProperties, kn) {
+					currentKey = ffjtSchemaProperties
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeySchemaRequired, kn) {
+					currentKey = ffjtSchemaRequired
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeySchemaNullable, kn) {
+					currentKey = ffjtSchemaNullable
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeySchemaFormat, kn) {
+					currentKey = ffjtSchemaFormat
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeySchemaType, kn) {
+					currentKey = ffjtSchemaType
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.AsciiEqualFold(ffjKeySchemaRef, kn) {
+					currentKey = ffjtSchemaRef
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeySchemaDescription, kn) {
+					currentKey = ffjtSchemaDescription
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				currentKey = ffjtSchemanosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			}
+
+		case fflib.FFParse_want_colon:
+			if tok != fflib.FFTok_colon {
+				wantedTok = fflib.FFTok_colon
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_value
+			continue
+		case fflib.FFParse_want_value:
+
+			if tok == fflib.FFTok_left_brace || tok == fflib.FFTok_left_bracket || tok == fflib.FFTok_integer || tok == fflib.FFTok_double || tok == fflib.FFTok_string || tok == fflib.FFTok_bool || tok == fflib.FFTok_null {
+				switch currentKey {
+
+				case ffjtSchemaDescription:
+					goto handle_Description
+
+				case ffjtSchemaRef:
+					goto handle_Ref
+
+				case ffjtSchemaType:
+					goto handle_Type
+
+				case ffjtSchemaFormat:
+					goto handle_Format
+
+				case ffjtSchemaNullable:
+					goto handle_Nullable
+
+				case ffjtSchemaRequired:
+					goto handle_Required
+
+				case ffjtSchemaProperties:
+					goto handle_Properties
+
+				case ffjtSchemaAdditionalProperties:
+					goto handle_AdditionalProperties
+
+				case ffjtSchemaItems:
+					goto handle_Items
+
+				case ffjtSchemaAllOf:
+					goto handle_AllOf
+
+				case ffjtSchemaAnyOf:
+					goto handle_AnyOf
+
+				case ffjtSchemaEnum:
+					goto handle_Enum
+
+				case ffjtSchemaEnumVarNames:
+					goto handle_EnumVarNames
+
+				case ffjtSchemaMinimum:
+					goto handle_Minimum
+
+				case ffjtSchemaMaximum:
+					goto handle_Maximum
+
+				case ffjtSchemaMinLength:
+					goto handle_MinLength
+
+				case ffjtSchemaMaxLength:
+					goto handle_MaxLength
+
+				case ffjtSchemaMinItems:
+					goto handle_MinItems
+
+				case ffjtSchemaMaxItems:
+					goto handle_MaxItems
+
+				case ffjtSchemaExample:
+					goto handle_Example
+
+				case ffjtSchemaExamples:
+					goto handle_Examples
+
+				case ffjtSchemanosuchkey:
+					err = fs.SkipField(tok)
+					if err != nil {
+						return fs.WrapErr(err)
+					}
+					state = fflib.FFParse_after_value
+					goto mainparse
+				}
+			} else {
+				goto wantedvalue
+			}
+		}
+	}
+
+handle_Description:
+
+	/* handler: j.Description type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Description = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Ref:
+
+	/* handler: j.Ref type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Ref = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Type:
+
+	/* handler: j.Type type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.Type = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Format:
+
+	/* handler: j.Format type=string kind=string quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
 				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
 			}
 		}
@@ -5797,9 +6898,751 @@ handle_Format:
 
 		} else {
 
-			outBuf := fs.Output.Bytes()
+			outBuf := fs.Output.Bytes()
+
+			j.Format = string(string(outBuf))
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Nullable:
+
+	/* handler: j.Nullable type=bool kind=bool quoted=false*/
+
+	{
+		if tok != fflib.FFTok_bool && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for bool", tok))
+		}
+	}
+
+	{
+		if tok == fflib.FFTok_null {
+
+		} else {
+			tmpb := fs.Output.Bytes()
+
+			if bytes.Compare([]byte{'t', 'r', 'u', 'e'}, tmpb) == 0 {
+
+				j.Nullable = true
+
+			} else if bytes.Compare([]byte{'f', 'a', 'l', 's', 'e'}, tmpb) == 0 {
+
+				j.Nullable = false
+
+			} else {
+				err = errors.New("unexpected bytes for true/false value")
+				return fs.WrapErr(err)
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Required:
+
+	/* handler: j.Required type=[]string kind=slice quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_left_brace && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for ", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+			j.Required = nil
+		} else {
+
+			j.Required = []string{}
+
+			wantVal := true
+
+			for {
+
+				var tmpJRequired string
+
+				tok = fs.Scan()
+				if tok == fflib.FFTok_error {
+					goto tokerror
+				}
+				if tok == fflib.FFTok_right_brace {
+					break
+				}
+
+				if tok == fflib.FFTok_comma {
+					if wantVal == true {
+						// TODO(pquerna): this isn't an ideal error message, this handles
+						// things like [,,,] as an array value.
+						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+					}
+					continue
+				} else {
+					wantVal = true
+				}
+
+				/* handler: tmpJRequired type=string kind=string quoted=false*/
+
+				{
+
+					{
+						if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+							return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+						}
+					}
+
+					if tok == fflib.FFTok_null {
+
+					} else {
+
+						outBuf := fs.Output.Bytes()
+
+						tmpJRequired = string(string(outBuf))
+
+					}
+				}
+
+				j.Required = append(j.Required, tmpJRequired)
+
+				wantVal = false
+			}
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Properties:
+
+	/* handler: j.Properties type=openapi.Properties kind=map quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_left_bracket && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for Properties", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+			j.Properties = nil
+		} else {
+
+			j.Properties = make(map[string]*Schema, 0)
+
+			wantVal := true
+
+			for {
+
+				var k string
+
+				var tmpJProperties *Schema
+
+				tok = fs.Scan()
+				if tok == fflib.FFTok_error {
+					goto tokerror
+				}
+				if tok == fflib.FFTok_right_bracket {
+					break
+				}
+
+				if tok == fflib.FFTok_comma {
+					if wantVal == true {
+						// TODO(pquerna): this isn't an ideal error message, this handles
+						// things like [,,,] as an array value.
+						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+					}
+					continue
+				} else {
+					wantVal = true
+				}
+
+				/* handler: k type=string kind=string quoted=false*/
+
+				{
+
+					{
+						if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+							return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+						}
+					}
+
+					if tok == fflib.FFTok_null {
+
+					} else {
+
+						outBuf := fs.Output.Bytes()
+
+						k = string(string(outBuf))
+
+					}
+				}
+
+				// Expect ':' after key
+				tok = fs.Scan()
+				if tok != fflib.FFTok_colon {
+					return fs.WrapErr(fmt.Errorf("wanted colon token, but got token: %v", tok))
+				}
+
+				tok = fs.Scan()
+				/* handler: tmpJProperties type=*openapi.Schema kind=ptr quoted=false*/
+
+				{
+					if tok == fflib.FFTok_null {
+
+						tmpJProperties = nil
+
+					} else {
+
+						if tmpJProperties == nil {
+							tmpJProperties = new(Schema)
+						}
+
+						err = tmpJProperties.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+						if err != nil {
+							return err
+						}
+					}
+					state = fflib.FFParse_after_value
+				}
+
+				j.Properties[k] = tmpJProperties
+
+				wantVal = false
+			}
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_AdditionalProperties:
+
+	/* handler: j.AdditionalProperties type=openapi.Schema kind=struct quoted=false*/
+
+	{
+		if tok == fflib.FFTok_null {
+
+			j.AdditionalProperties = nil
+
+		} else {
+
+			if j.AdditionalProperties == nil {
+				j.AdditionalProperties = new(Schema)
+			}
+
+			err = j.AdditionalProperties.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+			if err != nil {
+				return err
+			}
+		}
+		state = fflib.FFParse_after_value
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Items:
+
+	/* handler: j.Items type=openapi.Schema kind=struct quoted=false*/
+
+	{
+		if tok == fflib.FFTok_null {
+
+			j.Items = nil
+
+		} else {
+
+			if j.Items == nil {
+				j.Items = new(Schema)
+			}
+
+			err = j.Items.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+			if err != nil {
+				return err
+			}
+		}
+		state = fflib.FFParse_after_value
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_AllOf:
+
+	/* handler: j.AllOf type=[]openapi.Schema kind=slice quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_left_brace && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for ", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+			j.AllOf = nil
+		} else {
+
+			j.AllOf = []Schema{}
+
+			wantVal := true
+
+			for {
+
+				var tmpJAllOf Schema
+
+				tok = fs.Scan()
+				if tok == fflib.FFTok_error {
+					goto tokerror
+				}
+				if tok == fflib.FFTok_right_brace {
+					break
+				}
+
+				if tok == fflib.FFTok_comma {
+					if wantVal == true {
+						// TODO(pquerna): this isn't an ideal error message, this handles
+						// things like [,,,] as an array value.
+						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+					}
+					continue
+				} else {
+					wantVal = true
+				}
+
+				/* handler: tmpJAllOf type=openapi.Schema kind=struct quoted=false*/
+
+				{
+					if tok == fflib.FFTok_null {
+
+					} else {
+
+						err = tmpJAllOf.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+						if err != nil {
+							return err
+						}
+					}
+					state = fflib.FFParse_after_value
+				}
+
+				j.AllOf = append(j.AllOf, tmpJAllOf)
+
+				wantVal = false
+			}
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_AnyOf:
+
+	/* handler: j.AnyOf type=[]openapi.Schema kind=slice quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_left_brace && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for ", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+			j.AnyOf = nil
+		} else {
+
+			j.AnyOf = []Schema{}
+
+			wantVal := true
+
+			for {
+
+				var tmpJAnyOf Schema
+
+				tok = fs.Scan()
+				if tok == fflib.FFTok_error {
+					goto tokerror
+				}
+				if tok == fflib.FFTok_right_brace {
+					break
+				}
+
+				if tok == fflib.FFTok_comma {
+					if wantVal == true {
+						// TODO(pquerna): this isn't an ideal error message, this handles
+						// things like [,,,] as an array value.
+						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+					}
+					continue
+				} else {
+					wantVal = true
+				}
+
+				/* handler: tmpJAnyOf type=openapi.Schema kind=struct quoted=false*/
+
+				{
+					if tok == fflib.FFTok_null {
+
+					} else {
+
+						err = tmpJAnyOf.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
+						if err != nil {
+							return err
+						}
+					}
+					state = fflib.FFParse_after_value
+				}
+
+				j.AnyOf = append(j.AnyOf, tmpJAnyOf)
+
+				wantVal = false
+			}
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Enum:
+
+	/* handler: j.Enum type=[]interface {} kind=slice quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_left_brace && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for ", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+			j.Enum = nil
+		} else {
+
+			j.Enum = []interface{}{}
+
+			wantVal := true
+
+			for {
+
+				var tmpJEnum interface{}
+
+				tok = fs.Scan()
+				if tok == fflib.FFTok_error {
+					goto tokerror
+				}
+				if tok == fflib.FFTok_right_brace {
+					break
+				}
+
+				if tok == fflib.FFTok_comma {
+					if wantVal == true {
+						// TODO(pquerna): this isn't an ideal error message, this handles
+						// things like [,,,] as an array value.
+						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+					}
+					continue
+				} else {
+					wantVal = true
+				}
+
+				/* handler: tmpJEnum type=interface {} kind=interface quoted=false*/
+
+				{
+					/* Falling back. type=interface {} kind=interface */
+					tbuf, err := fs.CaptureField(tok)
+					if err != nil {
+						return fs.WrapErr(err)
+					}
+
+					err = json.Unmarshal(tbuf, &tmpJEnum)
+					if err != nil {
+						return fs.WrapErr(err)
+					}
+				}
+
+				j.Enum = append(j.Enum, tmpJEnum)
+
+				wantVal = false
+			}
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_EnumVarNames:
+
+	/* handler: j.EnumVarNames type=[]string kind=slice quoted=false*/
+
+	{
+
+		{
+			if tok != fflib.FFTok_left_brace && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for ", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
+			j.EnumVarNames = nil
+		} else {
+
+			j.EnumVarNames = []string{}
+
+			wantVal := true
+
+			for {
+
+				var tmpJEnumVarNames string
+
+				tok = fs.Scan()
+				if tok == fflib.FFTok_error {
+					goto tokerror
+				}
+				if tok == fflib.FFTok_right_brace {
+					break
+				}
+
+				if tok == fflib.FFTok_comma {
+					if wantVal == true {
+						// TODO(pquerna): this isn't an ideal error message, this handles
+						// things like [,,,] as an array value.
+						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+					}
+					continue
+				} else {
+					wantVal = true
+				}
+
+				/* handler: tmpJEnumVarNames type=string kind=string quoted=false*/
+
+				{
+
+					{
+						if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+							return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+						}
+					}
+
+					if tok == fflib.FFTok_null {
+
+					} else {
+
+						outBuf := fs.Output.Bytes()
+
+						tmpJEnumVarNames = string(string(outBuf))
+
+					}
+				}
+
+				j.EnumVarNames = append(j.EnumVarNames, tmpJEnumVarNames)
+
+				wantVal = false
+			}
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Minimum:
+
+	/* handler: j.Minimum type=float64 kind=float64 quoted=false*/
+
+	{
+		if tok != fflib.FFTok_double && tok != fflib.FFTok_integer && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for float64", tok))
+		}
+	}
+
+	{
+
+		if tok == fflib.FFTok_null {
+
+			j.Minimum = nil
+
+		} else {
+
+			tval, err := fflib.ParseFloat(fs.Output.Bytes(), 64)
+
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			ttypval := float64(tval)
+			j.Minimum = &ttypval
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Maximum:
+
+	/* handler: j.Maximum type=float64 kind=float64 quoted=false*/
+
+	{
+		if tok != fflib.FFTok_double && tok != fflib.FFTok_integer && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for float64", tok))
+		}
+	}
+
+	{
+
+		if tok == fflib.FFTok_null {
+
+			j.Maximum = nil
+
+		} else {
+
+			tval, err := fflib.ParseFloat(fs.Output.Bytes(), 64)
+
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			ttypval := float64(tval)
+			j.Maximum = &ttypval
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_MinLength:
+
+	/* handler: j.MinLength type=uint64 kind=uint64 quoted=false*/
+
+	{
+		if tok != fflib.FFTok_integer && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for uint64", tok))
+		}
+	}
+
+	{
+
+		if tok == fflib.FFTok_null {
+
+			j.MinLength = nil
+
+		} else {
+
+			tval, err := fflib.ParseUint(fs.Output.Bytes(), 10, 64)
+
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			ttypval := uint64(tval)
+			j.MinLength = &ttypval
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_MaxLength:
+
+	/* handler: j.MaxLength type=uint64 kind=uint64 quoted=false*/
+
+	{
+		if tok != fflib.FFTok_integer && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for uint64", tok))
+		}
+	}
+
+	{
+
+		if tok == fflib.FFTok_null {
+
+			j.MaxLength = nil
+
+		} else {
+
+			tval, err := fflib.ParseUint(fs.Output.Bytes(), 10, 64)
+
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			ttypval := uint64(tval)
+			j.MaxLength = &ttypval
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_MinItems:
+
+	/* handler: j.MinItems type=uint64 kind=uint64 quoted=false*/
+
+	{
+		if tok != fflib.FFTok_integer && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for uint64", tok))
+		}
+	}
+
+	{
+
+		if tok == fflib.FFTok_null {
+
+			j.MinItems = nil
+
+		} else {
+
+			tval, err := fflib.ParseUint(fs.Output.Bytes(), 10, 64)
+
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			ttypval := uint64(tval)
+			j.MinItems = &ttypval
+
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_MaxItems:
+
+	/* handler: j.MaxItems type=uint64 kind=uint64 quoted=false*/
+
+	{
+		if tok != fflib.FFTok_integer && tok != fflib.FFTok_null {
+			return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for uint64", tok))
+		}
+	}
+
+	{
+
+		if tok == fflib.FFTok_null {
+
+			j.MaxItems = nil
+
+		} else {
+
+			tval, err := fflib.ParseUint(fs.Output.Bytes(), 10, 64)
 
-			j.Format = string(string(outBuf))
+			if err != nil {
+				return fs.WrapErr(err)
+			}
+
+			ttypval := uint64(tval)
+			j.MaxItems = &ttypval
 
 		}
 	}
@@ -5807,37 +7650,55 @@ handle_Format:
 	state = fflib.FFParse_after_value
 	goto mainparse
 
-handle_Properties:
+handle_Example:
 
-	/* handler: j.Properties type=openapi.Properties kind=map quoted=false*/
+	/* handler: j.Example type=interface {} kind=interface quoted=false*/
+
+	{
+		/* Falling back. type=interface {} kind=interface */
+		tbuf, err := fs.CaptureField(tok)
+		if err != nil {
+			return fs.WrapErr(err)
+		}
+
+		err = json.Unmarshal(tbuf, &j.Example)
+		if err != nil {
+			return fs.WrapErr(err)
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+handle_Examples:
+
+	/* handler: j.Examples type=[]interface {} kind=slice quoted=false*/
 
 	{
 
 		{
-			if tok != fflib.FFTok_left_bracket && tok != fflib.FFTok_null {
-				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for Properties", tok))
+			if tok != fflib.FFTok_left_brace && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for ", tok))
 			}
 		}
 
 		if tok == fflib.FFTok_null {
-			j.Properties = nil
+			j.Examples = nil
 		} else {
 
-			j.Properties = make(map[string]*Schema, 0)
+			j.Examples = []interface{}{}
 
 			wantVal := true
 
 			for {
 
-				var k string
-
-				var tmpJProperties *Schema
+				var tmpJExamples interface{}
 
 				tok = fs.Scan()
 				if tok == fflib.FFTok_error {
 					goto tokerror
 				}
-				if tok == fflib.FFTok_right_bracket {
+				if tok == fflib.FFTok_right_brace {
 					break
 				}
 
@@ -5852,248 +7713,484 @@ handle_Properties:
 					wantVal = true
 				}
 
-				/* handler: k type=string kind=string quoted=false*/
+				/* handler: tmpJExamples type=interface {} kind=interface quoted=false*/
 
 				{
+					/* Falling back. type=interface {} kind=interface */
+					tbuf, err := fs.CaptureField(tok)
+					if err != nil {
+						return fs.WrapErr(err)
+					}
 
-					{
-						if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
-							return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
-						}
+					err = json.Unmarshal(tbuf, &tmpJExamples)
+					if err != nil {
+						return fs.WrapErr(err)
 					}
+				}
 
-					if tok == fflib.FFTok_null {
+				j.Examples = append(j.Examples, tmpJExamples)
 
-					} else {
+				wantVal = false
+			}
+		}
+	}
+
+	state = fflib.FFParse_after_value
+	goto mainparse
+
+wantedvalue:
+	return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
+wrongtokenerror:
+	return fs.WrapErr(fmt.Errorf("ffjson: wanted token: %v, but got token: %v output=%s", wantedTok, tok, fs.Output.String()))
+tokerror:
+	if fs.BigError != nil {
+		return fs.WrapErr(fs.BigError)
+	}
+	err = fs.Error.ToError()
+	if err != nil {
+		return fs.WrapErr(err)
+	}
+	panic("ffjson-generated: unreachable, please report bug.")
+done:
+
+	return nil
+}
+
+// MarshalJSON marshal bytes to json - template
+func (j *SecurityScheme) MarshalJSON() ([]byte, error) {
+	var buf fflib.Buffer
+	if j == nil {
+		buf.WriteString("null")
+		return buf.Bytes(), nil
+	}
+	err := j.MarshalJSONBuf(&buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSONBuf marshal buff to json - template
+func (j *SecurityScheme) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
+	if j == nil {
+		buf.WriteString("null")
+		return nil
+	}
+	var err error
+	var obj []byte
+	_ = obj
+	_ = err
+	buf.WriteString(`{ `)
+	if len(j.Type) != 0 {
+		buf.WriteString(`"type":`)
+		fflib.WriteJsonString(buf, string(j.Type))
+		buf.WriteByte(',')
+	}
+	if len(j.Description) != 0 {
+		buf.WriteString(`"description":`)
+		fflib.WriteJsonString(buf, string(j.Description))
+		buf.WriteByte(',')
+	}
+	if len(j.Name) != 0 {
+		buf.WriteString(`"name":`)
+		fflib.WriteJsonString(buf, string(j.Name))
+		buf.WriteByte(',')
+	}
+	if len(j.In) != 0 {
+		buf.WriteString(`"in":`)
+		fflib.WriteJsonString(buf, string(j.In))
+		buf.WriteByte(',')
+	}
+	if len(j.Scheme) != 0 {
+		buf.WriteString(`"scheme":`)
+		fflib.WriteJsonString(buf, string(j.Scheme))
+		buf.WriteByte(',')
+	}
+	if len(j.BearerFormat) != 0 {
+		buf.WriteString(`"bearerFormat":`)
+		fflib.WriteJsonString(buf, string(j.BearerFormat))
+		buf.WriteByte(',')
+	}
+	buf.Rewind(1)
+	buf.WriteByte('}')
+	return nil
+}
+
+const (
+	ffjtSecuritySchemebase = iota
+	ffjtSecuritySchemenosuchkey
+
+	ffjtSecuritySchemeType
+
+	ffjtSecuritySchemeDescription
+
+	ffjtSecuritySchemeName
+
+	ffjtSecuritySchemeIn
+
+	ffjtSecuritySchemeScheme
+
+	ffjtSecuritySchemeBearerFormat
+)
+
+var ffjKeySecuritySchemeType = []byte("type")
+
+var ffjKeySecuritySchemeDescription = []byte("description")
+
+var ffjKeySecuritySchemeName = []byte("name")
+
+var ffjKeySecuritySchemeIn = []byte("in")
+
+var ffjKeySecuritySchemeScheme = []byte("scheme")
+
+var ffjKeySecuritySchemeBearerFormat = []byte("bearerFormat")
+
+// UnmarshalJSON umarshall json - template of ffjson
+func (j *SecurityScheme) UnmarshalJSON(input []byte) error {
+	fs := fflib.NewFFLexer(input)
+	return j.UnmarshalJSONFFLexer(fs, fflib.FFParse_map_start)
+}
+
+// UnmarshalJSONFFLexer fast json unmarshall - template ffjson
+func (j *SecurityScheme) UnmarshalJSONFFLexer(fs *fflib.FFLexer, state fflib.FFParseState) error {
+	var err error
+	currentKey := ffjtSecuritySchemebase
+	_ = currentKey
+	tok := fflib.FFTok_init
+	wantedTok := fflib.FFTok_init
+
+mainparse:
+	for {
+		tok = fs.Scan()
+		//	println(fmt.Sprintf("debug: tok: %v  state: %v", tok, state))
+		if tok == fflib.FFTok_error {
+			goto tokerror
+		}
+
+		switch state {
+
+		case fflib.FFParse_map_start:
+			if tok != fflib.FFTok_left_bracket {
+				wantedTok = fflib.FFTok_left_bracket
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_key
+			continue
+
+		case fflib.FFParse_after_value:
+			if tok == fflib.FFTok_comma {
+				state = fflib.FFParse_want_key
+			} else if tok == fflib.FFTok_right_bracket {
+				goto done
+			} else {
+				wantedTok = fflib.FFTok_comma
+				goto wrongtokenerror
+			}
+
+		case fflib.FFParse_want_key:
+			// json {} ended. goto exit. woo.
+			if tok == fflib.FFTok_right_bracket {
+				goto done
+			}
+			if tok != fflib.FFTok_string {
+				wantedTok = fflib.FFTok_string
+				goto wrongtokenerror
+			}
+
+			kn := fs.Output.Bytes()
+			if len(kn) <= 0 {
+				// "" case. hrm.
+				currentKey = ffjtSecuritySchemenosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			} else {
+				switch kn[0] {
+
+				case 'b':
+
+					if bytes.Equal(ffjKeySecuritySchemeBearerFormat, kn) {
+						currentKey = ffjtSecuritySchemeBearerFormat
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'd':
+
+					if bytes.Equal(ffjKeySecuritySchemeDescription, kn) {
+						currentKey = ffjtSecuritySchemeDescription
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'i':
+
+					if bytes.Equal(ffjKeySecuritySchemeIn, kn) {
+						currentKey = ffjtSecuritySchemeIn
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 'n':
+
+					if bytes.Equal(ffjKeySecuritySchemeName, kn) {
+						currentKey = ffjtSecuritySchemeName
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 's':
+
+					if bytes.Equal(ffjKeySecuritySchemeScheme, kn) {
+						currentKey = ffjtSecuritySchemeScheme
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				case 't':
+
+					if bytes.Equal(ffjKeySecuritySchemeType, kn) {
+						currentKey = ffjtSecuritySchemeType
+						state = fflib.FFParse_want_colon
+						goto mainparse
+					}
+
+				}
+
+				if fflib.SimpleLetterEqualFold(ffjKeySecuritySchemeBearerFormat, kn) {
+					currentKey = ffjtSecuritySchemeBearerFormat
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
+
+				if fflib.EqualFoldRight(ffjKeySecuritySchemeScheme, kn) {
+					currentKey = ffjtSecuritySchemeScheme
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
 
-						outBuf := fs.Output.Bytes()
+				if fflib.SimpleLetterEqualFold(ffjKeySecuritySchemeIn, kn) {
+					currentKey = ffjtSecuritySchemeIn
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
 
-						k = string(string(outBuf))
+				if fflib.SimpleLetterEqualFold(ffjKeySecuritySchemeName, kn) {
+					currentKey = ffjtSecuritySchemeName
+					state = fflib.FFParse_want_colon
+					goto mainparse
+				}
 
-					}
+				if fflib.EqualFoldRight(ffjKeySecuritySchemeDescription, kn) {
+					currentKey = ffjtSecuritySchemeDescription
+					state = fflib.FFParse_want_colon
+					goto mainparse
 				}
 
-				// Expect ':' after key
-				tok = fs.Scan()
-				if tok != fflib.FFTok_colon {
-					return fs.WrapErr(fmt.Errorf("wanted colon token, but got token: %v", tok))
+				if fflib.SimpleLetterEqualFold(ffjKeySecuritySchemeType, kn) {
+					currentKey = ffjtSecuritySchemeType
+					state = fflib.FFParse_want_colon
+					goto mainparse
 				}
 
-				tok = fs.Scan()
-				/* handler: tmpJProperties type=*openapi.Schema kind=ptr quoted=false*/
+				currentKey = ffjtSecuritySchemenosuchkey
+				state = fflib.FFParse_want_colon
+				goto mainparse
+			}
 
-				{
-					if tok == fflib.FFTok_null {
+		case fflib.FFParse_want_colon:
+			if tok != fflib.FFTok_colon {
+				wantedTok = fflib.FFTok_colon
+				goto wrongtokenerror
+			}
+			state = fflib.FFParse_want_value
+			continue
+		case fflib.FFParse_want_value:
 
-						tmpJProperties = nil
+			if tok == fflib.FFTok_left_brace || tok == fflib.FFTok_left_bracket || tok == fflib.FFTok_integer || tok == fflib.FFTok_double || tok == fflib.FFTok_string || tok == fflib.FFTok_bool || tok == fflib.FFTok_null {
+				switch currentKey {
 
-					} else {
+				case ffjtSecuritySchemeType:
+					goto handle_Type
 
-						if tmpJProperties == nil {
-							tmpJProperties = new(Schema)
-						}
+				case ffjtSecuritySchemeDescription:
+					goto handle_Description
 
-						err = tmpJProperties.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
-						if err != nil {
-							return err
-						}
+				case ffjtSecuritySchemeName:
+					goto handle_Name
+
+				case ffjtSecuritySchemeIn:
+					goto handle_In
+
+				case ffjtSecuritySchemeScheme:
+					goto handle_Scheme
+
+				case ffjtSecuritySchemeBearerFormat:
+					goto handle_BearerFormat
+
+				case ffjtSecuritySchemenosuchkey:
+					err = fs.SkipField(tok)
+					if err != nil {
+						return fs.WrapErr(err)
 					}
 					state = fflib.FFParse_after_value
+					goto mainparse
 				}
-
-				j.Properties[k] = tmpJProperties
-
-				wantVal = false
+			} else {
+				goto wantedvalue
 			}
-
 		}
 	}
 
-	state = fflib.FFParse_after_value
-	goto mainparse
-
-handle_Items:
+handle_Type:
 
-	/* handler: j.Items type=openapi.Schema kind=struct quoted=false*/
+	/* handler: j.Type type=string kind=string quoted=false*/
 
 	{
-		if tok == fflib.FFTok_null {
 
-			j.Items = nil
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
+
+		if tok == fflib.FFTok_null {
 
 		} else {
 
-			if j.Items == nil {
-				j.Items = new(Schema)
-			}
+			outBuf := fs.Output.Bytes()
+
+			j.Type = string(string(outBuf))
 
-			err = j.Items.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
-			if err != nil {
-				return err
-			}
 		}
-		state = fflib.FFParse_after_value
 	}
 
 	state = fflib.FFParse_after_value
 	goto mainparse
 
-handle_AnyOf:
+handle_Description:
 
-	/* handler: j.AnyOf type=[]openapi.Schema kind=slice quoted=false*/
+	/* handler: j.Description type=string kind=string quoted=false*/
 
 	{
 
 		{
-			if tok != fflib.FFTok_left_brace && tok != fflib.FFTok_null {
-				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for ", tok))
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
 			}
 		}
 
 		if tok == fflib.FFTok_null {
-			j.AnyOf = nil
+
 		} else {
 
-			j.AnyOf = []Schema{}
+			outBuf := fs.Output.Bytes()
 
-			wantVal := true
+			j.Description = string(string(outBuf))
 
-			for {
+		}
+	}
 
-				var tmpJAnyOf Schema
+	state = fflib.FFParse_after_value
+	goto mainparse
 
-				tok = fs.Scan()
-				if tok == fflib.FFTok_error {
-					goto tokerror
-				}
-				if tok == fflib.FFTok_right_brace {
-					break
-				}
+handle_Name:
 
-				if tok == fflib.FFTok_comma {
-					if wantVal == true {
-						// TODO(pquerna): this isn't an ideal error message, this handles
-						// things like [,,,] as an array value.
-						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
-					}
-					continue
-				} else {
-					wantVal = true
-				}
+	/* handler: j.Name type=string kind=string quoted=false*/
 
-				/* handler: tmpJAnyOf type=openapi.Schema kind=struct quoted=false*/
+	{
 
-				{
-					if tok == fflib.FFTok_null {
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
 
-					} else {
+		if tok == fflib.FFTok_null {
 
-						err = tmpJAnyOf.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
-						if err != nil {
-							return err
-						}
-					}
-					state = fflib.FFParse_after_value
-				}
+		} else {
 
-				j.AnyOf = append(j.AnyOf, tmpJAnyOf)
+			outBuf := fs.Output.Bytes()
+
+			j.Name = string(string(outBuf))
 
-				wantVal = false
-			}
 		}
 	}
 
 	state = fflib.FFParse_after_value
 	goto mainparse
 
-handle_Enum:
+handle_In:
 
-	/* handler: j.Enum type=[]string kind=slice quoted=false*/
+	/* handler: j.In type=string kind=string quoted=false*/
 
 	{
 
 		{
-			if tok != fflib.FFTok_left_brace && tok != fflib.FFTok_null {
-				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for ", tok))
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
 			}
 		}
 
 		if tok == fflib.FFTok_null {
-			j.Enum = nil
-		} else {
-
-			j.Enum = []string{}
-
-			wantVal := true
 
-			for {
+		} else {
 
-				var tmpJEnum string
+			outBuf := fs.Output.Bytes()
 
-				tok = fs.Scan()
-				if tok == fflib.FFTok_error {
-					goto tokerror
-				}
-				if tok == fflib.FFTok_right_brace {
-					break
-				}
+			j.In = string(string(outBuf))
 
-				if tok == fflib.FFTok_comma {
-					if wantVal == true {
-						// TODO(pquerna): this isn't an ideal error message, this handles
-						// things like [,,,] as an array value.
-						return fs.WrapErr(fmt.Errorf("wanted value token, but got token: %v", tok))
-					}
-					continue
-				} else {
-					wantVal = true
-				}
+		}
+	}
 
-				/* handler: tmpJEnum type=string kind=string quoted=false*/
+	state = fflib.FFParse_after_value
+	goto mainparse
 
-				{
+handle_Scheme:
 
-					{
-						if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
-							return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
-						}
-					}
+	/* handler: j.Scheme type=string kind=string quoted=false*/
 
-					if tok == fflib.FFTok_null {
+	{
 
-					} else {
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
+		}
 
-						outBuf := fs.Output.Bytes()
+		if tok == fflib.FFTok_null {
 
-						tmpJEnum = string(string(outBuf))
+		} else {
 
-					}
-				}
+			outBuf := fs.Output.Bytes()
 
-				j.Enum = append(j.Enum, tmpJEnum)
+			j.Scheme = string(string(outBuf))
 
-				wantVal = false
-			}
 		}
 	}
 
 	state = fflib.FFParse_after_value
 	goto mainparse
 
-handle_Example:
+handle_BearerFormat:
 
-	/* handler: j.Example type=interface {} kind=interface quoted=false*/
+	/* handler: j.BearerFormat type=string kind=string quoted=false*/
 
 	{
-		/* Falling back. type=interface {} kind=interface */
-		tbuf, err := fs.CaptureField(tok)
-		if err != nil {
-			return fs.WrapErr(err)
+
+		{
+			if tok != fflib.FFTok_string && tok != fflib.FFTok_null {
+				return fs.WrapErr(fmt.Errorf("cannot unmarshal %s into Go value for string", tok))
+			}
 		}
 
-		err = json.Unmarshal(tbuf, &j.Example)
-		if err != nil {
-			return fs.WrapErr(err)
+		if tok == fflib.FFTok_null {
+
+		} else {
+
+			outBuf := fs.Output.Bytes()
+
+			j.BearerFormat = string(string(outBuf))
+
 		}
 	}
 
@@ -6533,18 +8630,20 @@ func (j *Tag) MarshalJSONBuf(buf fflib.EncodingBuffer) error {
 		fflib.WriteJsonString(buf, string(j.Description))
 		buf.WriteByte(',')
 	}
-	if true {
-		buf.WriteString(`"externalDocs":`)
+	if j.ExternalDocs != nil {
+		if true {
+			buf.WriteString(`"externalDocs":`)
 
-		{
+			{
 
-			err = j.ExternalDocs.MarshalJSONBuf(buf)
-			if err != nil {
-				return err
-			}
+				err = j.ExternalDocs.MarshalJSONBuf(buf)
+				if err != nil {
+					return err
+				}
 
+			}
+			buf.WriteByte(',')
 		}
-		buf.WriteByte(',')
 	}
 	buf.Rewind(1)
 	buf.WriteByte('}')
@@ -6772,8 +8871,14 @@ handle_ExternalDocs:
 	{
 		if tok == fflib.FFTok_null {
 
+			j.ExternalDocs = nil
+
 		} else {
 
+			if j.ExternalDocs == nil {
+				j.ExternalDocs = new(ExternalDocs)
+			}
+
 			err = j.ExternalDocs.UnmarshalJSONFFLexer(fs, fflib.FFParse_want_key)
 			if err != nil {
 				return err
@@ -0,0 +1,1033 @@
+package openapiimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/iancoleman/strcase"
+	"gopkg.in/yaml.v3"
+
+	"github.com/swipe-io/swipe/pkg/format"
+	"github.com/swipe-io/swipe/pkg/openapi"
+	"github.com/swipe-io/swipe/pkg/writer"
+)
+
+const componentsSchemasRef = "#/components/schemas/"
+
+// Config of the import.
+type Config struct {
+	// Package is the name of the generated package.
+	Package string
+	// Interface is the name of the service interface, default is "Service".
+	Interface string
+	// Format is the format of the document exported by swipe, "json" or "yaml".
+	Format string
+}
+
+// Result of the import.
+type Result struct {
+	// Files are the generated files by the name.
+	Files map[string][]byte
+	// Warnings are the parts of the document that are not imported as is.
+	Warnings []string
+}
+
+type param struct {
+	name   string
+	wire   string
+	in     string
+	goType string
+}
+
+type result struct {
+	name   string
+	goType string
+}
+
+type method struct {
+	name       string
+	comments   []string
+	httpMethod string
+	path       string
+	tags       []string
+	params     []param
+	results    []result
+}
+
+type importer struct {
+	cfg      Config
+	doc      openapi.OpenAPI
+	types    map[string]string
+	names    map[string]bool
+	decls    []string
+	enums    []string
+	imports  map[string]bool
+	warnings []string
+}
+
+// Import generates the service interface, the models and the swipe.go file from the OpenAPI 3 document,
+// the data is the document in the JSON or YAML format.
+func Import(data []byte, cfg Config) (Result, error) {
+	if cfg.Interface == "" {
+		cfg.Interface = "Service"
+	}
+	if cfg.Format == "" {
+		cfg.Format = "json"
+	}
+	doc, warnings, err := decodeDocument(data)
+	if err != nil {
+		return Result{}, err
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return Result{}, fmt.Errorf("the OpenAPI 3 document is required; found version %q", doc.OpenAPI)
+	}
+	i := &importer{
+		cfg:      cfg,
+		doc:      doc,
+		types:    map[string]string{},
+		names:    map[string]bool{cfg.Interface: true},
+		imports:  map[string]bool{},
+		warnings: warnings,
+	}
+	methods, err := i.methods()
+	if err != nil {
+		return Result{}, err
+	}
+
+	files := map[string][]byte{}
+
+	serviceImports := i.imports
+	if len(i.decls) > 0 {
+		modelImports := map[string]bool{}
+		for _, decl := range i.decls {
+			if strings.Contains(decl, "time.Time") {
+				modelImports["time"] = true
+			}
+		}
+		w := new(writer.BaseWriter)
+		i.writeHeader(w, modelImports)
+		for _, decl := range i.decls {
+			w.W("%s\n", decl)
+		}
+		if files["model.go"], err = format.Source(w.Bytes()); err != nil {
+			return Result{}, err
+		}
+	}
+
+	serviceImports["context"] = true
+	w := new(writer.BaseWriter)
+	i.writeHeader(w, serviceImports)
+	i.writeInterface(w, methods)
+	if files["service.go"], err = format.Source(w.Bytes()); err != nil {
+		return Result{}, err
+	}
+
+	w = new(writer.BaseWriter)
+	w.W("//+build swipe\n\n")
+	i.writeHeader(w, map[string]bool{"net/http": true, "github.com/swipe-io/swipe/pkg/swipe": true})
+	i.writeSwipe(w, methods)
+	if files["swipe.go"], err = format.Source(w.Bytes()); err != nil {
+		return Result{}, err
+	}
+	return Result{Files: files, Warnings: i.warnings}, nil
+}
+
+// decodeDocument decodes the JSON or YAML document, the YAML keys are always decoded as strings
+// so the response codes are not the integer keys. The warnings are the schema keywords that are dropped.
+func decodeDocument(data []byte) (doc openapi.OpenAPI, warnings []string, err error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return doc, nil, err
+	}
+	v, err := nodeValue(&node)
+	if err != nil {
+		return doc, nil, err
+	}
+	normalizeTypes(v)
+	warnings = unsupportedKeywords(v, "#", nil)
+	b, err := json.Marshal(v)
+	if err != nil {
+		return doc, nil, err
+	}
+	err = json.Unmarshal(b, &doc)
+	return doc, warnings, err
+}
+
+// unsupportedKeywords returns the warnings for the schema keywords that have no Go type, the schema is
+// imported without them. The anyOf keyword is supported only as the nullable value.
+func unsupportedKeywords(v interface{}, location string, warnings []string) []string {
+	return walkKeywords(v, location, false, warnings)
+}
+
+// namedKeys are the keys of the maps by the name, the names are not the keywords.
+var namedKeys = map[string]bool{
+	"properties": true, "schemas": true, "paths": true, "responses": true, "content": true,
+	"headers": true, "securitySchemes": true, "requestBodies": true, "parameters": true,
+}
+
+func walkKeywords(v interface{}, location string, names bool, warnings []string) []string {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if !names {
+				switch k {
+				case "example", "examples":
+					// the values are the data, not the schemas.
+					continue
+				case "oneOf", "not", "discriminator":
+					warnings = append(warnings, fmt.Sprintf("%s: the %s keyword is not supported, the schema is imported without it", location, k))
+					continue
+				case "anyOf":
+					if !isNullableAnyOf(v[k]) {
+						warnings = append(warnings, fmt.Sprintf("%s: the anyOf keyword is supported only with the null type, the schema is imported without it", location))
+						continue
+					}
+				}
+			}
+			// the location is the JSON pointer.
+			pointer := location + "/" + strings.ReplaceAll(strings.ReplaceAll(k, "~", "~0"), "/", "~1")
+			warnings = walkKeywords(v[k], pointer, !names && namedKeys[k], warnings)
+		}
+	case []interface{}:
+		for idx, e := range v {
+			warnings = walkKeywords(e, location+"/"+strconv.Itoa(idx), false, warnings)
+		}
+	}
+	return warnings
+}
+
+// isNullableAnyOf reports whether the anyOf is the 3.1 nullable form, the value and the null type.
+func isNullableAnyOf(v interface{}) bool {
+	schemas, ok := v.([]interface{})
+	if !ok || len(schemas) != 2 {
+		return false
+	}
+	for _, s := range schemas {
+		if m, ok := s.(map[string]interface{}); ok && m["type"] == "null" {
+			return true
+		}
+	}
+	return false
+}
+
+func nodeValue(n *yaml.Node) (interface{}, error) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil
+		}
+		return nodeValue(n.Content[0])
+	case yaml.AliasNode:
+		return nodeValue(n.Alias)
+	case yaml.MappingNode:
+		m := make(map[string]interface{}, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v, err := nodeValue(n.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m[n.Content[i].Value] = v
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		s := make([]interface{}, 0, len(n.Content))
+		for _, c := range n.Content {
+			v, err := nodeValue(c)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, v)
+		}
+		return s, nil
+	}
+	var v interface{}
+	err := n.Decode(&v)
+	return v, err
+}
+
+// normalizeTypes replaces the 3.1 type list with the single type and the nullable flag.
+func normalizeTypes(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if types, ok := v["type"].([]interface{}); ok {
+			delete(v, "type")
+			for _, t := range types {
+				if t == "null" {
+					v["nullable"] = true
+				} else if s, ok := t.(string); ok {
+					v["type"] = s
+				}
+			}
+		}
+		for _, e := range v {
+			normalizeTypes(e)
+		}
+	case []interface{}:
+		for _, e := range v {
+			normalizeTypes(e)
+		}
+	}
+}
+
+func (i *importer) methods() ([]method, error) {
+	paths := make([]string, 0, len(i.doc.Paths))
+	for p := range i.doc.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	usedNames := map[string]bool{}
+
+	var methods []method
+	for _, pathStr := range paths {
+		p := i.doc.Paths[pathStr]
+		if p == nil {
+			continue
+		}
+		if p.Ref != "" {
+			return nil, fmt.Errorf("the path %s: $ref is not supported", pathStr)
+		}
+		for _, op := range []struct {
+			name string
+			o    *openapi.Operation
+		}{
+			{"GET", p.Get},
+			{"POST", p.Post},
+			{"PUT", p.Put},
+			{"PATCH", p.Patch},
+			{"DELETE", p.Delete},
+		} {
+			if op.o == nil {
+				continue
+			}
+			m, err := i.method(op.name, pathStr, op.o)
+			if err != nil {
+				return nil, fmt.Errorf("the %s %s operation: %w", op.name, pathStr, err)
+			}
+			m.name = uniqueName(m.name, usedNames)
+			methods = append(methods, m)
+		}
+	}
+	return methods, nil
+}
+
+func (i *importer) method(httpMethod, pathStr string, o *openapi.Operation) (method, error) {
+	m := method{httpMethod: httpMethod, path: pathStr, tags: o.Tags}
+
+	switch {
+	case o.OperationID != "":
+		m.name = goName(o.OperationID, true)
+	case isIdentifier(o.Summary):
+		m.name = goName(o.Summary, true)
+	default:
+		m.name = goName(strings.ToLower(httpMethod)+" "+pathStr, true)
+	}
+	if o.Summary != "" && o.Summary != m.name {
+		m.comments = append(m.comments, strings.Split(o.Summary, "\n")...)
+	}
+	if o.Description != "" {
+		m.comments = append(m.comments, strings.Split(o.Description, "\n")...)
+	}
+
+	usedParams := map[string]bool{"ctx": true, "err": true}
+
+	for _, p := range o.Parameters {
+		if p.Ref == "" && p.In != "path" && p.In != "query" && p.In != "header" {
+			i.warnings = append(i.warnings, fmt.Sprintf("%s %s: the %s parameter %q is not imported", httpMethod, pathStr, p.In, p.Name))
+		}
+	}
+	for _, in := range []string{"path", "query", "header"} {
+		for _, p := range o.Parameters {
+			if p.Ref != "" {
+				return m, fmt.Errorf("the parameter $ref is not supported")
+			}
+			if p.In != in {
+				continue
+			}
+			name := uniqueName(goName(p.Name, false), usedParams)
+			if in == "path" {
+				m.path = strings.Replace(m.path, "{"+p.Name+"}", "{"+name+"}", 1)
+			}
+			goType := i.paramType(p.Schema)
+			if t := i.resolve(p.Schema).Type; goType == "string" && t != "" && t != "string" {
+				i.warnings = append(i.warnings, fmt.Sprintf("%s %s: the %s parameter %q of the %s type is imported as the string", httpMethod, pathStr, in, p.Name, t))
+			}
+			m.params = append(m.params, param{
+				name:   name,
+				wire:   p.Name,
+				in:     in,
+				goType: goType,
+			})
+		}
+	}
+
+	if o.RequestBody != nil {
+		s := jsonSchema(o.RequestBody.Content)
+		if s == nil && len(o.RequestBody.Content) > 0 {
+			i.warnings = append(i.warnings, fmt.Sprintf("%s %s: the request body of the %s media type is not imported", httpMethod, pathStr, strings.Join(sortedMediaTypes(o.RequestBody.Content), ", ")))
+		}
+		if s != nil {
+			if props := i.objectProperties(s); props != nil {
+				// the params are sent as the body properties, so the object is flattened to the params.
+				if s.Ref != "" {
+					i.warnings = append(i.warnings, fmt.Sprintf("%s %s: the request body %s is imported as the method params", httpMethod, pathStr, s.Ref))
+				}
+				if required := i.resolve(s).Required; len(required) > 0 {
+					i.warnings = append(i.warnings, fmt.Sprintf("%s %s: the required request body properties %s are not checked", httpMethod, pathStr, strings.Join(required, ", ")))
+				}
+				for _, prop := range sortedKeys(props) {
+					name := goName(prop, false)
+					if strcase.ToLowerCamel(name) != prop {
+						i.warnings = append(i.warnings, fmt.Sprintf("%s %s: the request body property %q is sent as %q", httpMethod, pathStr, prop, strcase.ToLowerCamel(name)))
+					}
+					m.params = append(m.params, param{
+						name:   uniqueName(name, usedParams),
+						in:     "body",
+						goType: i.typeExpr(props[prop], m.name+goName(prop, true), i.imports),
+					})
+				}
+			} else {
+				i.warnings = append(i.warnings, fmt.Sprintf("%s %s: the request body is sent as the body property", httpMethod, pathStr))
+				m.params = append(m.params, param{
+					name:   uniqueName("body", usedParams),
+					in:     "body",
+					goType: i.typeExpr(s, m.name+"Request", i.imports),
+				})
+			}
+		}
+	}
+
+	codes := make([]string, 0, len(o.Responses))
+	for code := range o.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	if len(codes) == 0 {
+		return m, nil
+	}
+	// the server responds with the 200 status code.
+	if codes[0] != "200" {
+		i.warnings = append(i.warnings, fmt.Sprintf("%s %s: the %s response is imported as the 200 response", httpMethod, pathStr, codes[0]))
+	}
+	if len(codes) > 1 {
+		i.warnings = append(i.warnings, fmt.Sprintf("%s %s: the %s responses are not imported", httpMethod, pathStr, strings.Join(codes[1:], ", ")))
+	}
+	response := o.Responses[codes[0]]
+	if media, ok := response.Content["text/event-stream"]; ok && media.Schema != nil {
+		m.results = []result{{goType: "<-chan " + i.typeExpr(media.Schema, m.name+"Event", i.imports)}}
+		return m, nil
+	}
+	s := jsonSchema(response.Content)
+	if s == nil {
+		if len(response.Content) > 0 {
+			i.warnings = append(i.warnings, fmt.Sprintf("%s %s: the %s response of the %s media type is not imported", httpMethod, pathStr, codes[0], strings.Join(sortedMediaTypes(response.Content), ", ")))
+		}
+		return m, nil
+	}
+	if s.Ref == "" && s.Type == "object" && len(s.Properties) > 1 && s.AdditionalProperties == nil {
+		// the several results are encoded as the object properties, the names must match the encoding.
+		names := sortedKeys(s.Properties)
+		ok := true
+		for _, name := range names {
+			if !isIdentifier(name) || strcase.ToLowerCamel(name) != name || usedParams[name] || token.IsKeyword(name) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			for _, name := range names {
+				m.results = append(m.results, result{
+					name:   name,
+					goType: i.typeExpr(s.Properties[name], m.name+goName(name, true), i.imports),
+				})
+			}
+			return m, nil
+		}
+	}
+	m.results = []result{{goType: i.typeExpr(s, m.name+"Response", i.imports)}}
+	return m, nil
+}
+
+// paramType returns the type of the path, query or header parameter, the types that
+// the server can not convert from the string are imported as the string.
+func (i *importer) paramType(s *openapi.Schema) string {
+	if s == nil {
+		return "string"
+	}
+	if s.Ref != "" {
+		if r := i.resolve(s); r.Type == "string" && len(r.Enum) > 0 && !r.Nullable {
+			return i.componentType(s.Ref)
+		}
+		s = i.resolve(s)
+	}
+	switch s.Type {
+	case "integer", "number", "boolean":
+		return i.typeExpr(s, "", i.imports)
+	case "array":
+		if s.Items != nil {
+			if t := i.paramType(s.Items); !i.isEnum(t) {
+				return "[]" + t
+			}
+		}
+	}
+	return "string"
+}
+
+func (i *importer) isEnum(t string) bool {
+	for _, e := range i.enums {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
+
+// objectProperties returns the properties of the inline or referenced object schema.
+func (i *importer) objectProperties(s *openapi.Schema) openapi.Properties {
+	s = i.resolve(s)
+	if s.Type == "object" && len(s.Properties) > 0 && s.AdditionalProperties == nil && len(s.AllOf) == 0 {
+		return s.Properties
+	}
+	return nil
+}
+
+func (i *importer) resolve(s *openapi.Schema) *openapi.Schema {
+	for depth := 0; s != nil && s.Ref != "" && depth < 32; depth++ {
+		s = i.doc.Components.Schemas[strings.TrimPrefix(s.Ref, componentsSchemasRef)]
+	}
+	if s == nil {
+		return &openapi.Schema{}
+	}
+	return s
+}
+
+// typeExpr returns the Go type of the schema, the inline objects are declared as the types named by the hint.
+func (i *importer) typeExpr(s *openapi.Schema, hint string, imports map[string]bool) string {
+	if s == nil {
+		return "interface{}"
+	}
+	if s.Ref != "" {
+		t := i.componentType(s.Ref)
+		if s.Nullable {
+			return "*" + t
+		}
+		return t
+	}
+	if len(s.AllOf) == 1 && s.Type == "" && len(s.Properties) == 0 {
+		t := i.typeExpr(&s.AllOf[0], hint, imports)
+		if s.Nullable && !strings.HasPrefix(t, "*") {
+			return pointer(t)
+		}
+		return t
+	}
+	if len(s.AnyOf) == 2 && s.Type == "" {
+		// the 3.1 nullable schema is anyOf the value and the null type.
+		for idx, v := range s.AnyOf {
+			if v.Type == "null" {
+				return pointer(i.typeExpr(&s.AnyOf[1-idx], hint, imports))
+			}
+		}
+	}
+	var t string
+	switch s.Type {
+	case "string":
+		switch s.Format {
+		case "date-time":
+			imports["time"] = true
+			t = "time.Time"
+		case "byte", "binary":
+			t = "[]byte"
+		default:
+			t = "string"
+		}
+	case "integer":
+		switch s.Format {
+		case "int32":
+			t = "int32"
+		case "int64":
+			t = "int64"
+		default:
+			t = "int"
+		}
+	case "number":
+		if s.Format == "float" {
+			t = "float32"
+		} else {
+			t = "float64"
+		}
+	case "boolean":
+		t = "bool"
+	case "array":
+		t = "[]" + i.typeExpr(s.Items, hint+"Item", imports)
+	case "object", "":
+		switch {
+		case len(s.Properties) > 0 || len(s.AllOf) > 0:
+			t = i.declareType(uniqueName(goName(hint, true), i.names), s)
+		case s.AdditionalProperties != nil:
+			t = "map[string]" + i.typeExpr(s.AdditionalProperties, hint+"Value", imports)
+		case s.Type == "object":
+			t = "map[string]interface{}"
+		default:
+			t = "interface{}"
+		}
+	default:
+		t = "interface{}"
+	}
+	if s.Nullable {
+		return pointer(t)
+	}
+	return t
+}
+
+// componentType returns the type of the component schema, the type is declared on the first use.
+func (i *importer) componentType(ref string) string {
+	if !strings.HasPrefix(ref, componentsSchemasRef) {
+		i.warnings = append(i.warnings, fmt.Sprintf("the $ref %q is not supported, the interface{} type is used", ref))
+		return "interface{}"
+	}
+	component := strings.TrimPrefix(ref, componentsSchemasRef)
+	if t, ok := i.types[component]; ok {
+		return t
+	}
+	s, ok := i.doc.Components.Schemas[component]
+	if !ok || s == nil {
+		i.warnings = append(i.warnings, fmt.Sprintf("the $ref %q is not found, the interface{} type is used", ref))
+		return "interface{}"
+	}
+	name := uniqueName(goName(component, true), i.names)
+	// the type is registered before the declaration, so the recursive schemas refer to it.
+	i.types[component] = name
+	return i.declareType(name, s)
+}
+
+// declareType adds the declaration of the type, the place is reserved before the nested types are declared.
+func (i *importer) declareType(name string, s *openapi.Schema) string {
+	idx := len(i.decls)
+	i.decls = append(i.decls, "")
+	i.decls[idx] = i.declare(name, s)
+	return name
+}
+
+func (i *importer) declare(name string, s *openapi.Schema) string {
+	w := new(writer.BaseWriter)
+	writeComment(w, s.Description)
+
+	if s.Type == "string" && len(s.Enum) > 0 && !s.Nullable {
+		i.enums = append(i.enums, name)
+		w.W("type %s string\n\n", name)
+		w.W("const (\n")
+		usedNames := map[string]bool{}
+		for _, e := range s.Enum {
+			v := fmt.Sprint(e)
+			w.W("%s %s = %s\n", uniqueName(name+goName(v, true), usedNames), name, strconv.Quote(v))
+		}
+		w.W(")\n")
+		return w.String()
+	}
+
+	if len(s.Properties) == 0 && len(s.AllOf) == 0 {
+		w.W("type %s %s\n", name, i.typeExpr(&openapi.Schema{
+			Type:                 s.Type,
+			Format:               s.Format,
+			Items:                s.Items,
+			AdditionalProperties: s.AdditionalProperties,
+			AnyOf:                s.AnyOf,
+		}, name, map[string]bool{}))
+		return w.String()
+	}
+
+	w.W("type %s struct {\n", name)
+	usedFields := map[string]bool{}
+	for idx := range s.AllOf {
+		part := &s.AllOf[idx]
+		if part.Ref != "" {
+			// the composed schemas are embedded, encoding/json inlines the fields of the embedded struct.
+			w.W("%s\n", i.componentType(part.Ref))
+			continue
+		}
+		i.writeFields(w, name, part, usedFields)
+	}
+	i.writeFields(w, name, s, usedFields)
+	w.W("}\n")
+	return w.String()
+}
+
+func (i *importer) writeFields(w *writer.BaseWriter, typeName string, s *openapi.Schema, usedFields map[string]bool) {
+	required := map[string]bool{}
+	for _, name := range s.Required {
+		required[name] = true
+	}
+	for _, prop := range sortedKeys(s.Properties) {
+		ps := s.Properties[prop]
+		fieldName := uniqueName(goName(prop, true), usedFields)
+		fieldType := i.typeExpr(ps, typeName+fieldName, map[string]bool{})
+
+		tags := []string{}
+		jsonTag := prop
+		if !required[prop] && !strings.HasPrefix(fieldType, "*") {
+			jsonTag += ",omitempty"
+		}
+		tags = append(tags, "json:"+strconv.Quote(jsonTag))
+
+		var rules []string
+		if required[prop] && strings.HasPrefix(fieldType, "*") {
+			rules = append(rules, "required")
+		}
+		rules = append(rules, validateRules(i.resolveInline(ps))...)
+		if len(rules) > 0 {
+			tags = append(tags, "validate:"+strconv.Quote(strings.Join(rules, ",")))
+		}
+		if ps != nil && ps.Example != nil {
+			tags = append(tags, "example:"+strconv.Quote(exampleValue(ps.Example)))
+		}
+
+		w.W("%s %s `%s`", fieldName, fieldType, strings.Join(tags, " "))
+		if ps != nil && strings.TrimSpace(ps.Description) != "" {
+			// the field comment is the line comment, so the description is exported back.
+			w.W(" // %s", strings.Join(strings.Fields(ps.Description), " "))
+		}
+		w.W("\n")
+	}
+}
+
+// resolveInline returns the schema of the inline value, the constraints of the components are not repeated in the tags.
+func (i *importer) resolveInline(s *openapi.Schema) *openapi.Schema {
+	if s == nil || s.Ref != "" {
+		return &openapi.Schema{}
+	}
+	return s
+}
+
+// validateRules maps the schema constraints to the go-playground/validator rules.
+func validateRules(s *openapi.Schema) (rules []string) {
+	switch s.Format {
+	case "email", "uuid", "hostname", "ipv4", "ipv6":
+		rules = append(rules, s.Format)
+	case "uri":
+		rules = append(rules, "url")
+	}
+	if s.Type == "string" && len(s.Enum) > 0 {
+		values := make([]string, 0, len(s.Enum))
+		for _, e := range s.Enum {
+			values = append(values, fmt.Sprint(e))
+		}
+		rules = append(rules, "oneof="+strings.Join(values, " "))
+	}
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	if s.Minimum != nil {
+		rules = append(rules, "min="+formatFloat(*s.Minimum))
+	}
+	if s.Maximum != nil {
+		rules = append(rules, "max="+formatFloat(*s.Maximum))
+	}
+	for _, c := range []struct {
+		rule string
+		v    *uint64
+	}{
+		{"min", s.MinLength},
+		{"max", s.MaxLength},
+		{"min", s.MinItems},
+		{"max", s.MaxItems},
+	} {
+		if c.v != nil {
+			rules = append(rules, c.rule+"="+strconv.FormatUint(*c.v, 10))
+		}
+	}
+	return
+}
+
+func exampleValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func (i *importer) writeHeader(w *writer.BaseWriter, imports map[string]bool) {
+	w.W("package %s\n\n", i.cfg.Package)
+	if len(imports) == 0 {
+		return
+	}
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if isStdPkg(paths[i]) != isStdPkg(paths[j]) {
+			return isStdPkg(paths[i])
+		}
+		return paths[i] < paths[j]
+	})
+	w.W("import (\n")
+	for idx, path := range paths {
+		// the standard packages are grouped before the other packages.
+		if idx > 0 && !isStdPkg(path) && isStdPkg(paths[idx-1]) {
+			w.W("\n")
+		}
+		w.W("%s\n", strconv.Quote(path))
+	}
+	w.W(")\n\n")
+}
+
+func (i *importer) writeInterface(w *writer.BaseWriter, methods []method) {
+	writeComment(w, i.doc.Info.Description)
+	w.W("type %s interface {\n", i.cfg.Interface)
+	for _, m := range methods {
+		for _, c := range m.comments {
+			w.W("// %s\n", c)
+		}
+		params := []string{"ctx context.Context"}
+		for _, p := range m.params {
+			params = append(params, p.name+" "+p.goType)
+		}
+		w.W("%s(%s) ", m.name, strings.Join(params, ", "))
+		switch {
+		case len(m.results) == 0:
+			w.W("error\n")
+		case m.results[0].name == "":
+			w.W("(%s, error)\n", m.results[0].goType)
+		default:
+			results := make([]string, 0, len(m.results)+1)
+			for _, r := range m.results {
+				results = append(results, r.name+" "+r.goType)
+			}
+			results = append(results, "err error")
+			w.W("(%s)\n", strings.Join(results, ", "))
+		}
+	}
+	w.W("}\n")
+}
+
+func (i *importer) writeSwipe(w *writer.BaseWriter, methods []method) {
+	iface := i.cfg.Interface
+
+	w.W("func Swipe() {\n")
+	w.W("swipe.Build(\n")
+	w.W("swipe.Service((*%s)(nil),\n", iface)
+	w.W("swipe.Transport(\"http\",\n")
+	w.W("swipe.ClientEnable(),\n")
+
+	w.W("swipe.Openapi(\n")
+	info := i.doc.Info
+	w.W("swipe.OpenapiInfo(%s, %s, %s),\n", strconv.Quote(info.Title), strconv.Quote(info.Description), strconv.Quote(info.Version))
+	if info.Contact != nil {
+		w.W("swipe.OpenapiContact(%s, %s, %s),\n", strconv.Quote(info.Contact.Name), strconv.Quote(info.Contact.Email), strconv.Quote(info.Contact.URL))
+	}
+	if info.License != nil {
+		w.W("swipe.OpenapiLicence(%s, %s),\n", strconv.Quote(info.License.Name), strconv.Quote(info.License.URL))
+	}
+	for _, s := range i.doc.Servers {
+		w.W("swipe.OpenapiServer(%s, %s),\n", strconv.Quote(s.Description), strconv.Quote(s.URL))
+	}
+	if i.cfg.Format != "json" {
+		w.W("swipe.OpenapiFormat(%s),\n", strconv.Quote(i.cfg.Format))
+	}
+	if strings.HasPrefix(i.doc.OpenAPI, "3.1") {
+		w.W("swipe.OpenapiVersion(\"3.1\"),\n")
+	}
+	// the methods with the same tags are grouped in the one option.
+	var tagKeys []string
+	tagMethods := map[string][]string{}
+	for _, m := range methods {
+		if len(m.tags) == 0 {
+			continue
+		}
+		key := strings.Join(m.tags, "\x00")
+		if _, ok := tagMethods[key]; !ok {
+			tagKeys = append(tagKeys, key)
+		}
+		tagMethods[key] = append(tagMethods[key], iface+"."+m.name)
+	}
+	for _, key := range tagKeys {
+		tags := strings.Split(key, "\x00")
+		for idx, tag := range tags {
+			tags[idx] = strconv.Quote(tag)
+		}
+		w.W("swipe.OpenapiTags([]interface{}{%s}, []string{%s}),\n", strings.Join(tagMethods[key], ", "), strings.Join(tags, ", "))
+	}
+	w.W("),\n")
+
+	for _, m := range methods {
+		w.W("swipe.MethodOptions(%s.%s,\n", iface, m.name)
+		w.W("swipe.Path(%s),\n", strconv.Quote(m.path))
+		w.W("swipe.Method(http.Method%s),\n", strcase.ToCamel(strings.ToLower(m.httpMethod)))
+		for _, in := range []struct {
+			in     string
+			option string
+		}{
+			{"query", "QueryVars"},
+			{"header", "HeaderVars"},
+		} {
+			var vars []string
+			for _, p := range m.params {
+				if p.in == in.in {
+					vars = append(vars, strconv.Quote(p.name), strconv.Quote(p.wire))
+				}
+			}
+			if len(vars) > 0 {
+				w.W("swipe.%s([]string{%s}),\n", in.option, strings.Join(vars, ", "))
+			}
+		}
+		w.W("),\n")
+	}
+
+	w.W("),\n")
+	w.W("),\n")
+	w.W(")\n")
+	w.W("}\n")
+
+	for _, e := range i.enums {
+		w.W("\nfunc Swipe%s() {\n", e)
+		w.W("swipe.Build(\n")
+		w.W("swipe.Enum((*%s)(nil)),\n", e)
+		w.W(")\n")
+		w.W("}\n")
+	}
+}
+
+func jsonSchema(content openapi.Content) *openapi.Schema {
+	if m, ok := content["application/json"]; ok {
+		return m.Schema
+	}
+	for _, mediaType := range sortedMediaTypes(content) {
+		if strings.HasSuffix(mediaType, "json") {
+			return content[mediaType].Schema
+		}
+	}
+	return nil
+}
+
+func sortedMediaTypes(content openapi.Content) []string {
+	keys := make([]string, 0, len(content))
+	for k := range content {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedKeys(props openapi.Properties) []string {
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeComment(w *writer.BaseWriter, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		w.W("// %s\n", strings.TrimRightFunc(line, unicode.IsSpace))
+	}
+}
+
+func pointer(t string) string {
+	if strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[") || t == "interface{}" || strings.HasPrefix(t, "*") {
+		return t
+	}
+	return "*" + t
+}
+
+func isStdPkg(path string) bool {
+	return !strings.Contains(strings.SplitN(path, "/", 2)[0], ".")
+}
+
+func uniqueName(name string, used map[string]bool) string {
+	unique := name
+	for n := 2; used[unique]; n++ {
+		unique = name + strconv.Itoa(n)
+	}
+	used[unique] = true
+	return unique
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for idx, r := range s {
+		if !unicode.IsLetter(r) && r != '_' && (idx == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+var initialisms = map[string]string{
+	"Api":  "API",
+	"Html": "HTML",
+	"Http": "HTTP",
+	"Id":   "ID",
+	"Ip":   "IP",
+	"Json": "JSON",
+	"Uri":  "URI",
+	"Url":  "URL",
+	"Uuid": "UUID",
+}
+
+// goName returns the Go identifier of the name, the exported names use the common initialisms.
+func goName(s string, exported bool) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, s)
+	var name string
+	if exported {
+		var b strings.Builder
+		for _, word := range splitWords(strcase.ToCamel(s)) {
+			if v, ok := initialisms[word]; ok {
+				word = v
+			}
+			b.WriteString(word)
+		}
+		name = b.String()
+	} else {
+		name = strcase.ToLowerCamel(s)
+	}
+	if name == "" {
+		name = "value"
+		if exported {
+			name = "Value"
+		}
+	}
+	if unicode.IsDigit([]rune(name)[0]) {
+		name = "V" + name
+	}
+	if token.IsKeyword(name) {
+		name += "_"
+	}
+	return name
+}
+
+// splitWords splits the camel case name before the each upper case letter.
+func splitWords(s string) (words []string) {
+	start := 0
+	for idx, r := range s {
+		if idx > 0 && unicode.IsUpper(r) {
+			words = append(words, s[start:idx])
+			start = idx
+		}
+	}
+	return append(words, s[start:])
+}
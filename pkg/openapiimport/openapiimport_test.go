@@ -0,0 +1,121 @@
+package openapiimport
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// TestImportGolden imports the documents of the testdata directories and compares the generated files
+// and the warnings with the golden files.
+func TestImportGolden(t *testing.T) {
+	dirs, err := filepath.Glob(filepath.Join("testdata", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range dirs {
+		dir := dir
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			data, err := ioutil.ReadFile(filepath.Join(dir, "openapi.yaml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			result, err := Import(data, Config{Package: filepath.Base(dir), Format: "yaml"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			files := map[string][]byte{"warnings": []byte(strings.Join(result.Warnings, "\n") + "\n")}
+			for name, data := range result.Files {
+				files[name] = data
+			}
+			for name, data := range files {
+				golden := filepath.Join(dir, name+".golden")
+				if *update {
+					if err := ioutil.WriteFile(golden, data, 0644); err != nil {
+						t.Fatal(err)
+					}
+					continue
+				}
+				want, err := ioutil.ReadFile(golden)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(data) != string(want) {
+					t.Errorf("%s: got\n%s\nwant\n%s", name, data, want)
+				}
+			}
+		})
+	}
+}
+
+func TestImportWarnings(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want []string
+	}{
+		{
+			name: "lossless",
+			doc: `{"openapi":"3.0.0","paths":{"/get":{"get":{"operationId":"get","responses":{"200":{"description":"OK",
+				"content":{"application/json":{"schema":{"type":"string","nullable":true}}}}}}}}}`,
+		},
+		{
+			name: "status code",
+			doc:  `{"openapi":"3.0.0","paths":{"/create":{"post":{"operationId":"create","responses":{"201":{"description":"Created"},"204":{"description":"No Content"}}}}}}`,
+			want: []string{
+				"POST /create: the 201 response is imported as the 200 response",
+				"POST /create: the 204 responses are not imported",
+			},
+		},
+		{
+			name: "oneOf",
+			doc: `{"openapi":"3.0.0","paths":{},"components":{"schemas":{"Value":{"oneOf":[{"type":"string"},{"type":"integer"}]},
+				"Nullable":{"anyOf":[{"type":"string"},{"type":"null"}]},"Any":{"anyOf":[{"type":"string"},{"type":"integer"}]}}}}`,
+			want: []string{
+				"#/components/schemas/Any: the anyOf keyword is supported only with the null type, the schema is imported without it",
+				"#/components/schemas/Value: the oneOf keyword is not supported, the schema is imported without it",
+			},
+		},
+		{
+			name: "property names",
+			doc:  `{"openapi":"3.0.0","paths":{},"components":{"schemas":{"not":{"type":"object","properties":{"oneOf":{"type":"string"}}}}}}`,
+		},
+		{
+			name: "body ref",
+			doc: `{"openapi":"3.0.0","paths":{"/create":{"post":{"operationId":"create",
+				"requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/User"}}}},"responses":{"200":{"description":"OK"}}}}},
+				"components":{"schemas":{"User":{"type":"object","required":["name"],"properties":{"name":{"type":"string"}}}}}}`,
+			want: []string{
+				"POST /create: the request body #/components/schemas/User is imported as the method params",
+				"POST /create: the required request body properties name are not checked",
+			},
+		},
+		{
+			name: "parameters",
+			doc: `{"openapi":"3.0.0","paths":{"/get":{"get":{"operationId":"get","parameters":[
+				{"name":"sid","in":"cookie","schema":{"type":"string"}},{"name":"filter","in":"query","schema":{"type":"object"}}],
+				"responses":{"200":{"description":"OK","content":{"text/plain":{"schema":{"type":"string"}}}}}}}}}`,
+			want: []string{
+				`GET /get: the cookie parameter "sid" is not imported`,
+				`GET /get: the query parameter "filter" of the object type is imported as the string`,
+				"GET /get: the 200 response of the text/plain media type is not imported",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Import([]byte(tt.doc), Config{Package: "api"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result.Warnings, tt.want) {
+				t.Fatalf("got %q, want %q", result.Warnings, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package openrpc
+
+import "github.com/swipe-io/swipe/pkg/openapi"
+
+type Contact struct {
+	Name  string `json:"name,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+type License struct {
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+type Info struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Contact     *Contact `json:"contact,omitempty"`
+	License     *License `json:"license,omitempty"`
+	Version     string   `json:"version"`
+}
+
+type Server struct {
+	Name        string `json:"name,omitempty"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+type ContentDescriptor struct {
+	Name        string          `json:"name"`
+	Summary     string          `json:"summary,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Required    bool            `json:"required,omitempty"`
+	Schema      *openapi.Schema `json:"schema"`
+}
+
+type Error struct {
+	Code    int64       `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type Method struct {
+	Name           string              `json:"name"`
+	Tags           []Tag               `json:"tags,omitempty"`
+	Summary        string              `json:"summary,omitempty"`
+	Description    string              `json:"description,omitempty"`
+	ParamStructure string              `json:"paramStructure,omitempty"`
+	Params         []ContentDescriptor `json:"params"`
+	Result         *ContentDescriptor  `json:"result,omitempty"`
+	Errors         []Error             `json:"errors,omitempty"`
+}
+
+type Components struct {
+	Schemas openapi.Schemas `json:"schemas,omitempty"`
+}
+
+type OpenRPC struct {
+	OpenRPC    string     `json:"openrpc"`
+	Info       Info       `json:"info"`
+	Servers    []Server   `json:"servers,omitempty"`
+	Methods    []Method   `json:"methods"`
+	Components Components `json:"components,omitempty"`
+}
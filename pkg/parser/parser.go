@@ -49,8 +49,8 @@ func (v Value) String() (value string) {
 }
 
 func (v Value) Int() (value int) {
-	value, _ = v.v.(int)
-	return
+	i, _ := v.v.(int64)
+	return int(i)
 }
 
 func (v Value) Bool() (value bool) {
@@ -130,7 +130,8 @@ func (p Parser) process(expr ast.Expr) (*Option, error) {
 		fnSig := fnObj.Type().(*types.Signature)
 
 		for i, expr := range v.Args {
-			if fnSig.Variadic() && i >= fnSig.Params().Len()-1 {
+			_, isCall := astutil.Unparen(expr).(*ast.CallExpr)
+			if fnSig.Variadic() && i >= fnSig.Params().Len()-1 && isCall {
 				val, err := p.process(expr)
 				if err != nil {
 					return nil, err
@@ -145,10 +146,15 @@ func (p Parser) process(expr ast.Expr) (*Option, error) {
 					expr: v.Args[i],
 					pos:  p.pkg.Fset.Position(v.Args[i].Pos()),
 				}
-				if fnSig.Params().Len() == 1 {
+				if fnSig.Params().Len() == 1 && !fnSig.Variadic() {
 					result.Value = v
 				} else {
-					name := fnSig.Params().At(i).Name()
+					paramIdx := i
+					if paramIdx >= fnSig.Params().Len() {
+						// variadic values are collected under the name of the last parameter.
+						paramIdx = fnSig.Params().Len() - 1
+					}
+					name := fnSig.Params().At(paramIdx).Name()
 
 					result.Properties[name] = append(
 						result.Properties[name],
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const testSrc = `package options
+
+type Option string
+
+func Build(opts ...Option) {}
+
+func Method(name string) Option { return "" }
+
+func Require(permissions ...string) Option { return "" }
+
+func Vars(key string, values ...string) Option { return "" }
+
+func Service(opts ...Option) Option { return "" }
+
+var (
+	method    = Method("GET")
+	require   = Require("read", "write")
+	noRequire = Require()
+	vars      = Vars("id", "a", "b")
+	service   = Service(Method("POST"), Require("admin"), (Require("root")))
+)
+`
+
+// parseTestOptions parses the values of the package vars of the test source by the var name.
+func parseTestOptions(t *testing.T) map[string]*Option {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "options.go", testSrc, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Defs:  map[*ast.Ident]types.Object{},
+		Uses:  map[*ast.Ident]types.Object{},
+	}
+	if _, err := (&types.Config{}).Check("options", fset, []*ast.File{f}, info); err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser(&packages.Package{Fset: fset, TypesInfo: info})
+
+	options := map[string]*Option{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if vs, ok := n.(*ast.ValueSpec); ok {
+			o, err := p.Parse(vs.Values[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			options[vs.Names[0].Name] = o
+		}
+		return true
+	})
+	return options
+}
+
+// propertyValues returns the string values of the property, the value of the nested option is its single argument.
+func propertyValues(o *Option, key string) (values []string) {
+	for _, v := range o.Properties[key] {
+		values = append(values, v.Value.String())
+	}
+	return values
+}
+
+func TestParseVariadic(t *testing.T) {
+	options := parseTestOptions(t)
+
+	tests := []struct {
+		name       string
+		value      string
+		properties map[string][]string
+	}{
+		{name: "method", value: "GET", properties: map[string][]string{}},
+		{name: "require", properties: map[string][]string{"permissions": {"read", "write"}}},
+		{name: "noRequire", properties: map[string][]string{}},
+		{name: "vars", properties: map[string][]string{"key": {"id"}, "values": {"a", "b"}}},
+		{name: "service", properties: map[string][]string{"Method": {"POST"}, "Require": {"", ""}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := options[tt.name]
+			if o.Value.String() != tt.value {
+				t.Fatalf("value: got %q, want %q", o.Value.String(), tt.value)
+			}
+			properties := map[string][]string{}
+			for key := range o.Properties {
+				properties[key] = propertyValues(o, key)
+			}
+			if !reflect.DeepEqual(properties, tt.properties) {
+				t.Fatalf("properties: got %v, want %v", properties, tt.properties)
+			}
+		})
+	}
+
+	// the nested options are parsed with their own values.
+	service := options["service"]
+	if m, ok := service.At("Method"); !ok || m.Value.String() != "POST" {
+		t.Fatalf("service method: got %+v", m)
+	}
+	var permissions []string
+	for _, r := range service.Properties["Require"] {
+		permissions = append(permissions, propertyValues(r, "permissions")...)
+	}
+	if want := []string{"admin", "root"}; !reflect.DeepEqual(permissions, want) {
+		t.Fatalf("service permissions: got %v, want %v", permissions, want)
+	}
+}
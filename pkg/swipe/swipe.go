@@ -33,7 +33,7 @@
 // 				ClientEnable(),
 // 				Openapi(
 // 					OpenapiOutput("/../../docs"),
-// 					OpenapiVersion("1.0.0"),
+// 					OpenapiVersion("3.1"),
 // 				),
 // 			),
 // 			Logging(),
@@ -43,6 +43,8 @@
 //  }
 package swipe
 
+import "time"
+
 // A Option is an option for a Swipe.
 type Option string
 
@@ -55,6 +57,9 @@ type InstrumentingOption string
 // A LoggingOption is an option logging.
 type LoggingOption string
 
+// A TracingOption is an option tracing.
+type TracingOption string
+
 // A TransportOption is an option gokit transport.
 type TransportOption string
 
@@ -70,6 +75,12 @@ type OpenapiOption string
 // A ConfigEnvOption is an option env config.
 type ConfigEnvOption string
 
+// A OpenRPCOption is an option for openrpc doc.
+type OpenRPCOption string
+
+// A EnumOption is an option for the enum generation.
+type EnumOption string
+
 // A OpenapiServersOption is an openapi servers option.
 type OpenapiServersOption string
 
@@ -84,6 +95,12 @@ type GatewayServiceMethodOption string
 
 type ReadmeOption string
 
+// A AuthOption is an option JWT authentication.
+type AuthOption string
+
+// A CircuitBreakerOption is an option circuit breaker.
+type CircuitBreakerOption string
+
 // Build the basic option for defining the generation.
 func Build(Option) {
 }
@@ -122,6 +139,32 @@ func FuncName(string) ConfigEnvOption {
 	return "implementation not generated, run swipe"
 }
 
+// Enum option for enum generation.
+//
+// Generates the String, Values, IsValid, MarshalText and UnmarshalText methods and the Parse<Type> function
+// for the const group of the type, the type must be declared in the package of the Build call.
+// Given enum is nil pointer of the type, for example:
+//  (*Status)(nil)
+//
+// The const names are used as the text form of the integer enums, by default the JSON form is the text form.
+// The zero value that is not a const is encoded as the empty text.
+// The servers check the request fields of the type are set to one of the consts or the zero value.
+// Only the types with the Enum option are validated and listed as enums in the OpenAPI and OpenRPC documents
+// and the JS client types, the other const groups are documented as the underlying type.
+func Enum(enum interface{}, opts ...EnumOption) Option {
+	return "implementation not generated, run swipe"
+}
+
+// EnumTrimPrefix trims the prefix from the const names in the text form.
+func EnumTrimPrefix(prefix string) EnumOption {
+	return "implementation not generated, run swipe"
+}
+
+// EnumJSONValue encodes the enum to JSON as the const value instead of the text form.
+func EnumJSONValue() EnumOption {
+	return "implementation not generated, run swipe"
+}
+
 // Service a option that defines the generation of transport, metrics, tracing, and logging for gokit.
 // Given iface is nil pointer interface, for example:
 //  (*pkg.Iface)(nil)
@@ -139,11 +182,107 @@ func Logging(...LoggingOption) ServiceOption {
 	return "implementation not generated, run swipe"
 }
 
+// LogSkip sets the names of the method params and results that are not written to the log.
+func LogSkip(names ...string) LoggingOption {
+	return "implementation not generated, run swipe"
+}
+
+// LogRedact sets the names of the method params and results, values of which are replaced with "[REDACTED]" in the log.
+//
+// The fields of the structures are skipped or redacted with the log:"-" and log:"redact" struct tags.
+// The values implementing fmt.Stringer, encoding.TextMarshaler or json.Marshaler are written
+// in the text form, except the structures with the log tags.
+func LogRedact(names ...string) LoggingOption {
+	return "implementation not generated, run swipe"
+}
+
+// LogMaxSize sets the maximum number of the elements of the slices and maps,
+// and the maximum length of the strings and byte payloads written to the log.
+func LogMaxSize(size int) LoggingOption {
+	return "implementation not generated, run swipe"
+}
+
+// LogSlowThreshold sets the duration of the method call, above which the successful call is logged at the warn level.
+func LogSlowThreshold(d time.Duration) LoggingOption {
+	return "implementation not generated, run swipe"
+}
+
+// LogSuccessLevel sets the level of the successful method calls: debug or info, by default info.
+//
+// The method calls that returned an error are logged at the error level.
+func LogSuccessLevel(level string) LoggingOption {
+	return "implementation not generated, run swipe"
+}
+
+// LoggerBackend sets the logger used by the logging middleware: kit (by default), zap, zerolog or slog.
+//
+//  kit - github.com/go-kit/kit/log.Logger
+//  zap - *go.uber.org/zap.Logger
+//  zerolog - github.com/rs/zerolog.Logger
+//  slog - *log/slog.Logger
+func LoggerBackend(name string) LoggingOption {
+	return "implementation not generated, run swipe"
+}
+
+// LogMethodOptions sets the logging options for the method, the options are merged with the service logging options.
+func LogMethodOptions(signature interface{}, opts ...LoggingOption) LoggingOption {
+	return "implementation not generated, run swipe"
+}
+
 // Instrumenting a option enabled instrumenting (collect metrics) middleware.
 func Instrumenting(namespace, subsystem string, opts ...InstrumentingOption) ServiceOption {
 	return "implementation not generated, run swipe"
 }
 
+// MetricsBackend sets the go-kit metrics provider used for the default metrics:
+// prometheus (by default), statsd, dogstatsd, expvar or none.
+//
+// For statsd and dogstatsd the generated constructor accepts the *statsd.Statsd or *dogstatsd.Dogstatsd provider,
+// for none the metrics are passed by the caller, not passed metrics are discarded.
+// statsd has no tags, so the method is put into the metric name (<namespace>.<subsystem>.<method>.requests_total)
+// and the error label is not used.
+func MetricsBackend(name string) InstrumentingOption {
+	return "implementation not generated, run swipe"
+}
+
+// InstrumentingBuckets sets the buckets (in seconds) of the request duration histogram, by default prometheus.DefBuckets.
+func InstrumentingBuckets(buckets ...float64) InstrumentingOption {
+	return "implementation not generated, run swipe"
+}
+
+// InstrumentingLabel adds the static label to the default metrics, the statsd and expvar backends have no labels.
+func InstrumentingLabel(name, value string) InstrumentingOption {
+	return "implementation not generated, run swipe"
+}
+
+// InstrumentingLegacyNames keeps the request_count counter and the request_latency_microseconds summary
+// instead of the requests_total counter and the request_duration_seconds histogram.
+//
+// The legacy metrics have only the method label, the requests in flight gauge is not generated and
+// the constructor keeps the previous signature:
+//  NewInstrumentingMiddleware<serviceName>(s, requestCount, requestLatency)
+func InstrumentingLegacyNames() InstrumentingOption {
+	return "implementation not generated, run swipe"
+}
+
+// Tracing a option enabled OpenTelemetry tracing middleware.
+//
+// Swipe generates the NewTracingMiddleware<serviceName>(s, tracer) function, the middleware starts a span
+// for each method call with the method params as the span attributes and records the returned error.
+// Only the params of the basic types are added as the attributes, for the slices, the arrays and the maps
+// the length is added, the structs and the pointers are skipped. The methods without the context.Context
+// param are not traced.
+//
+// The generated servers extract and the generated clients inject the W3C "traceparent" header.
+func Tracing(...TracingOption) ServiceOption {
+	return "implementation not generated, run swipe"
+}
+
+// TracingRedact sets the names of the method params, values of which are hidden in the span attributes.
+func TracingRedact(params ...string) TracingOption {
+	return "implementation not generated, run swipe"
+}
+
 // Transport a option that defines the transport generation settings.
 //
 // Swipe generates a method for creating an transport handler using the
@@ -152,6 +291,16 @@ func Instrumenting(namespace, subsystem string, opts ...InstrumentingOption) Ser
 //  MakeHandler<transportType><projectName><serviceName>
 //
 // <transportType> is REST or JSONRPC.
+//
+// REST methods with a receive-only channel result, e.g. (<-chan T, error), are served
+// as a server-sent events stream, the client returns a channel fed from the stream.
+// Use the <serviceName>ServerEventStreamHeartbeat server option to change the heartbeat interval (15s by default).
+// The stream ends with the context of the call, the error that ends the stream (the broken connection,
+// the invalid event or the canceled context) is sent to the channel passed with the client option:
+//  <serviceName><methodName>ClientStreamErrors(errs)
+// The error is sent without blocking before the events channel is closed, use a buffered channel.
+// The Timeout option cannot be used with the channel result, since its deadline would cancel the stream,
+// the Retry option retries only the opening of the stream.
 func Transport(protocol string, opts ...TransportOption) ServiceOption {
 	return "implementation not generated, run swipe"
 }
@@ -173,7 +322,38 @@ func FastEnable() TransportOption {
 	return "implementation not generated, run swipe"
 }
 
-// MarkdownDoc enable for generate markdown JSON RPC doc for JS client.
+// AccessLog enable the access log of the HTTP handler returned by MakeHandler.
+//
+// Each request is logged with the HTTP method, path, route template, status code, response size and duration,
+// JSON RPC requests also with the RPC method and error code. The default logger writes logfmt to stdout,
+// use the <serviceName>ServerAccessLogger server option to replace it.
+//
+// Supported in both REST and JSON RPC.
+func AccessLog() TransportOption {
+	return "implementation not generated, run swipe"
+}
+
+// HTTPMetrics enable the metrics of the HTTP handler returned by MakeHandler.
+//
+// The http_requests_total counter, the http_request_duration_seconds and http_response_size_bytes histograms
+// are labeled by the HTTP method, route template and status code, for JSON RPC also by the RPC method and error code.
+// Use the <serviceName>ServerHTTPMetrics server option to replace the default metrics.
+//
+// The default metrics are created by the MetricsBackend of the Instrumenting option: Prometheus (by default),
+// expvar (without the labels) or none (discarded). For the statsd and dogstatsd backends the metrics must be
+// passed with the server option, otherwise MakeHandler returns an error.
+//
+// Supported in both REST and JSON RPC.
+func HTTPMetrics() TransportOption {
+	return "implementation not generated, run swipe"
+}
+
+// MarkdownDoc enable for generate markdown doc to the outputDir.
+//
+// For JSON RPC the doc of the JS client is generated to jsonrpc_<service>_doc_gen.md.
+// For REST the doc of the HTTP API is generated to rest_<service>_doc_gen.md, each method is described
+// with the HTTP method and path, the path, query and header parameters, the request body and response fields,
+// the error codes and the curl example.
 func MarkdownDoc(outputDir string) TransportOption {
 	return "implementation not generated, run swipe"
 }
@@ -200,6 +380,29 @@ func JSONRPCPath(string) JSONRPCOption {
 	return "implementation not generated, run swipe"
 }
 
+// WebSocket enable serving JSON RPC over WebSocket on the given path.
+//
+// The connection accepts single and batch requests, the requests are processed concurrently.
+// Methods with a receive-only channel result are available only over WebSocket, the call responds
+// with a null result and then each value is sent as the notification:
+//  {"jsonrpc": "2.0", "method": "<method>", "params": {"id": <request id>, "result": <value>}}
+// When the channel is closed, the {"id": <request id>, "done": true} params are sent.
+//
+// The generated Go client is created with NewWebSocketClientJSONRPC<serviceName>,
+// the generated JS client gets the WebSocketTransport with reconnect.
+// Browsers cannot set the Authorization header, so the JS client passes the token in the
+// bearer.<token> value of the Sec-WebSocket-Protocol header, the server selects the jsonrpc subprotocol.
+// A stream is canceled with the notification:
+//  {"jsonrpc": "2.0", "method": "rpc.cancel", "params": {"id": <request id>}}
+//
+// The server accepts only the same origin requests, use the <serviceName>ServerWebSocketCheckOrigin
+// server option to allow other origins. The connection is closed if the ping is not answered within 60s.
+// The message size is limited to 1MB and 16 messages of the connection are served concurrently,
+// use the <serviceName>ServerWebSocketLimits(readLimit, concurrency) server option to change the limits.
+func WebSocket(path string) JSONRPCOption {
+	return "implementation not generated, run swipe"
+}
+
 // WrapResponse wrap the response from the server to an object, for example if you want to return as:
 //  {data: { you responce data }}
 // need to add option:
@@ -259,6 +462,157 @@ func ClientDecodeResponseFunc(interface{}) MethodOption {
 	return "implementation not generated, run swipe"
 }
 
+// Auth enable JWT bearer authentication for all methods of the transport.
+//
+// The keyFunc parameter is a function with the jwt.Keyfunc signature from github.com/dgrijalva/jwt-go,
+// it is used to verify the token, for example:
+//  func KeyFunc(token *jwt.Token) (interface{}, error) {
+//    if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+//      return nil, jwt.ErrSignatureInvalid
+//    }
+//    return []byte("secret"), nil
+//  }
+//
+// The server extracts the token from the "Authorization: Bearer" header, verifies it and puts
+// the claims into the context with the go-kit jwt.JWTClaimsContextKey key.
+// If the token is missing or invalid, the server responds with the UnauthorizedError,
+// 401 (REST) or the -32001 error code (JSON RPC), the client decodes the code to the UnauthorizedError.
+// If the service declares the error with the same code, the service error is used instead.
+//
+// The generated client gets the <serviceName>ClientTokenProvider option to set the token for requests.
+func Auth(keyFunc interface{}, opts ...AuthOption) TransportOption {
+	return "implementation not generated, run swipe"
+}
+
+// AuthClaimsFactory sets the go-kit jwt.ClaimsFactory for the token claims, default is jwt.MapClaimsFactory.
+func AuthClaimsFactory(interface{}) AuthOption {
+	return "implementation not generated, run swipe"
+}
+
+// Public disable authentication for the method.
+func Public() MethodOption {
+	return "implementation not generated, run swipe"
+}
+
+// Principal sets the function for extracting the principal from the context, it is used
+// to check the Require and Roles method options, for example:
+//  func PrincipalFromContext(ctx context.Context) (*User, error) {
+//    claims, ok := ctx.Value(jwt.JWTClaimsContextKey).(jwt.MapClaims)
+//    ...
+//  }
+//
+// The principal type must have the following methods:
+//  HasPermission(permission string) bool // if the Require option is used.
+//  HasRole(role string) bool             // if the Roles option is used.
+//
+// If the extractor returns an error, the error is returned to the client.
+// If the principal does not satisfy the method requirements, the server responds with the ForbiddenError,
+// 403 (REST) or the -32003 error code (JSON RPC), the client decodes the code to the ForbiddenError.
+// If the service declares the error with the same code, the service error is used instead.
+// The Require and Roles options of MethodDefaultOptions are applied to all methods.
+func Principal(extractor interface{}) TransportOption {
+	return "implementation not generated, run swipe"
+}
+
+// Require sets the permissions required to call the method, the principal must have all of them.
+func Require(permissions ...string) MethodOption {
+	return "implementation not generated, run swipe"
+}
+
+// Roles sets the roles allowed to call the method, the principal must have at least one of them.
+func Roles(roles ...string) MethodOption {
+	return "implementation not generated, run swipe"
+}
+
+// RateLimit enable the token bucket rate limiter for the method, rps is the number of requests
+// per second and burst is the maximum burst size. The limiter is applied both in the server and in the client.
+//
+// If the limit is exceeded, the RateLimitExceededError is returned, the server responds
+// with 429 (REST) or the -32029 error code (JSON RPC).
+// If the service declares the error with the same code, the service error is used instead.
+//
+// The limits can be changed at runtime with the generated options:
+//  <serviceName><methodName>ServerRateLimit(rps, burst)
+//  <serviceName><methodName>ClientRateLimit(rps, burst)
+func RateLimit(rps float64, burst int) MethodOption {
+	return "implementation not generated, run swipe"
+}
+
+// CircuitBreaker enable the circuit breaker (github.com/sony/gobreaker) for the method.
+// The circuit breaker is applied both in the server and in the client.
+//
+// If the circuit breaker is open, the ServiceUnavailableError is returned, the server responds
+// with 503 (REST) or the -32053 error code (JSON RPC).
+// If the service declares the error with the same code, the service error is used instead.
+// Only the transport and server failures are counted: the errors with the status code 5xx (REST),
+// the internal and the -32053 error codes (JSON RPC) and the errors without a code,
+// the service errors with the other codes do not trip the circuit breaker.
+//
+// The settings can be changed at runtime with the generated options:
+//  <serviceName><methodName>ServerCircuitBreaker(gobreaker.Settings)
+//  <serviceName><methodName>ClientCircuitBreaker(gobreaker.Settings)
+func CircuitBreaker(opts ...CircuitBreakerOption) MethodOption {
+	return "implementation not generated, run swipe"
+}
+
+// CircuitBreakerMaxRequests sets the maximum number of requests allowed to pass through
+// when the circuit breaker is half-open, default is 1.
+func CircuitBreakerMaxRequests(uint32) CircuitBreakerOption {
+	return "implementation not generated, run swipe"
+}
+
+// CircuitBreakerInterval sets the cyclic period of the closed state to clear the internal counts,
+// default is 0 (the counts are never cleared in the closed state).
+func CircuitBreakerInterval(time.Duration) CircuitBreakerOption {
+	return "implementation not generated, run swipe"
+}
+
+// CircuitBreakerTimeout sets the period of the open state, after which the state
+// becomes half-open, default is 60 seconds.
+func CircuitBreakerTimeout(time.Duration) CircuitBreakerOption {
+	return "implementation not generated, run swipe"
+}
+
+// CircuitBreakerFailures sets the number of consecutive failures, when exceeded the circuit breaker trips, default is 5.
+func CircuitBreakerFailures(uint32) CircuitBreakerOption {
+	return "implementation not generated, run swipe"
+}
+
+// Timeout sets the client deadline for the method call, including all retries.
+//
+// The timeout can be changed at runtime with the generated option:
+//  <serviceName><methodName>ClientTimeout(d)
+func Timeout(time.Duration) MethodOption {
+	return "implementation not generated, run swipe"
+}
+
+// Retry enable the client retries for the method, max is the maximum number of retries,
+// backoff is the delay before the first retry, the delay is doubled for each next retry.
+//
+// Retries are allowed only for idempotent methods: REST methods with GET, PUT and DELETE http methods
+// or methods marked with the Idempotent option. The errors decoded by the ErrorDecode function
+// (the service errors and the http errors) are not retried.
+//
+// The retries can be changed at runtime with the generated option:
+//  <serviceName><methodName>ClientRetry(max, backoff)
+func Retry(max int, backoff time.Duration) MethodOption {
+	return "implementation not generated, run swipe"
+}
+
+// Idempotent marks the method as idempotent, it is required to use the Retry option
+// for JSON RPC methods and REST methods with POST and PATCH http methods.
+func Idempotent() MethodOption {
+	return "implementation not generated, run swipe"
+}
+
+// Notification marks the JSON RPC method as the notification, the Go client sends the call
+// without the id and does not wait for the result, the results are always zero values.
+// The call is sent by the HTTP client of the <serviceName>HTTPClient client option, the go-kit client options are not used.
+// Methods that return only error are sent as notifications without this option.
+func Notification() MethodOption {
+	return "implementation not generated, run swipe"
+}
+
 // ClientEnable enable generate client for the selected transport.
 func ClientEnable() TransportOption {
 	return "implementation not generated, run swipe"
@@ -269,6 +623,20 @@ func ServerDisabled() TransportOption {
 	return "implementation not generated, run swipe"
 }
 
+// PanicRecoveryDisabled disable the recovery of panics in the service methods.
+//
+// By default a panic is converted to the 500 Internal Server Error response for REST
+// and to the -32603 Internal error response for JSON RPC, the stack is logged with the logger
+// set by the <serviceName>ServerPanicLogger server option, by default the logger of the LoggerBackend
+// writes to stderr. If instrumenting is enabled, the panics_total counter is incremented, use
+// the <serviceName>ServerPanicCounter server option to replace it, for the statsd and dogstatsd
+// backends the counter is created by the provider passed with the <serviceName>ServerMetricsProvider option.
+// The panics of the request decoders and the response encoders are recovered by the handler
+// and logged without the method name.
+func PanicRecoveryDisabled() TransportOption {
+	return "implementation not generated, run swipe"
+}
+
 // Openapi generate openapi documentation.
 func Openapi(...OpenapiOption) TransportOption {
 	return "implementation not generated, run swipe"
@@ -304,6 +672,55 @@ func OpenapiServer(description, url string) OpenapiOption {
 	return "implementation not generated, run swipe"
 }
 
+// OpenapiFormat sets the document format, "json" or "yaml", default is "json".
+func OpenapiFormat(string) OpenapiOption {
+	return "implementation not generated, run swipe"
+}
+
+// OpenapiVersion sets the OpenAPI specification version, "3.0" or "3.1", default is "3.0".
+// The 3.1 document uses the JSON Schema 2020-12 null type and examples instead of nullable and example.
+func OpenapiVersion(string) OpenapiOption {
+	return "implementation not generated, run swipe"
+}
+
+// OpenapiSplit writes the component schemas to the separate files in the openapi_<rest|jsonrpc>_schemas directory,
+// the document components refer to the files with the relative $ref.
+func OpenapiSplit() OpenapiOption {
+	return "implementation not generated, run swipe"
+}
+
+// OpenapiServe serves the embedded OpenAPI document at the <path>/openapi.json and the Swagger UI docs page at the path,
+// the routes are registered on the router of the MakeHandler function and do not use the network at runtime.
+// The Swagger UI files are embedded by the github.com/swaggo/files/v2 module, it requires Go 1.16 or later.
+func OpenapiServe(path string) OpenapiOption {
+	return "implementation not generated, run swipe"
+}
+
+// OpenRPC generate OpenRPC documentation for JSON RPC transport.
+func OpenRPC(...OpenRPCOption) TransportOption {
+	return "implementation not generated, run swipe"
+}
+
+// OpenRPCOutput sets output directory, path relative to the file, default is "./".
+func OpenRPCOutput(string) OpenRPCOption {
+	return "implementation not generated, run swipe"
+}
+
+// OpenRPCInfo sets info.
+func OpenRPCInfo(title, description, version string) OpenRPCOption {
+	return "implementation not generated, run swipe"
+}
+
+// OpenRPCServer sets openrpc server.
+func OpenRPCServer(name, url string) OpenRPCOption {
+	return "implementation not generated, run swipe"
+}
+
+// OpenRPCDiscover registers the rpc.discover method that returns the OpenRPC document.
+func OpenRPCDiscover() OpenRPCOption {
+	return "implementation not generated, run swipe"
+}
+
 func Gateway(services ...GatewayOption) Option {
 	return "implementation not generated, run swipe"
 }
@@ -319,3 +736,17 @@ func GatewayServiceMethod(signature interface{}, opts ...GatewayServiceMethodOpt
 func GatewayBalancer(string) GatewayServiceMethodOption {
 	return "implementation not generated, run swipe"
 }
+
+// GatewayServicePrefix sets the path prefix of the service in the gateway openapi documentation,
+// default is the service ID in kebab case.
+func GatewayServicePrefix(string) GatewayServiceOption {
+	return "implementation not generated, run swipe"
+}
+
+// GatewayOpenapi generates the openapi documentation merged from the gateway services.
+// The paths of the service are documented from its Service option with the Openapi option,
+// the info of the service Openapi option is set to the service tag, the paths are served by the gateway
+// servers. The prefixed paths of the services must not conflict, otherwise the generation fails.
+func GatewayOpenapi(...OpenapiOption) GatewayOption {
+	return "implementation not generated, run swipe"
+}
@@ -2,12 +2,14 @@ package swipe_test
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/valyala/fasthttp"
 
 	"github.com/swipe-io/swipe/fixtures/service"
 	"github.com/swipe-io/swipe/fixtures/transport/jsonrpc"
 	"github.com/swipe-io/swipe/fixtures/transport/rest"
+	"github.com/swipe-io/swipe/fixtures/user"
 	. "github.com/swipe-io/swipe/pkg/swipe"
 )
 
@@ -67,6 +69,77 @@ func ExampleLogging() {
 	)
 }
 
+// Example the password param is redacted, the data param is skipped,
+// the results of the GetAll method are limited to 10 elements in the log.
+func ExampleLogRedact() {
+	Build(
+		Service((*service.Service)(nil),
+			Transport("http"),
+			Logging(
+				LogSkip("data"),
+				LogRedact("password"),
+				LogMethodOptions(service.Interface.GetAll,
+					LogMaxSize(10),
+				),
+			),
+		),
+	)
+}
+
+// Example the logging middleware uses the zap logger, the successful calls are logged at the debug level,
+// the GetAll calls longer than one second are logged at the warn level.
+func ExampleLoggerBackend() {
+	Build(
+		Service((*service.Service)(nil),
+			Transport("http"),
+			Logging(
+				LoggerBackend("zap"),
+				LogSuccessLevel("debug"),
+				LogMethodOptions(service.Interface.GetAll,
+					LogSlowThreshold(time.Second),
+				),
+			),
+		),
+	)
+}
+
+// Example the request duration histogram with the custom buckets and the static label.
+func ExampleInstrumentingBuckets() {
+	Build(
+		Service((*service.Service)(nil),
+			Transport("http"),
+			Instrumenting("api", "api",
+				InstrumentingBuckets(0.01, 0.1, 1),
+				InstrumentingLabel("service", "users"),
+			),
+		),
+	)
+}
+
+// Example the default metrics are created by the go-kit DogStatsD provider passed to the constructor.
+func ExampleMetricsBackend() {
+	Build(
+		Service((*service.Service)(nil),
+			Transport("http"),
+			Instrumenting("api", "api",
+				MetricsBackend("dogstatsd"),
+			),
+		),
+	)
+}
+
+// Example basic use tracing, the values of the password param are hidden in the span attributes.
+func ExampleTracing() {
+	Build(
+		Service((*service.Service)(nil),
+			Transport("http"),
+			Tracing(
+				TracingRedact("password"),
+			),
+		),
+	)
+}
+
 // Example basic use instrumenting.
 func ExampleInstrumenting() {
 	Build(
@@ -80,6 +153,79 @@ func ExampleInstrumenting() {
 	)
 }
 
+// Example access log and metrics of the HTTP handler.
+func ExampleAccessLog() {
+	Build(
+		Service((*service.Service)(nil),
+			Transport("http",
+				AccessLog(),
+				HTTPMetrics(),
+			),
+		),
+	)
+}
+
+// Example disable the panic recovery of the HTTP handler.
+func ExamplePanicRecoveryDisabled() {
+	Build(
+		Service((*service.Service)(nil),
+			Transport("http",
+				PanicRecoveryDisabled(),
+			),
+		),
+	)
+}
+
+func ExampleWebSocket() {
+	Build(
+		Service((*service.Service)(nil),
+			Transport("http",
+				JSONRPC(
+					JSONRPCPath("/rpc"),
+					WebSocket("/ws"),
+				),
+			),
+		),
+	)
+}
+
+func ExampleNotification() {
+	Build(
+		Service((*service.Service)(nil),
+			Transport("http",
+				JSONRPC(),
+				MethodOptions(service.Interface.Create,
+					Notification(),
+				),
+			),
+		),
+	)
+}
+
+func ExampleOpenRPC() {
+	Build(
+		Service((*service.Service)(nil),
+			Transport("http",
+				JSONRPC(),
+				OpenRPC(
+					OpenRPCOutput("/doc"),
+					OpenRPCInfo("Service title", "Service description", "v1.0.0"),
+					OpenRPCServer("production", "http://127.0.0.1/rpc"),
+					OpenRPCDiscover(),
+				),
+			),
+		),
+	)
+}
+
+func ExampleEnum() {
+	Build(
+		Enum((*user.Status)(nil),
+			EnumTrimPrefix("Status"),
+		),
+	)
+}
+
 // Use the swipe.MethodOptions option to specify settings for generating the service method.
 func ExamplePath() {
 	Build(
@@ -134,6 +280,17 @@ func ExampleQueryVars() {
 	)
 }
 
+// For REST the doc is generated to the ./docs/rest_service_doc_gen.md.
+func ExampleMarkdownDoc() {
+	Build(
+		Service((*service.Service)(nil),
+			Transport("http",
+				MarkdownDoc("./docs"),
+			),
+		),
+	)
+}
+
 func ExampleOpenapi() {
 	Build(
 		Service((*service.Service)(nil),
@@ -168,6 +325,46 @@ func ExampleOpenapiInfo() {
 	)
 }
 
+func ExampleOpenapiFormat() {
+	Build(
+		Service((*service.Service)(nil),
+			Transport("http",
+				Openapi(
+					OpenapiFormat("yaml"),
+					OpenapiVersion("3.1"),
+					OpenapiSplit(),
+				),
+			),
+		),
+	)
+}
+
+func ExampleOpenapiServe() {
+	Build(
+		Service((*service.Service)(nil),
+			Transport("http",
+				Openapi(
+					OpenapiServe("/docs"),
+				),
+			),
+		),
+	)
+}
+
+func ExampleGatewayOpenapi() {
+	Build(
+		Gateway(
+			GatewayService((*service.Interface)(nil),
+				GatewayServicePrefix("/users"),
+			),
+			GatewayOpenapi(
+				OpenapiInfo("Gateway", "The gateway services", "1.0.0"),
+				OpenapiOutput("../../docs"),
+			),
+		),
+	)
+}
+
 func ExampleOpenapiServer() {
 	Build(
 		Service((*service.Service)(nil),
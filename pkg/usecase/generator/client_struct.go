@@ -36,7 +36,7 @@ func (g *clientStruct) Process(ctx context.Context) error {
 	clientType := fmt.Sprintf("client%s", g.o.ID)
 	clientOptionType := fmt.Sprintf("%sClientOption", g.o.ID)
 
-	if len(g.o.Methods) > 0 {
+	if len(g.o.Methods) > 0 || transportOpt.Auth.Enable {
 		contextPkg = g.i.Import("context", "context")
 	}
 
@@ -78,6 +78,30 @@ func (g *clientStruct) Process(ctx context.Context) error {
 		},
 	)
 
+	if transportOpt.JsonRPC.Enable && !transportOpt.FastHTTP {
+		g.WriteFunc(
+			g.o.ID+"HTTPClient",
+			"",
+			[]string{"client", g.i.Import("http", "github.com/go-kit/kit/transport/http") + ".HTTPClient"},
+			[]string{"", clientOptionType},
+			func() {
+				g.W("return func(c *%s) { c.httpClient = client }\n", clientType)
+			},
+		)
+	}
+
+	if transportOpt.Auth.Enable {
+		g.WriteFunc(
+			g.o.ID+"ClientTokenProvider",
+			"",
+			[]string{"fn", "func(" + contextPkg + ".Context) (string, error)"},
+			[]string{"", clientOptionType},
+			func() {
+				g.W("return func(c *%s) { c.tokenProvider = fn }\n", clientType)
+			},
+		)
+	}
+
 	for _, m := range g.o.Methods {
 		g.WriteFunc(g.o.ID+m.Name+"ClientOptions",
 			"",
@@ -96,6 +120,71 @@ func (g *clientStruct) Process(ctx context.Context) error {
 				g.W("return func(c *%s) { c.%sEndpointMiddleware = opt }\n", clientType, m.LcName)
 			},
 		)
+
+		mopt := transportOpt.MethodOptions[m.Name]
+
+		if m.StreamElem != nil && !transportOpt.JsonRPC.Enable {
+			g.WriteFunc(g.o.ID+m.Name+"ClientStreamErrors",
+				"",
+				[]string{"errs", "chan<- error"},
+				[]string{"", clientOptionType},
+				func() {
+					g.W("return func(c *%s) { c.%sStreamErrors = errs }\n", clientType, m.LcName)
+				},
+			)
+		}
+
+		if mopt.RateLimit.Enable {
+			ratePkg := g.i.Import("rate", "golang.org/x/time/rate")
+
+			g.WriteFunc(g.o.ID+m.Name+"ClientRateLimit",
+				"",
+				[]string{"rps", "float64", "burst", "int"},
+				[]string{"", clientOptionType},
+				func() {
+					g.W("return func(c *%s) { c.%sRateLimiter = %s.NewLimiter(%[3]s.Limit(rps), burst) }\n", clientType, m.LcName, ratePkg)
+				},
+			)
+		}
+
+		if mopt.Timeout.Enable {
+			timePkg := g.i.Import("time", "time")
+
+			g.WriteFunc(g.o.ID+m.Name+"ClientTimeout",
+				"",
+				[]string{"d", timePkg + ".Duration"},
+				[]string{"", clientOptionType},
+				func() {
+					g.W("return func(c *%s) { c.%sTimeout = d }\n", clientType, m.LcName)
+				},
+			)
+		}
+
+		if mopt.Retry.Enable {
+			timePkg := g.i.Import("time", "time")
+
+			g.WriteFunc(g.o.ID+m.Name+"ClientRetry",
+				"",
+				[]string{"max", "int", "backoff", timePkg + ".Duration"},
+				[]string{"", clientOptionType},
+				func() {
+					g.W("return func(c *%s) {\nc.%[2]sRetryMax = max\nc.%[2]sRetryBackoff = backoff\n}\n", clientType, m.LcName)
+				},
+			)
+		}
+
+		if mopt.CircuitBreaker.Enable {
+			gobreakerPkg := g.i.Import("gobreaker", "github.com/sony/gobreaker")
+
+			g.WriteFunc(g.o.ID+m.Name+"ClientCircuitBreaker",
+				"",
+				[]string{"settings", gobreakerPkg + ".Settings"},
+				[]string{"", clientOptionType},
+				func() {
+					g.W("return func(c *%s) { c.%sCircuitBreaker = %s.NewCircuitBreaker(settings) }\n", clientType, m.LcName, gobreakerPkg)
+				},
+			)
+		}
 	}
 
 	g.W("type %s struct {\n", clientType)
@@ -103,12 +192,112 @@ func (g *clientStruct) Process(ctx context.Context) error {
 		g.W("%sEndpoint %s.Endpoint\n", m.LcName, endpointPkg)
 		g.W("%sClientOption []%s.ClientOption\n", m.LcName, kithttpPkg)
 		g.W("%sEndpointMiddleware []%s.Middleware\n", m.LcName, endpointPkg)
+
+		if m.StreamElem != nil && !transportOpt.JsonRPC.Enable {
+			g.W("%sStreamErrors chan<- error\n", m.LcName)
+		}
+
+		mopt := transportOpt.MethodOptions[m.Name]
+		if mopt.RateLimit.Enable {
+			g.W("%sRateLimiter %s.Allower\n", m.LcName, g.i.Import("ratelimit", "github.com/go-kit/kit/ratelimit"))
+		}
+		if mopt.CircuitBreaker.Enable {
+			g.W("%sCircuitBreaker *%s.CircuitBreaker\n", m.LcName, g.i.Import("gobreaker", "github.com/sony/gobreaker"))
+		}
+		if mopt.Timeout.Enable {
+			g.W("%sTimeout %s.Duration\n", m.LcName, g.i.Import("time", "time"))
+		}
+		if mopt.Retry.Enable {
+			g.W("%sRetryMax int\n", m.LcName)
+			g.W("%sRetryBackoff %s.Duration\n", m.LcName, g.i.Import("time", "time"))
+		}
 	}
 	g.W("genericClientOption []%s.ClientOption\n", kithttpPkg)
 	g.W("genericEndpointMiddleware []%s.Middleware\n", endpointPkg)
+	if transportOpt.JsonRPC.Enable && !transportOpt.FastHTTP {
+		g.W("httpClient %s.HTTPClient\n", g.i.Import("http", "github.com/go-kit/kit/transport/http"))
+	}
+	if transportOpt.Auth.Enable {
+		g.W("tokenProvider func(%s.Context) (string, error)\n", contextPkg)
+	}
 
 	g.W("}\n\n")
 
+	var hasTimeout, hasRetry bool
+	for _, m := range g.o.Methods {
+		mopt := transportOpt.MethodOptions[m.Name]
+		if mopt.Retry.Enable && !isIdempotentMethod(transportOpt, m) {
+			return fmt.Errorf("the Retry option is allowed only for idempotent methods, use the Idempotent option for the %s method", m.Name)
+		}
+		hasTimeout = hasTimeout || mopt.Timeout.Enable
+		hasRetry = hasRetry || mopt.Retry.Enable
+	}
+
+	if hasTimeout {
+		timePkg := g.i.Import("time", "time")
+
+		g.W("func timeoutMiddleware(d %s.Duration) %s.Middleware {\n", timePkg, endpointPkg)
+		g.W("return func(next %[1]s.Endpoint) %[1]s.Endpoint {\n", endpointPkg)
+		g.W("return func(ctx %s.Context, request interface{}) (interface{}, error) {\n", contextPkg)
+		g.W("if d <= 0 {\n")
+		g.W("return next(ctx, request)\n")
+		g.W("}\n")
+		g.W("ctx, cancel := %s.WithTimeout(ctx, d)\n", contextPkg)
+		g.W("defer cancel()\n")
+		g.W("return next(ctx, request)\n")
+		g.W("}\n")
+		g.W("}\n")
+		g.W("}\n\n")
+	}
+
+	if hasRetry {
+		timePkg := g.i.Import("time", "time")
+
+		codeMethodName := "StatusCode"
+		if transportOpt.JsonRPC.Enable {
+			codeMethodName = "ErrorCode"
+		}
+
+		g.W("func retryMiddleware(max int, backoff %s.Duration) %s.Middleware {\n", timePkg, endpointPkg)
+		g.W("return func(next %[1]s.Endpoint) %[1]s.Endpoint {\n", endpointPkg)
+		g.W("return func(ctx %s.Context, request interface{}) (response interface{}, err error) {\n", contextPkg)
+		g.W("for i := 0; ; i++ {\n")
+		g.W("response, err = next(ctx, request)\n")
+		g.W("if err == nil || i >= max {\n")
+		g.W("return\n")
+		g.W("}\n")
+		g.W("// the errors decoded by ErrorDecode are not retried.\n")
+		g.W("if _, ok := err.(interface{ %s() int }); ok {\n", codeMethodName)
+		g.W("return\n")
+		g.W("}\n")
+		g.W("select {\n")
+		g.W("case <-ctx.Done():\n")
+		g.W("return nil, ctx.Err()\n")
+		g.W("case <-%s.After(backoff << uint(i)):\n", timePkg)
+		g.W("}\n")
+		g.W("}\n")
+		g.W("}\n")
+		g.W("}\n")
+		g.W("}\n\n")
+	}
+
+	if transportOpt.Auth.Enable {
+		kitjwtPkg := g.i.Import("jwt", "github.com/go-kit/kit/auth/jwt")
+
+		g.W("func authClientMiddleware(tokenProvider func(%s.Context) (string, error)) %s.Middleware {\n", contextPkg, endpointPkg)
+		g.W("return func(next %[1]s.Endpoint) %[1]s.Endpoint {\n", endpointPkg)
+		g.W("return func(ctx %s.Context, request interface{}) (interface{}, error) {\n", contextPkg)
+		g.W("token, err := tokenProvider(ctx)\n")
+		g.W("if err != nil {\n")
+		g.W("return nil, err\n")
+		g.W("}\n")
+		g.W("ctx = %s.WithValue(ctx, %s.JWTTokenContextKey, token)\n", contextPkg, kitjwtPkg)
+		g.W("return next(ctx, request)\n")
+		g.W("}\n")
+		g.W("}\n")
+		g.W("}\n\n")
+	}
+
 	for _, m := range g.o.Methods {
 		var params []string
 
@@ -160,7 +349,7 @@ func (g *clientStruct) Process(ctx context.Context) error {
 						if i > 0 {
 							g.W(",")
 						}
-						g.W(types.ZeroValue(r.Type()))
+						g.W("%s", types.ZeroValue(r.Type(), g.i.QualifyPkg))
 					}
 					g.W(",")
 				}
@@ -139,7 +139,7 @@ func (g *config) Process(ctx context.Context) error {
 
 			errorsPkg := g.i.Import("errors", "errors")
 
-			g.W("if %s == %s {\n", "cfg."+opts.fieldPath, types.ZeroValue(f.Type()))
+			g.W("if %s == %s {\n", "cfg."+opts.fieldPath, types.ZeroValue(f.Type(), g.i.QualifyPkg))
 
 			requiredMsg := strconv.Quote(fmt.Sprintf("%s %s required", tagName, opts.name))
 
@@ -100,7 +100,7 @@ func (g *endpoint) Process(ctx context.Context) error {
 					if i > 0 {
 						g.W(", ")
 					}
-					g.W(p.Name())
+					g.W("%s", p.Name())
 				}
 			} else {
 				g.W("result")
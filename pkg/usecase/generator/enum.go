@@ -0,0 +1,149 @@
+package generator
+
+import (
+	"context"
+	stdtypes "go/types"
+	"strconv"
+	stdstrings "strings"
+
+	"github.com/swipe-io/swipe/pkg/domain/model"
+	"github.com/swipe-io/swipe/pkg/importer"
+	"github.com/swipe-io/swipe/pkg/writer"
+)
+
+type enum struct {
+	*writer.GoLangWriter
+	o model.EnumOption
+	i *importer.Importer
+}
+
+func (g *enum) Prepare(ctx context.Context) error {
+	return nil
+}
+
+func (g *enum) Process(ctx context.Context) error {
+	fmtPkg := g.i.Import("fmt", "fmt")
+
+	typeName := g.o.Type.Obj().Name()
+	zero := "0"
+	if g.o.IsString() {
+		zero = `""`
+	}
+
+	names := make([]string, 0, len(g.o.Enums))
+	for _, e := range g.o.Enums {
+		names = append(names, e.Name)
+	}
+
+	g.W("// String returns the text form of the %s.\n", typeName)
+	g.W("func (v %s) String() string {\n", typeName)
+	if g.o.IsString() {
+		g.W("return string(v)\n")
+	} else {
+		g.W("switch v {\n")
+		for _, e := range g.o.Enums {
+			g.W("case %s:\nreturn %s\n", e.Name, strconv.Quote(g.o.Text(e)))
+		}
+		g.W("}\n")
+		g.W("return %s + %s.FormatInt(int64(v), 10) + \")\"\n", strconv.Quote(typeName+"("), g.i.Import("strconv", "strconv"))
+	}
+	g.W("}\n\n")
+
+	g.W("// IsValid reports whether the value is one of the %s consts.\n", typeName)
+	g.W("func (v %s) IsValid() bool {\n", typeName)
+	g.W("switch v {\n")
+	g.W("case %s:\nreturn true\n", stdstrings.Join(names, ", "))
+	g.W("}\n")
+	g.W("return false\n")
+	g.W("}\n\n")
+
+	g.W("// Values returns the %s consts.\n", typeName)
+	g.W("func (%[1]s) Values() []%[1]s {\n", typeName)
+	g.W("return []%s{%s}\n", typeName, stdstrings.Join(names, ", "))
+	g.W("}\n\n")
+
+	g.W("// Parse%[1]s returns the %[1]s by the text form.\n", typeName)
+	g.W("func Parse%[1]s(s string) (%[1]s, error) {\n", typeName)
+	g.W("switch s {\n")
+	for _, e := range g.o.Enums {
+		g.W("case %s:\nreturn %s, nil\n", strconv.Quote(g.o.Text(e)), e.Name)
+	}
+	g.W("}\n")
+	g.W("var v %s\n", typeName)
+	g.W("return v, %s.Errorf(\"invalid %s value: %%q\", s)\n", fmtPkg, typeName)
+	g.W("}\n\n")
+
+	// the zero value is not always a const, it is encoded as the empty text so the unset fields are decoded back.
+	g.W("// MarshalText implements the encoding.TextMarshaler interface.\n")
+	g.W("func (v %s) MarshalText() ([]byte, error) {\n", typeName)
+	g.W("if !v.IsValid() {\n")
+	g.W("if v == %s {\nreturn []byte{}, nil\n}\n", zero)
+	g.W("return nil, %s.Errorf(\"invalid %s value: %%s\", v)\n", fmtPkg, typeName)
+	g.W("}\n")
+	g.W("return []byte(v.String()), nil\n")
+	g.W("}\n\n")
+
+	g.W("// UnmarshalText implements the encoding.TextUnmarshaler interface.\n")
+	g.W("func (v *%s) UnmarshalText(text []byte) error {\n", typeName)
+	g.W("if len(text) == 0 {\n*v = %s\nreturn nil\n}\n", zero)
+	g.W("p, err := Parse%s(string(text))\n", typeName)
+	g.W("if err != nil {\nreturn err\n}\n")
+	g.W("*v = p\n")
+	g.W("return nil\n")
+	g.W("}\n\n")
+
+	if g.o.JSONValue && !g.o.IsString() {
+		g.writeJSONValue(typeName, fmtPkg)
+	}
+	return nil
+}
+
+func (g *enum) writeJSONValue(typeName, fmtPkg string) {
+	jsonPkg := g.i.Import("json", "encoding/json")
+	strconvPkg := g.i.Import("strconv", "strconv")
+
+	intType, format := "int64", "AppendInt"
+	if b, ok := g.o.Type.Underlying().(*stdtypes.Basic); ok && b.Info()&stdtypes.IsUnsigned != 0 {
+		intType, format = "uint64", "AppendUint"
+	}
+
+	g.W("// MarshalJSON encodes the %s as the const value.\n", typeName)
+	g.W("func (v %s) MarshalJSON() ([]byte, error) {\n", typeName)
+	g.W("if !v.IsValid() && v != 0 {\n")
+	g.W("return nil, %s.Errorf(\"invalid %s value: %%s\", v)\n", fmtPkg, typeName)
+	g.W("}\n")
+	g.W("return %s.%s(nil, %s(v), 10), nil\n", strconvPkg, format, intType)
+	g.W("}\n\n")
+
+	g.W("// UnmarshalJSON decodes the %s from the const value.\n", typeName)
+	g.W("func (v *%s) UnmarshalJSON(data []byte) error {\n", typeName)
+	g.W("var n %s\n", intType)
+	g.W("if err := %s.Unmarshal(data, &n); err != nil {\nreturn err\n}\n", jsonPkg)
+	g.W("p := %s(n)\n", typeName)
+	g.W("if !p.IsValid() && p != 0 {\n")
+	g.W("return %s.Errorf(\"invalid %s value: %%d\", n)\n", fmtPkg, typeName)
+	g.W("}\n")
+	g.W("*v = p\n")
+	g.W("return nil\n")
+	g.W("}\n\n")
+}
+
+func (g *enum) PkgName() string {
+	return ""
+}
+
+func (g *enum) OutputDir() string {
+	return ""
+}
+
+func (g *enum) Filename() string {
+	return "enum_gen.go"
+}
+
+func (g *enum) SetImporter(i *importer.Importer) {
+	g.i = i
+}
+
+func NewEnum(o model.EnumOption) Generator {
+	return &enum{GoLangWriter: writer.NewGoLangWriter(), o: o}
+}
@@ -0,0 +1,201 @@
+package generator
+
+import (
+	"fmt"
+	stdtypes "go/types"
+	"strconv"
+	stdstrings "strings"
+
+	"github.com/iancoleman/strcase"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/swipe-io/swipe/pkg/domain/model"
+	"github.com/swipe-io/swipe/pkg/importer"
+	"github.com/swipe-io/swipe/pkg/strings"
+	"github.com/swipe-io/swipe/pkg/writer"
+)
+
+// enumValidator writes the functions that check the decoded requests contain only the known enum values,
+// only the types with the Enum option are checked.
+type enumValidator struct {
+	w        *writer.GoLangWriter
+	i        *importer.Importer
+	enumOpts *typeutil.Map
+	names    map[string]stdtypes.Type
+	funcs    typeutil.Map
+	hasEnum  typeutil.Map
+	queue    []*stdtypes.Named
+	varIndex int
+}
+
+func newEnumValidator(w *writer.GoLangWriter, i *importer.Importer, enumOpts *typeutil.Map) *enumValidator {
+	return &enumValidator{w: w, i: i, enumOpts: enumOpts, names: map[string]stdtypes.Type{}}
+}
+
+func (v *enumValidator) enumValues(t stdtypes.Type) []model.Enum {
+	o, _ := enumOption(v.enumOpts, t)
+	return o.Enums
+}
+
+// contains reports whether the value of the type can hold an enum value.
+func (v *enumValidator) contains(t stdtypes.Type) bool {
+	switch t := t.(type) {
+	case *stdtypes.Pointer:
+		return v.contains(t.Elem())
+	case *stdtypes.Slice:
+		return v.contains(t.Elem())
+	case *stdtypes.Array:
+		return v.contains(t.Elem())
+	case *stdtypes.Map:
+		return v.contains(t.Elem())
+	case *stdtypes.Named:
+		if len(v.enumValues(t)) > 0 {
+			return true
+		}
+		if _, ok := t.Underlying().(*stdtypes.Struct); ok && !t.Obj().Exported() {
+			return false
+		}
+		if result, ok := v.hasEnum.At(t).(bool); ok {
+			return result
+		}
+		// the recursive types are assumed to have no enums until the fields are walked.
+		v.hasEnum.Set(t, false)
+		result := v.contains(t.Underlying())
+		v.hasEnum.Set(t, result)
+		return result
+	case *stdtypes.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if t.Field(i).Exported() && v.contains(t.Field(i).Type()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (v *enumValidator) funcName(named *stdtypes.Named) string {
+	if name, ok := v.funcs.At(named).(string); ok {
+		return name
+	}
+	name := named.Obj().Name()
+	if t, ok := v.names[name]; ok && !stdtypes.Identical(t, named) && named.Obj().Pkg() != nil {
+		name = strcase.ToCamel(named.Obj().Pkg().Name()) + name
+	}
+	v.names[name] = named
+	if len(v.enumValues(named)) > 0 {
+		name = "validate" + name + "Enum"
+	} else {
+		name = "validate" + name
+	}
+	v.funcs.Set(named, name)
+	v.queue = append(v.queue, named)
+	return name
+}
+
+// writeCheck writes the check of the expression, the enclosing function returns an error.
+func (v *enumValidator) writeCheck(expr string, t stdtypes.Type) {
+	if !v.contains(t) {
+		return
+	}
+	switch t := t.(type) {
+	case *stdtypes.Pointer:
+		v.w.W("if %s != nil {\n", expr)
+		if _, ok := t.Elem().(*stdtypes.Struct); ok {
+			v.writeCheck(expr, t.Elem())
+		} else {
+			v.writeCheck("*"+expr, t.Elem())
+		}
+		v.w.W("}\n")
+	case *stdtypes.Slice:
+		v.writeRange(expr, t.Elem())
+	case *stdtypes.Array:
+		v.writeRange(expr, t.Elem())
+	case *stdtypes.Map:
+		v.writeRange(expr, t.Elem())
+	case *stdtypes.Named:
+		switch t.Underlying().(type) {
+		case *stdtypes.Basic, *stdtypes.Struct:
+			v.w.W("if err := %s(%s); err != nil {\nreturn err\n}\n", v.funcName(t), expr)
+		default:
+			v.writeCheck(expr, t.Underlying())
+		}
+	case *stdtypes.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if f := t.Field(i); f.Exported() {
+				v.writeCheck(expr+"."+f.Name(), f.Type())
+			}
+		}
+	}
+}
+
+func (v *enumValidator) writeRange(expr string, elem stdtypes.Type) {
+	v.varIndex++
+	name := fmt.Sprintf("v%d", v.varIndex)
+	v.w.W("for _, %s := range %s {\n", name, expr)
+	v.writeCheck(name, elem)
+	v.w.W("}\n")
+}
+
+// writeRequest writes the check of the method request, if the request params can hold an enum value.
+func (v *enumValidator) writeRequest(m model.ServiceMethod, id string) {
+	if !v.hasEnums(m) {
+		return
+	}
+	v.w.W("func validate%sRequest%s(req %sRequest%[2]s) error {\n", m.Name, id, m.LcName)
+	for _, p := range m.Params {
+		v.writeCheck("req."+strings.UcFirst(p.Name()), p.Type())
+	}
+	v.w.W("return nil\n")
+	v.w.W("}\n\n")
+}
+
+func (v *enumValidator) hasEnums(m model.ServiceMethod) bool {
+	for _, p := range m.Params {
+		if v.contains(p.Type()) {
+			return true
+		}
+	}
+	return false
+}
+
+// flush writes the functions of the types found by the checks.
+func (v *enumValidator) flush() {
+	for len(v.queue) > 0 {
+		named := v.queue[0]
+		v.queue = v.queue[1:]
+
+		v.w.W("func %s(v %s) error {\n", v.funcs.At(named).(string), stdtypes.TypeString(named, v.i.QualifyPkg))
+		if enums := v.enumValues(named); len(enums) > 0 {
+			b, _ := named.Underlying().(*stdtypes.Basic)
+
+			zero, zeroValue := "0", "0"
+			if b != nil && b.Info()&stdtypes.IsString != 0 {
+				zero, zeroValue = `""`, ""
+			}
+			var prefix string
+			if pkg := v.i.QualifyPkg(named.Obj().Pkg()); pkg != "" {
+				prefix = pkg + "."
+			}
+			// the consts of the Enum option have the unique values.
+			values := make([]string, 0, len(enums)+1)
+			var hasZero bool
+			for _, e := range enums {
+				values = append(values, prefix+e.Name)
+				hasZero = hasZero || e.Value == zeroValue
+			}
+			// the zero value is the unset field, it is encoded as the empty text.
+			if !hasZero {
+				values = append(values, zero)
+			}
+			v.w.W("switch v {\n")
+			v.w.W("case %s:\n", stdstrings.Join(values, ", "))
+			v.w.W("return nil\n")
+			v.w.W("}\n")
+			v.w.W("return errInvalidEnum(%s, v)\n", strconv.Quote(named.Obj().Name()))
+		} else {
+			v.writeCheck("v", named.Underlying())
+			v.w.W("return nil\n")
+		}
+		v.w.W("}\n\n")
+	}
+}
@@ -23,6 +23,7 @@ func (g *gatewayGenerator) Prepare(ctx context.Context) error {
 
 func (g *gatewayGenerator) Process(ctx context.Context) error {
 	ioPkg := g.i.Import("io", "io")
+	contextPkg := g.i.Import("context", "context")
 	epPkg := g.i.Import("endpoint", "github.com/go-kit/kit/endpoint")
 	httpkitPkg := g.i.Import("endpoint", "github.com/go-kit/kit/transport/http")
 	jsonrpckitPkg := g.i.Import("jsonrpc", "github.com/l-vitaly/go-kit/transport/http/jsonrpc")
@@ -35,12 +36,12 @@ func (g *gatewayGenerator) Process(ctx context.Context) error {
 
 	g.W("type BalancerFactory func(s %s.Endpointer) %s.Balancer\n\n", sdPkg, lbPkg)
 
-	g.W("func RetryErrorExtractor() endpoint.Middleware {\n")
-	g.W("return func(next endpoint.Endpoint) endpoint.Endpoint {\n")
-	g.W("return func(ctx context.Context, request interface{}) (response interface{}, err error) {\n")
+	g.W("func RetryErrorExtractor() %s.Middleware {\n", epPkg)
+	g.W("return func(next %[1]s.Endpoint) %[1]s.Endpoint {\n", epPkg)
+	g.W("return func(ctx %s.Context, request interface{}) (response interface{}, err error) {\n", contextPkg)
 	g.W("response, err = next(ctx, request)\n")
 	g.W("if err != nil {\n")
-	g.W("if re, ok := err.(sd2.RetryError); ok {\n")
+	g.W("if re, ok := err.(%s.RetryError); ok {\n", lbPkg)
 	g.W("return nil, re.Final\n")
 	g.W("}\n}\n")
 	g.W("return\n")
@@ -0,0 +1,190 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	stdtypes "go/types"
+	"path/filepath"
+	stdstrings "strings"
+
+	"github.com/swipe-io/swipe/pkg/domain/model"
+	"github.com/swipe-io/swipe/pkg/openapi"
+)
+
+type gatewayOpenapiDoc struct {
+	bytes.Buffer
+	info      model.GenerateInfo
+	o         model.GatewayOption
+	outputDir string
+	files     map[string][]byte
+}
+
+func (g *gatewayOpenapiDoc) Prepare(ctx context.Context) error {
+	outputDir, err := filepath.Abs(filepath.Join(g.info.BasePath, g.o.Openapi.Output))
+	if err != nil {
+		return err
+	}
+	g.outputDir = outputDir
+	return nil
+}
+
+func (g *gatewayOpenapiDoc) Process(ctx context.Context) error {
+	doc, err := g.makeDocument()
+	if err != nil {
+		return err
+	}
+	files, err := encodeOpenapiDocument(g, doc, g.o.Openapi, "openapi_gateway_schemas")
+	g.files = files
+	return err
+}
+
+// makeDocument merges the documents of the gateway services, the paths are prefixed and
+// the component names are namespaced with the service ID. The tags of the services are merged by the name.
+// The paths are served by the gateway, so the servers of the services are not copied.
+func (g *gatewayOpenapiDoc) makeDocument() (openapi.OpenAPI, error) {
+	opt := g.o.Openapi
+	doc := openapi.OpenAPI{
+		OpenAPI: "3.0.0",
+		Info:    opt.Info,
+		Servers: opt.Servers,
+		Paths:   map[string]*openapi.Path{},
+		Components: openapi.Components{
+			Schemas: openapi.Schemas{},
+		},
+	}
+	pathServices := map[string]string{}
+	for _, s := range g.o.Services {
+		so, ok := g.serviceOption(s.Type)
+		if !ok {
+			return doc, fmt.Errorf("the gateway openapi requires the Service option of the %s interface", stdtypes.TypeString(s.Type, nil))
+		}
+		serviceDoc := (&openapiDoc{info: g.info, o: so}).makeDocument()
+
+		walkOpenapiSchemas(&serviceDoc, func(schema *openapi.Schema) *openapi.Schema {
+			if stdstrings.HasPrefix(schema.Ref, componentsSchemasRef) {
+				schema.Ref = componentsSchemasRef + s.ID + stdstrings.TrimPrefix(schema.Ref, componentsSchemasRef)
+			}
+			return schema
+		})
+		for name, schema := range serviceDoc.Components.Schemas {
+			doc.Components.Schemas[s.ID+name] = schema
+		}
+		// the schemes with the same name and definition are shared by the services,
+		// the conflicting scheme is namespaced with the service ID.
+		schemeNames := map[string]string{}
+		for name, scheme := range serviceDoc.Components.SecuritySchemes {
+			if doc.Components.SecuritySchemes == nil {
+				doc.Components.SecuritySchemes = openapi.SecuritySchemes{}
+			}
+			if existing, ok := doc.Components.SecuritySchemes[name]; ok && *existing != *scheme {
+				schemeNames[name] = s.ID + name
+				name = s.ID + name
+			}
+			doc.Components.SecuritySchemes[name] = scheme
+		}
+
+		info := so.Transport.Openapi.Info
+		tag := openapi.Tag{Name: s.ID, Description: info.Description}
+		if tag.Description == "" {
+			tag.Description = info.Title
+		}
+		doc.Tags = mergeOpenapiTags(doc.Tags, tag)
+		doc.Tags = mergeOpenapiTags(doc.Tags, serviceDoc.Tags...)
+
+		prefix := stdstrings.TrimSuffix(s.Prefix, "/")
+		for pathStr, p := range serviceDoc.Paths {
+			if id, ok := pathServices[prefix+pathStr]; ok {
+				return doc, fmt.Errorf("the gateway openapi path %s of the %s service conflicts with the %s service, change the gateway prefix", prefix+pathStr, s.ID, id)
+			}
+			pathServices[prefix+pathStr] = s.ID
+			p.Servers = nil
+			for _, o := range []*openapi.Operation{p.Get, p.Post, p.Patch, p.Put, p.Delete} {
+				if o == nil {
+					continue
+				}
+				for _, name := range o.Tags {
+					doc.Tags = mergeOpenapiTags(doc.Tags, openapi.Tag{Name: name})
+				}
+				o.Tags = append([]string{s.ID}, o.Tags...)
+				for _, requirement := range o.Security {
+					for name, scopes := range requirement {
+						if newName, ok := schemeNames[name]; ok {
+							delete(requirement, name)
+							requirement[newName] = scopes
+						}
+					}
+				}
+			}
+			doc.Paths[prefix+pathStr] = p
+		}
+	}
+	return doc, nil
+}
+
+// mergeOpenapiTags appends the tags that are not declared yet, the description of the declared tag
+// is filled when it is empty.
+func mergeOpenapiTags(tags []openapi.Tag, add ...openapi.Tag) []openapi.Tag {
+	for _, tag := range add {
+		found := false
+		for i := range tags {
+			if tags[i].Name == tag.Name {
+				if tags[i].Description == "" {
+					tags[i].Description = tag.Description
+				}
+				if tags[i].ExternalDocs == nil {
+					tags[i].ExternalDocs = tag.ExternalDocs
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// serviceOption returns the Service option of the interface, the option with the Openapi option
+// is preferred when the interface has the several transports.
+func (g *gatewayOpenapiDoc) serviceOption(t stdtypes.Type) (model.ServiceOption, bool) {
+	if g.info.ServiceOptions == nil {
+		return model.ServiceOption{}, false
+	}
+	serviceOpts, _ := g.info.ServiceOptions.At(t).([]model.ServiceOption)
+	if len(serviceOpts) == 0 {
+		return model.ServiceOption{}, false
+	}
+	for _, so := range serviceOpts {
+		if so.Transport.Openapi.Enable {
+			return so, true
+		}
+	}
+	return serviceOpts[0], true
+}
+
+// Files returns the component schema files by the path relative to the output directory.
+func (g *gatewayOpenapiDoc) Files() map[string][]byte {
+	return g.files
+}
+
+func (g *gatewayOpenapiDoc) PkgName() string {
+	return ""
+}
+
+func (g *gatewayOpenapiDoc) OutputDir() string {
+	return g.outputDir
+}
+
+func (g *gatewayOpenapiDoc) Filename() string {
+	return "openapi_gateway_gen." + g.o.Openapi.Format
+}
+
+func (g *gatewayOpenapiDoc) Imports() []string {
+	return nil
+}
+
+func NewGatewayOpenapi(info model.GenerateInfo, o model.GatewayOption) Generator {
+	return &gatewayOpenapiDoc{info: info, o: o}
+}
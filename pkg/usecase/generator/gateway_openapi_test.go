@@ -0,0 +1,131 @@
+package generator
+
+import (
+	"go/token"
+	stdtypes "go/types"
+	"reflect"
+	"testing"
+
+	"github.com/swipe-io/swipe/pkg/domain/model"
+	"github.com/swipe-io/swipe/pkg/openapi"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+func TestMergeOpenapiTags(t *testing.T) {
+	docs := &openapi.ExternalDocs{URL: "https://example.com"}
+	tests := []struct {
+		name string
+		tags []openapi.Tag
+		add  []openapi.Tag
+		want []openapi.Tag
+	}{
+		{
+			name: "new",
+			tags: []openapi.Tag{{Name: "a"}},
+			add:  []openapi.Tag{{Name: "b", Description: "B"}},
+			want: []openapi.Tag{{Name: "a"}, {Name: "b", Description: "B"}},
+		},
+		{
+			name: "declared",
+			tags: []openapi.Tag{{Name: "a", Description: "A"}},
+			add:  []openapi.Tag{{Name: "a", Description: "other"}},
+			want: []openapi.Tag{{Name: "a", Description: "A"}},
+		},
+		{
+			name: "fill",
+			tags: []openapi.Tag{{Name: "a"}},
+			add:  []openapi.Tag{{Name: "a", Description: "A", ExternalDocs: docs}},
+			want: []openapi.Tag{{Name: "a", Description: "A", ExternalDocs: docs}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeOpenapiTags(tt.tags, tt.add...); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGatewayOpenapiDocument checks the services are tagged by the ID and the same security scheme is shared.
+func TestGatewayOpenapiDocument(t *testing.T) {
+	pkg := stdtypes.NewPackage("example.com/testdata/gateway", "gateway")
+	serviceOpts := new(typeutil.Map)
+	var services []model.GatewayServiceOption
+	for _, id := range []string{"Users", "Orders"} {
+		iface := stdtypes.NewInterfaceType(nil, nil).Complete()
+		named := stdtypes.NewNamed(stdtypes.NewTypeName(token.NoPos, pkg, id, nil), iface, nil)
+		serviceOpts.Set(named, []model.ServiceOption{{
+			ID: id,
+			Transport: model.TransportOption{
+				Auth:    model.AuthHTTPTransportOption{Enable: true},
+				Openapi: model.OpenapiHTTPTransportOption{Enable: true, Info: openapi.Info{Title: id + " API"}},
+			},
+		}})
+		services = append(services, model.GatewayServiceOption{ID: id, Type: named, Prefix: "/" + id})
+	}
+
+	g := &gatewayOpenapiDoc{
+		info: model.GenerateInfo{ServiceOptions: serviceOpts},
+		o:    model.GatewayOption{Services: services},
+	}
+	doc, err := g.makeDocument()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantTags := []openapi.Tag{{Name: "Users", Description: "Users API"}, {Name: "Orders", Description: "Orders API"}}
+	if !reflect.DeepEqual(doc.Tags, wantTags) {
+		t.Fatalf("tags: got %+v, want %+v", doc.Tags, wantTags)
+	}
+	if len(doc.Components.SecuritySchemes) != 1 || doc.Components.SecuritySchemes["bearerAuth"] == nil {
+		t.Fatalf("security schemes: got %+v, want the shared bearerAuth", doc.Components.SecuritySchemes)
+	}
+}
+
+func TestGatewayOpenapiPaths(t *testing.T) {
+	makeGateway := func(prefixes ...string) *gatewayOpenapiDoc {
+		pkg := stdtypes.NewPackage("example.com/testdata/gateway", "gateway")
+		serviceOpts := new(typeutil.Map)
+		var services []model.GatewayServiceOption
+		for i, id := range []string{"Users", "Orders"} {
+			iface := stdtypes.NewInterfaceType(nil, nil).Complete()
+			named := stdtypes.NewNamed(stdtypes.NewTypeName(token.NoPos, pkg, id, nil), iface, nil)
+			serviceOpts.Set(named, []model.ServiceOption{{
+				ID: id,
+				Transport: model.TransportOption{
+					JsonRPC: model.JsonRPCHTTPTransportOption{Enable: true},
+					Openapi: model.OpenapiHTTPTransportOption{
+						Enable:  true,
+						Servers: []openapi.Server{{URL: "http://" + id + ".local"}},
+					},
+				},
+				Methods: []model.ServiceMethod{{Name: "Get"}},
+			}})
+			services = append(services, model.GatewayServiceOption{ID: id, Type: named, Prefix: prefixes[i]})
+		}
+		return &gatewayOpenapiDoc{
+			info: model.GenerateInfo{ServiceOptions: serviceOpts},
+			o: model.GatewayOption{
+				Services: services,
+				Openapi:  model.OpenapiHTTPTransportOption{Servers: []openapi.Server{{URL: "http://gateway.local"}}},
+			},
+		}
+	}
+
+	doc, err := makeGateway("/users", "/orders").makeDocument()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pathStr := range []string{"/users/get", "/orders/get"} {
+		p, ok := doc.Paths[pathStr]
+		if !ok {
+			t.Fatalf("path %s not found in %v", pathStr, doc.Paths)
+		}
+		if p.Servers != nil {
+			t.Errorf("path %s servers: got %+v, want the gateway servers", pathStr, p.Servers)
+		}
+	}
+	if _, err := makeGateway("/api", "/api").makeDocument(); err == nil {
+		t.Fatal("the conflicting paths are merged without the error")
+	}
+}
@@ -3,6 +3,9 @@ package generator
 import (
 	"context"
 	"fmt"
+	stdtypes "go/types"
+	"strconv"
+	"strings"
 
 	"github.com/gogo/protobuf/sortkeys"
 
@@ -72,6 +75,31 @@ func (g *httpTransport) Process(ctx context.Context) error {
 
 	g.W("func (e *httpError) StatusCode() int {\nreturn e.code\n}\n")
 
+	if transportOpt.JsonRPC.Enable {
+		g.W("func (e *httpError) ErrorCode() int {\nreturn e.code\n}\n")
+	}
+
+	var hasRateLimit, hasCircuitBreaker bool
+	for _, mopt := range transportOpt.MethodOptions {
+		hasRateLimit = hasRateLimit || mopt.RateLimit.Enable
+		hasCircuitBreaker = hasCircuitBreaker || mopt.CircuitBreaker.Enable
+	}
+
+	var hasEventStream bool
+	for _, m := range g.o.Methods {
+		hasEventStream = hasEventStream || (m.StreamElem != nil && !transportOpt.JsonRPC.Enable)
+	}
+
+	rateLimitCode, circuitBreakerCode, unauthorizedCode, forbiddenCode := int64(429), int64(503), int64(401), int64(403)
+	if transportOpt.JsonRPC.Enable {
+		rateLimitCode, circuitBreakerCode, unauthorizedCode, forbiddenCode = -32029, -32053, -32001, -32003
+	}
+
+	errorCodes := map[int64]bool{}
+	for _, e := range g.o.Transport.Errors {
+		errorCodes[e.Code] = true
+	}
+
 	errorDecodeParams := []string{"code", "int"}
 	if transportOpt.JsonRPC.Enable {
 		g.W("func (e *httpError) ErrorData() interface{} {\nreturn e.data\n}\n")
@@ -92,12 +120,23 @@ func (g *httpTransport) Process(ctx context.Context) error {
 		for _, key := range errorKeys {
 			e := g.o.Transport.Errors[key]
 			g.W("case %d:\n", e.Code)
-			pkg := g.i.Import(e.Named.Obj().Pkg().Name(), e.Named.Obj().Pkg().Path())
-			newPrefix := ""
-			if e.IsPointer {
-				newPrefix = "&"
-			}
-			g.W("err = %s%s.%s{}\n", newPrefix, pkg, e.Named.Obj().Name())
+			g.W("err = %s\n", g.errorValue(e))
+		}
+		if transportOpt.Auth.Enable && !errorCodes[unauthorizedCode] {
+			g.W("case %d:\n", unauthorizedCode)
+			g.W("err = UnauthorizedError{}\n")
+		}
+		if transportOpt.Principal.Enable && !errorCodes[forbiddenCode] {
+			g.W("case %d:\n", forbiddenCode)
+			g.W("err = ForbiddenError{}\n")
+		}
+		if hasRateLimit && !errorCodes[rateLimitCode] {
+			g.W("case %d:\n", rateLimitCode)
+			g.W("err = RateLimitExceededError{}\n")
+		}
+		if hasCircuitBreaker && !errorCodes[circuitBreakerCode] {
+			g.W("case %d:\n", circuitBreakerCode)
+			g.W("err = ServiceUnavailableError{}\n")
 		}
 		g.W("}\n")
 		if transportOpt.JsonRPC.Enable {
@@ -126,6 +165,38 @@ func (g *httpTransport) Process(ctx context.Context) error {
 	g.W("}\n")
 	g.W("}\n")
 
+	if transportOpt.Auth.Enable {
+		g.writeAuth(httpPkg, unauthorizedCode)
+	}
+
+	if transportOpt.Principal.Enable {
+		g.writeAuthorization(httpPkg, forbiddenCode)
+	}
+
+	if g.o.Tracing.Enable {
+		g.writeTracing(httpPkg)
+	}
+
+	if g.o.Logging.Enable {
+		g.writeRequestID(httpPkg)
+	}
+
+	if hasRateLimit {
+		g.writeRateLimit(httpPkg, rateLimitCode)
+	}
+
+	if hasCircuitBreaker {
+		g.writeCircuitBreaker(httpPkg, circuitBreakerCode)
+	}
+
+	if hasEventStream {
+		g.writeEventStream(httpPkg)
+	}
+
+	if transportOpt.JsonRPC.WebSocket.Enable {
+		g.writeWebSocket(kithttpPkg, httpPkg)
+	}
+
 	serverOptType := fmt.Sprintf("server%sOpts", g.o.ID)
 	serverOptionType := fmt.Sprintf("%sServerOption", g.o.ID)
 	kithttpServerOption := fmt.Sprintf("%s.ServerOption", kithttpPkg)
@@ -138,8 +209,44 @@ func (g *httpTransport) Process(ctx context.Context) error {
 	g.W("genericEndpointMiddleware []%s\n", endpointMiddlewareOption)
 
 	for _, m := range g.o.Methods {
+		mopt := transportOpt.MethodOptions[m.Name]
+
 		g.W("%sServerOption []%s\n", m.LcName, kithttpServerOption)
 		g.W("%sEndpointMiddleware []%s\n", m.LcName, endpointMiddlewareOption)
+
+		if mopt.RateLimit.Enable {
+			g.W("%sRateLimiter %s.Allower\n", m.LcName, g.i.Import("ratelimit", "github.com/go-kit/kit/ratelimit"))
+		}
+		if mopt.CircuitBreaker.Enable {
+			g.W("%sCircuitBreaker *%s.CircuitBreaker\n", m.LcName, g.i.Import("gobreaker", "github.com/sony/gobreaker"))
+		}
+	}
+	if transportOpt.AccessLog.Enable {
+		g.W("accessLogger %s.Logger\n", g.i.Import("log", "github.com/go-kit/kit/log"))
+	}
+	if transportOpt.HTTPMetrics.Enable {
+		metricsPkg := g.i.Import("metrics", "github.com/go-kit/kit/metrics")
+
+		g.W("httpRequestCount %s.Counter\n", metricsPkg)
+		g.W("httpRequestDuration %s.Histogram\n", metricsPkg)
+		g.W("httpResponseSize %s.Histogram\n", metricsPkg)
+	}
+	if hasEventStream {
+		g.W("eventStreamHeartbeat %s.Duration\n", g.i.Import("time", "time"))
+	}
+	if transportOpt.JsonRPC.WebSocket.Enable {
+		g.W("webSocketCheckOrigin func(r *%s.Request) bool\n", httpPkg)
+		g.W("webSocketReadLimit int64\n")
+		g.W("webSocketConcurrency int\n")
+	}
+	if transportOpt.PanicRecovery.Enable {
+		g.W("panicLogger %s\n", loggerType(g.i, g.o.Logging.Backend))
+		if g.o.Instrumenting.Enable {
+			g.W("panicCount %s.Counter\n", g.i.Import("metrics", "github.com/go-kit/kit/metrics"))
+			if !hasDefaultHTTPMetrics(g.o) {
+				g.W("metricsProvider %s\n", statsdProviderType(g.i, g.o.Instrumenting.Backend))
+			}
+		}
 	}
 	g.W("}\n")
 
@@ -183,10 +290,1510 @@ func (g *httpTransport) Process(ctx context.Context) error {
 				g.W("return func(c *%s) { c.%sEndpointMiddleware = opt }\n", serverOptType, m.LcName)
 			},
 		)
+
+		mopt := transportOpt.MethodOptions[m.Name]
+
+		if mopt.RateLimit.Enable {
+			ratePkg := g.i.Import("rate", "golang.org/x/time/rate")
+
+			g.WriteFunc(
+				g.o.ID+m.Name+"ServerRateLimit",
+				"",
+				[]string{"rps", "float64", "burst", "int"},
+				[]string{"", serverOptionType},
+				func() {
+					g.W("return func(c *%s) { c.%sRateLimiter = %s.NewLimiter(%[3]s.Limit(rps), burst) }\n", serverOptType, m.LcName, ratePkg)
+				},
+			)
+		}
+
+		if mopt.CircuitBreaker.Enable {
+			gobreakerPkg := g.i.Import("gobreaker", "github.com/sony/gobreaker")
+
+			g.WriteFunc(
+				g.o.ID+m.Name+"ServerCircuitBreaker",
+				"",
+				[]string{"settings", gobreakerPkg + ".Settings"},
+				[]string{"", serverOptionType},
+				func() {
+					g.W("return func(c *%s) { c.%sCircuitBreaker = %s.NewCircuitBreaker(settings) }\n", serverOptType, m.LcName, gobreakerPkg)
+				},
+			)
+		}
+	}
+
+	if transportOpt.AccessLog.Enable {
+		g.WriteFunc(
+			g.o.ID+"ServerAccessLogger",
+			"",
+			[]string{"logger", g.i.Import("log", "github.com/go-kit/kit/log") + ".Logger"},
+			[]string{"", serverOptionType},
+			func() {
+				g.W("return func(c *%s) { c.accessLogger = logger }\n", serverOptType)
+			},
+		)
+	}
+
+	if transportOpt.HTTPMetrics.Enable {
+		metricsPkg := g.i.Import("metrics", "github.com/go-kit/kit/metrics")
+
+		g.WriteFunc(
+			g.o.ID+"ServerHTTPMetrics",
+			"",
+			[]string{"requestCount", metricsPkg + ".Counter", "requestDuration", metricsPkg + ".Histogram", "responseSize", metricsPkg + ".Histogram"},
+			[]string{"", serverOptionType},
+			func() {
+				g.W("return func(c *%s) {\n", serverOptType)
+				g.W("c.httpRequestCount = requestCount\n")
+				g.W("c.httpRequestDuration = requestDuration\n")
+				g.W("c.httpResponseSize = responseSize\n")
+				g.W("}\n")
+			},
+		)
+		if hasDefaultHTTPMetrics(g.o) {
+			g.writeDefaultHTTPMetrics()
+		}
+	}
+
+	if hasEventStream {
+		g.WriteFunc(
+			g.o.ID+"ServerEventStreamHeartbeat",
+			"",
+			[]string{"interval", g.i.Import("time", "time") + ".Duration"},
+			[]string{"", serverOptionType},
+			func() {
+				g.W("return func(c *%s) { c.eventStreamHeartbeat = interval }\n", serverOptType)
+			},
+		)
+	}
+
+	if transportOpt.JsonRPC.WebSocket.Enable {
+		g.WriteFunc(
+			g.o.ID+"ServerWebSocketCheckOrigin",
+			"",
+			[]string{"fn", "func(r *" + httpPkg + ".Request) bool"},
+			[]string{"", serverOptionType},
+			func() {
+				g.W("return func(c *%s) { c.webSocketCheckOrigin = fn }\n", serverOptType)
+			},
+		)
+
+		g.WriteFunc(
+			g.o.ID+"ServerWebSocketLimits",
+			"",
+			[]string{"readLimit", "int64", "concurrency", "int"},
+			[]string{"", serverOptionType},
+			func() {
+				g.W("return func(c *%s) {\nc.webSocketReadLimit = readLimit\nc.webSocketConcurrency = concurrency\n}\n", serverOptType)
+			},
+		)
+	}
+
+	if transportOpt.PanicRecovery.Enable {
+		g.WriteFunc(
+			g.o.ID+"ServerPanicLogger",
+			"",
+			[]string{"logger", loggerType(g.i, g.o.Logging.Backend)},
+			[]string{"", serverOptionType},
+			func() {
+				g.W("return func(c *%s) { c.panicLogger = logger }\n", serverOptType)
+			},
+		)
+		if g.o.Instrumenting.Enable {
+			g.WriteFunc(
+				g.o.ID+"ServerPanicCounter",
+				"",
+				[]string{"counter", g.i.Import("metrics", "github.com/go-kit/kit/metrics") + ".Counter"},
+				[]string{"", serverOptionType},
+				func() {
+					g.W("return func(c *%s) { c.panicCount = counter }\n", serverOptType)
+				},
+			)
+			if !hasDefaultHTTPMetrics(g.o) {
+				g.WriteFunc(
+					g.o.ID+"ServerMetricsProvider",
+					"",
+					[]string{"provider", statsdProviderType(g.i, g.o.Instrumenting.Backend)},
+					[]string{"", serverOptionType},
+					func() {
+						g.W("return func(c *%s) { c.metricsProvider = provider }\n", serverOptType)
+					},
+				)
+			}
+			g.writeDefaultPanicCounter()
+		}
+		g.writeRecovery(httpPkg, serverOptType)
+	}
+
+	if transportOpt.AccessLog.Enable || transportOpt.HTTPMetrics.Enable {
+		g.writeObserve(httpPkg, serverOptType)
+	}
+
+	if !g.o.Transport.ServerDisabled {
+		g.writeEnumValidation(httpPkg)
 	}
 	return nil
 }
 
+func (g *httpTransport) writeEnumValidation(httpPkg string) {
+	v := newEnumValidator(g.GoLangWriter, g.i, g.info.EnumOptions)
+
+	var methods []model.ServiceMethod
+	for _, m := range g.o.Methods {
+		if g.o.Transport.MethodOptions[m.Name].ServerRequestFunc.Expr == nil && v.hasEnums(m) {
+			methods = append(methods, m)
+		}
+	}
+	if len(methods) == 0 {
+		return
+	}
+
+	g.W("func errInvalidEnum(name string, value interface{}) error {\n")
+	if g.o.Transport.JsonRPC.Enable {
+		g.W("return &httpError{code: -32602, message: %s.Sprintf(\"invalid %%s value: %%v\", name, value)}\n", g.i.Import("fmt", "fmt"))
+	} else {
+		g.W("return &httpError{code: %s.StatusBadRequest}\n", httpPkg)
+	}
+	g.W("}\n\n")
+
+	for _, m := range methods {
+		v.writeRequest(m, g.o.ID)
+	}
+	v.flush()
+}
+
+func (g *httpTransport) writeEventStream(httpPkg string) {
+	fmtPkg := g.i.Import("fmt", "fmt")
+	ioPkg := g.i.Import("io", "io")
+	jsonPkg := g.i.Import("ffjson", "github.com/pquerna/ffjson/ffjson")
+
+	g.W("type eventStream struct {\n")
+	g.W("w %s.ResponseWriter\n", httpPkg)
+	g.W("f %s.Flusher\n", httpPkg)
+	g.W("}\n\n")
+
+	g.W("func newEventStream(w %s.ResponseWriter) (*eventStream, error) {\n", httpPkg)
+	g.W("f, ok := w.(%s.Flusher)\n", httpPkg)
+	g.W("if !ok {\n")
+	g.W("return nil, %s.Errorf(\"response writer %%T does not support flushing\", w)\n", fmtPkg)
+	g.W("}\n")
+	g.W("h := w.Header()\n")
+	g.W("h.Set(\"Content-Type\", \"text/event-stream\")\n")
+	g.W("h.Set(\"Cache-Control\", \"no-cache\")\n")
+	g.W("h.Set(\"Connection\", \"keep-alive\")\n")
+	g.W("h.Set(\"X-Accel-Buffering\", \"no\")\n")
+	g.W("w.WriteHeader(%s.StatusOK)\n", httpPkg)
+	g.W("f.Flush()\n")
+	g.W("return &eventStream{w: w, f: f}, nil\n")
+	g.W("}\n\n")
+
+	g.W("func (s *eventStream) Event(v interface{}) error {\n")
+	g.W("data, err := %s.Marshal(v)\n", jsonPkg)
+	g.W("if err != nil {\n")
+	g.W("return err\n")
+	g.W("}\n")
+	g.W("if _, err := %s.Fprintf(s.w, \"data: %%s\\n\\n\", data); err != nil {\n", fmtPkg)
+	g.W("return err\n")
+	g.W("}\n")
+	g.W("s.f.Flush()\n")
+	g.W("return nil\n")
+	g.W("}\n\n")
+
+	g.W("func (s *eventStream) Heartbeat() error {\n")
+	g.W("if _, err := %s.WriteString(s.w, \": heartbeat\\n\\n\"); err != nil {\n", ioPkg)
+	g.W("return err\n")
+	g.W("}\n")
+	g.W("s.f.Flush()\n")
+	g.W("return nil\n")
+	g.W("}\n\n")
+
+	if g.o.Transport.Client.Enable {
+		bufioPkg := g.i.Import("bufio", "bufio")
+		bytesPkg := g.i.Import("bytes", "bytes")
+
+		g.W("func readEventStream(r %s.Reader, fn func(data []byte) error) error {\n", ioPkg)
+		g.W("br := %s.NewReader(r)\n", bufioPkg)
+		g.W("var data []byte\n")
+		g.W("for {\n")
+		g.W("line, err := br.ReadBytes('\\n')\n")
+		g.W("if err != nil {\n")
+		g.W("return err\n")
+		g.W("}\n")
+		g.W("line = %s.TrimRight(line, \"\\r\\n\")\n", bytesPkg)
+		g.W("switch {\n")
+		g.W("case len(line) == 0:\n")
+		g.W("if len(data) > 0 {\n")
+		g.W("if err := fn(data); err != nil {\n")
+		g.W("return err\n")
+		g.W("}\n")
+		g.W("data = nil\n")
+		g.W("}\n")
+		g.W("case %s.HasPrefix(line, []byte(\"data:\")):\n", bytesPkg)
+		g.W("if len(data) > 0 {\n")
+		g.W("data = append(data, '\\n')\n")
+		g.W("}\n")
+		g.W("data = append(data, %[1]s.TrimPrefix(line[5:], []byte(\" \"))...)\n", bytesPkg)
+		g.W("}\n")
+		g.W("}\n")
+		g.W("}\n\n")
+	}
+}
+
+func (g *httpTransport) writeWebSocket(jsonrpcPkg, httpPkg string) {
+	contextPkg := g.i.Import("context", "context")
+	jsonPkg := g.i.Import("json", "encoding/json")
+	bytesPkg := g.i.Import("bytes", "bytes")
+	syncPkg := g.i.Import("sync", "sync")
+	reflectPkg := g.i.Import("reflect", "reflect")
+	fmtPkg := g.i.Import("fmt", "fmt")
+	timePkg := g.i.Import("time", "time")
+	websocketPkg := g.i.Import("websocket", "github.com/gorilla/websocket")
+
+	g.W("const (\n")
+	g.W("webSocketPongWait = 60 * %s.Second\n", timePkg)
+	g.W("webSocketPingPeriod = webSocketPongWait * 9 / 10\n")
+	g.W("webSocketWriteWait = 10 * %s.Second\n", timePkg)
+	g.W("// webSocketCancelMethod cancels the stream with the id passed in the params.\n")
+	g.W("webSocketCancelMethod = \"rpc.cancel\"\n")
+	g.W(")\n\n")
+
+	g.W("type webSocketRequest struct {\n")
+	g.W("JSONRPC string `json:\"jsonrpc\"`\n")
+	g.W("Method string `json:\"method\"`\n")
+	g.W("Params %s.RawMessage `json:\"params,omitempty\"`\n", jsonPkg)
+	g.W("ID %s.RawMessage `json:\"id,omitempty\"`\n", jsonPkg)
+	g.W("}\n\n")
+
+	g.W("type webSocketResponse struct {\n")
+	g.W("JSONRPC string `json:\"jsonrpc\"`\n")
+	g.W("Method string `json:\"method,omitempty\"`\n")
+	g.W("Params *webSocketEvent `json:\"params,omitempty\"`\n")
+	g.W("Result %s.RawMessage `json:\"result,omitempty\"`\n", jsonPkg)
+	g.W("Error *%s.Error `json:\"error,omitempty\"`\n", jsonrpcPkg)
+	g.W("ID %s.RawMessage `json:\"id,omitempty\"`\n", jsonPkg)
+	g.W("}\n\n")
+
+	g.W("type webSocketEvent struct {\n")
+	g.W("ID %s.RawMessage `json:\"id\"`\n", jsonPkg)
+	g.W("Result %s.RawMessage `json:\"result,omitempty\"`\n", jsonPkg)
+	g.W("Done bool `json:\"done,omitempty\"`\n")
+	g.W("}\n\n")
+
+	g.W("func webSocketError(err error) *%s.Error {\n", jsonrpcPkg)
+	g.W("e := &%[1]s.Error{Code: %[1]s.InternalError, Message: err.Error()}\n", jsonrpcPkg)
+	g.W("if coder, ok := err.(interface{ ErrorCode() int }); ok {\n")
+	g.W("e.Code = coder.ErrorCode()\n")
+	g.W("}\n")
+	g.W("if data, ok := err.(%s.ErrorData); ok {\n", jsonrpcPkg)
+	g.W("e.Data = data.ErrorData()\n")
+	g.W("}\n")
+	g.W("return e\n")
+	g.W("}\n\n")
+
+	g.W("type webSocketConn struct {\n")
+	g.W("conn *%s.Conn\n", websocketPkg)
+	g.W("ecm %s.EndpointCodecMap\n", jsonrpcPkg)
+	g.W("mu %s.Mutex\n", syncPkg)
+	g.W("wg %s.WaitGroup\n", syncPkg)
+	g.W("smu %s.Mutex\n", syncPkg)
+	g.W("streams map[string]%s.CancelFunc\n", contextPkg)
+	g.W("}\n\n")
+
+	g.W("func (c *webSocketConn) write(v interface{}) error {\n")
+	g.W("c.mu.Lock()\n")
+	g.W("defer c.mu.Unlock()\n")
+	g.W("_ = c.conn.SetWriteDeadline(%s.Now().Add(webSocketWriteWait))\n", timePkg)
+	g.W("return c.conn.WriteJSON(v)\n")
+	g.W("}\n\n")
+
+	g.W("func (c *webSocketConn) ping(ctx %s.Context) {\n", contextPkg)
+	g.W("ticker := %s.NewTicker(webSocketPingPeriod)\n", timePkg)
+	g.W("defer ticker.Stop()\n")
+	g.W("for {\n")
+	g.W("select {\n")
+	g.W("case <-ctx.Done():\n")
+	g.W("return\n")
+	g.W("case <-ticker.C:\n")
+	g.W("if err := c.conn.WriteControl(%s.PingMessage, nil, %s.Now().Add(webSocketWriteWait)); err != nil {\n", websocketPkg, timePkg)
+	g.W("return\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("}\n\n")
+
+	g.W("func (c *webSocketConn) cancel(id string) {\n")
+	g.W("c.smu.Lock()\n")
+	g.W("cancel, ok := c.streams[id]\n")
+	g.W("delete(c.streams, id)\n")
+	g.W("c.smu.Unlock()\n")
+	g.W("if ok {\n")
+	g.W("cancel()\n")
+	g.W("}\n")
+	g.W("}\n\n")
+
+	g.W("func (c *webSocketConn) serve(ctx %s.Context, data []byte) {\n", contextPkg)
+	g.W("data = %s.TrimSpace(data)\n", bytesPkg)
+	g.W("if len(data) > 0 && data[0] == '[' {\n")
+	g.W("var requests []webSocketRequest\n")
+	g.W("if err := %s.Unmarshal(data, &requests); err != nil {\n", jsonPkg)
+	g.W("_ = c.write(webSocketResponse{JSONRPC: %[1]s.Version, Error: &%[1]s.Error{Code: %[1]s.ParseError, Message: err.Error()}})\n", jsonrpcPkg)
+	g.W("return\n")
+	g.W("}\n")
+	g.W("if len(requests) == 0 {\n")
+	g.W("_ = c.write(webSocketResponse{JSONRPC: %[1]s.Version, Error: &%[1]s.Error{Code: %[1]s.InvalidRequestError, Message: \"empty batch\"}})\n", jsonrpcPkg)
+	g.W("return\n")
+	g.W("}\n")
+	g.W("responses := make([]*webSocketResponse, len(requests))\n")
+	g.W("streams := make([]func(), len(requests))\n")
+	g.W("var wg %s.WaitGroup\n", syncPkg)
+	g.W("for i := range requests {\n")
+	g.W("wg.Add(1)\n")
+	g.W("go func(i int) {\n")
+	g.W("defer wg.Done()\n")
+	g.W("responses[i], streams[i] = c.call(ctx, requests[i])\n")
+	g.W("}(i)\n")
+	g.W("}\n")
+	g.W("wg.Wait()\n")
+	g.W("var results []*webSocketResponse\n")
+	g.W("for _, response := range responses {\n")
+	g.W("if response != nil {\n")
+	g.W("results = append(results, response)\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("if len(results) > 0 {\n")
+	g.W("if err := c.write(results); err != nil {\n")
+	g.W("return\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("for _, stream := range streams {\n")
+	g.W("if stream != nil {\n")
+	g.W("stream()\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("return\n")
+	g.W("}\n")
+	g.W("var request webSocketRequest\n")
+	g.W("if err := %s.Unmarshal(data, &request); err != nil {\n", jsonPkg)
+	g.W("_ = c.write(webSocketResponse{JSONRPC: %[1]s.Version, Error: &%[1]s.Error{Code: %[1]s.ParseError, Message: err.Error()}})\n", jsonrpcPkg)
+	g.W("return\n")
+	g.W("}\n")
+	g.W("response, stream := c.call(ctx, request)\n")
+	g.W("if response != nil {\n")
+	g.W("if err := c.write(response); err != nil {\n")
+	g.W("return\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("if stream != nil {\n")
+	g.W("stream()\n")
+	g.W("}\n")
+	g.W("}\n\n")
+
+	g.W("func (c *webSocketConn) call(ctx %s.Context, request webSocketRequest) (*webSocketResponse, func()) {\n", contextPkg)
+	g.W("response := &webSocketResponse{JSONRPC: %s.Version, ID: request.ID}\n", jsonrpcPkg)
+	g.W("if len(request.ID) == 0 {\n")
+	g.W("response = nil\n")
+	g.W("}\n")
+	g.W("fail := func(err *%s.Error) (*webSocketResponse, func()) {\n", jsonrpcPkg)
+	g.W("if response != nil {\n")
+	g.W("response.Error = err\n")
+	g.W("}\n")
+	g.W("return response, nil\n")
+	g.W("}\n")
+	g.W("if request.Method == webSocketCancelMethod {\n")
+	g.W("var params struct {\nID %s.RawMessage `json:\"id\"`\n}\n", jsonPkg)
+	g.W("if err := %s.Unmarshal(request.Params, &params); err != nil {\n", jsonPkg)
+	g.W("return fail(&%[1]s.Error{Code: %[1]s.InvalidParamsError, Message: err.Error()})\n", jsonrpcPkg)
+	g.W("}\n")
+	g.W("c.cancel(string(params.ID))\n")
+	g.W("if response != nil {\n")
+	g.W("response.Result = %s.RawMessage(\"null\")\n", jsonPkg)
+	g.W("}\n")
+	g.W("return response, nil\n")
+	g.W("}\n")
+	g.W("codec, ok := c.ecm[request.Method]\n")
+	g.W("if !ok {\n")
+	g.W("return fail(&%[1]s.Error{Code: %[1]s.MethodNotFoundError, Message: %[2]s.Sprintf(\"Method %%s was not found.\", request.Method)})\n", jsonrpcPkg, fmtPkg)
+	g.W("}\n")
+	g.W("ctx, cancel := %s.WithCancel(ctx)\n", contextPkg)
+	g.W("params, err := codec.Decode(ctx, request.Params)\n")
+	g.W("if err != nil {\n")
+	g.W("cancel()\n")
+	g.W("return fail(webSocketError(err))\n")
+	g.W("}\n")
+	g.W("result, err := codec.Endpoint(ctx, params)\n")
+	g.W("if err != nil {\n")
+	g.W("cancel()\n")
+	g.W("return fail(webSocketError(err))\n")
+	g.W("}\n")
+	g.W("if events := %[1]s.ValueOf(result); events.Kind() == %[1]s.Chan {\n", reflectPkg)
+	g.W("if response != nil {\n")
+	g.W("response.Result = %s.RawMessage(\"null\")\n", jsonPkg)
+	g.W("}\n")
+	g.W("id := string(request.ID)\n")
+	g.W("if id != \"\" {\n")
+	g.W("c.cancel(id)\n")
+	g.W("c.smu.Lock()\n")
+	g.W("c.streams[id] = cancel\n")
+	g.W("c.smu.Unlock()\n")
+	g.W("}\n")
+	g.W("return response, func() {\n")
+	g.W("c.wg.Add(1)\n")
+	g.W("go func() {\n")
+	g.W("defer c.wg.Done()\n")
+	g.W("defer c.cancel(id)\n")
+	g.W("defer cancel()\n")
+	g.W("c.stream(ctx, request, codec, events)\n")
+	g.W("}()\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("defer cancel()\n")
+	g.W("data, err := codec.Encode(ctx, result)\n")
+	g.W("if err != nil {\n")
+	g.W("return fail(webSocketError(err))\n")
+	g.W("}\n")
+	g.W("if response != nil {\n")
+	g.W("response.Result = data\n")
+	g.W("}\n")
+	g.W("return response, nil\n")
+	g.W("}\n\n")
+
+	g.W("func (c *webSocketConn) stream(ctx %s.Context, request webSocketRequest, codec %s.EndpointCodec, events %s.Value) {\n", contextPkg, jsonrpcPkg, reflectPkg)
+	g.W("cases := []%[1]s.SelectCase{\n", reflectPkg)
+	g.W("{Dir: %[1]s.SelectRecv, Chan: %[1]s.ValueOf(ctx.Done())},\n", reflectPkg)
+	g.W("{Dir: %s.SelectRecv, Chan: events},\n", reflectPkg)
+	g.W("}\n")
+	g.W("for {\n")
+	g.W("chosen, value, ok := %s.Select(cases)\n", reflectPkg)
+	g.W("if chosen == 0 {\n")
+	g.W("return\n")
+	g.W("}\n")
+	g.W("event := &webSocketEvent{ID: request.ID}\n")
+	g.W("if !ok {\n")
+	g.W("event.Done = true\n")
+	g.W("} else {\n")
+	g.W("data, err := codec.Encode(ctx, value.Interface())\n")
+	g.W("if err != nil {\n")
+	g.W("continue\n")
+	g.W("}\n")
+	g.W("event.Result = data\n")
+	g.W("}\n")
+	g.W("if err := c.write(webSocketResponse{JSONRPC: %s.Version, Method: request.Method, Params: event}); err != nil || event.Done {\n", jsonrpcPkg)
+	g.W("return\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("}\n\n")
+
+	g.W("func webSocketHandler(ecm %s.EndpointCodecMap, checkOrigin func(r *%s.Request) bool, readLimit int64, concurrency int, before ...func(%s.Context, *%[2]s.Request) %[3]s.Context) %[2]s.Handler {\n", jsonrpcPkg, httpPkg, contextPkg)
+	g.W("upgrader := %s.Upgrader{CheckOrigin: checkOrigin, Subprotocols: []string{\"jsonrpc\"}}\n", websocketPkg)
+	g.W("return %s.HandlerFunc(func(w %[1]s.ResponseWriter, r *%[1]s.Request) {\n", httpPkg)
+	g.W("conn, err := upgrader.Upgrade(w, r, nil)\n")
+	g.W("if err != nil {\n")
+	g.W("return\n")
+	g.W("}\n")
+	g.W("defer conn.Close()\n")
+	g.W("c := &webSocketConn{conn: conn, ecm: ecm, streams: map[string]%s.CancelFunc{}}\n", contextPkg)
+	g.W("defer c.wg.Wait()\n")
+	g.W("ctx, cancel := %s.WithCancel(r.Context())\n", contextPkg)
+	g.W("defer cancel()\n")
+	if g.o.Transport.AccessLog.Enable || g.o.Transport.HTTPMetrics.Enable {
+		g.W("ctx = %s.WithValue(ctx, httpObservationContextKey{}, (*httpObservation)(nil))\n", contextPkg)
+	}
+	g.W("for _, f := range before {\n")
+	g.W("ctx = f(ctx, r)\n")
+	g.W("}\n")
+	if g.o.Transport.Auth.Enable {
+		kitjwtPkg := g.i.Import("jwt", "github.com/go-kit/kit/auth/jwt")
+		stringsPkg := g.i.Import("strings", "strings")
+
+		g.W("// browsers cannot set the Authorization header, the token is passed in the bearer.<token> subprotocol.\n")
+		g.W("if _, ok := ctx.Value(%s.JWTTokenContextKey).(string); !ok {\n", kitjwtPkg)
+		g.W("for _, protocol := range %s.Subprotocols(r) {\n", websocketPkg)
+		g.W("if %s.HasPrefix(protocol, \"bearer.\") {\n", stringsPkg)
+		g.W("ctx = %s.WithValue(ctx, %s.JWTTokenContextKey, %s.TrimPrefix(protocol, \"bearer.\"))\n", contextPkg, kitjwtPkg, stringsPkg)
+		g.W("break\n")
+		g.W("}\n")
+		g.W("}\n")
+		g.W("}\n")
+	}
+	g.W("conn.SetReadLimit(readLimit)\n")
+	g.W("_ = conn.SetReadDeadline(%s.Now().Add(webSocketPongWait))\n", timePkg)
+	g.W("conn.SetPongHandler(func(string) error {\n")
+	g.W("return conn.SetReadDeadline(%s.Now().Add(webSocketPongWait))\n", timePkg)
+	g.W("})\n")
+	g.W("c.wg.Add(1)\n")
+	g.W("go func() {\n")
+	g.W("defer c.wg.Done()\n")
+	g.W("c.ping(ctx)\n")
+	g.W("}()\n")
+	g.W("// the number of the concurrently served messages is limited, the next message is read when a slot is free.\n")
+	g.W("slots := make(chan struct{}, concurrency)\n")
+	g.W("for {\n")
+	g.W("_, data, err := conn.ReadMessage()\n")
+	g.W("if err != nil {\n")
+	g.W("return\n")
+	g.W("}\n")
+	g.W("_ = conn.SetReadDeadline(%s.Now().Add(webSocketPongWait))\n", timePkg)
+	g.W("select {\n")
+	g.W("case slots <- struct{}{}:\n")
+	g.W("case <-ctx.Done():\n")
+	g.W("return\n")
+	g.W("}\n")
+	g.W("c.wg.Add(1)\n")
+	g.W("go func() {\n")
+	g.W("defer c.wg.Done()\n")
+	g.W("defer func() { <-slots }()\n")
+	g.W("c.serve(ctx, data)\n")
+	g.W("}()\n")
+	g.W("}\n")
+	g.W("})\n")
+	g.W("}\n\n")
+
+	if g.o.Transport.Client.Enable {
+		g.writeWebSocketClient(jsonrpcPkg, httpPkg, websocketPkg)
+	}
+}
+
+func (g *httpTransport) writeWebSocketClient(jsonrpcPkg, httpPkg, websocketPkg string) {
+	contextPkg := g.i.Import("context", "context")
+	jsonPkg := g.i.Import("json", "encoding/json")
+	syncPkg := g.i.Import("sync", "sync")
+	atomicPkg := g.i.Import("atomic", "sync/atomic")
+	strconvPkg := g.i.Import("strconv", "strconv")
+	errorsPkg := g.i.Import("errors", "errors")
+
+	g.W("var errWebSocketClosed = %s.New(\"websocket connection closed\")\n\n", errorsPkg)
+
+	g.W("// webSocketStreamBuffer is the number of the events buffered for a stream,\n")
+	g.W("// the events of a slow consumer are dropped when the buffer is full.\n")
+	g.W("const webSocketStreamBuffer = 64\n\n")
+
+	g.W("type webSocketStream struct {\n")
+	g.W("id uint64\n")
+	g.W("events chan []byte\n")
+	g.W("done chan struct{}\n")
+	g.W("}\n\n")
+
+	g.W("type webSocketClient struct {\n")
+	g.W("ctx %s.Context\n", contextPkg)
+	g.W("url string\n")
+	g.W("header func(%s.Context) (%s.Header, error)\n", contextPkg, httpPkg)
+	g.W("seq uint64\n")
+	g.W("mu %s.Mutex\n", syncPkg)
+	g.W("wmu %s.Mutex\n", syncPkg)
+	g.W("conn *%s.Conn\n", websocketPkg)
+	g.W("pending map[uint64]chan *webSocketResponse\n")
+	g.W("streams map[uint64]*webSocketStream\n")
+	g.W("}\n\n")
+
+	g.W("func newWebSocketClient(ctx %s.Context, url string) *webSocketClient {\n", contextPkg)
+	g.W("c := &webSocketClient{\n")
+	g.W("ctx: ctx,\n")
+	g.W("url: url,\n")
+	g.W("pending: map[uint64]chan *webSocketResponse{},\n")
+	g.W("streams: map[uint64]*webSocketStream{},\n")
+	g.W("}\n")
+	g.W("go func() {\n")
+	g.W("<-ctx.Done()\n")
+	g.W("c.mu.Lock()\n")
+	g.W("defer c.mu.Unlock()\n")
+	g.W("if c.conn != nil {\n")
+	g.W("_ = c.conn.Close()\n")
+	g.W("}\n")
+	g.W("}()\n")
+	g.W("return c\n")
+	g.W("}\n\n")
+
+	g.W("func (c *webSocketClient) dial(ctx %s.Context) (*%s.Conn, error) {\n", contextPkg, websocketPkg)
+	g.W("c.mu.Lock()\n")
+	g.W("defer c.mu.Unlock()\n")
+	g.W("if c.ctx.Err() != nil {\n")
+	g.W("return nil, errWebSocketClosed\n")
+	g.W("}\n")
+	g.W("if c.conn != nil {\n")
+	g.W("return c.conn, nil\n")
+	g.W("}\n")
+	g.W("var header %s.Header\n", httpPkg)
+	g.W("if c.header != nil {\n")
+	g.W("var err error\n")
+	g.W("if header, err = c.header(ctx); err != nil {\n")
+	g.W("return nil, err\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("conn, _, err := %s.DefaultDialer.DialContext(ctx, c.url, header)\n", websocketPkg)
+	g.W("if err != nil {\n")
+	g.W("return nil, err\n")
+	g.W("}\n")
+	g.W("c.conn = conn\n")
+	g.W("go c.read(conn)\n")
+	g.W("return conn, nil\n")
+	g.W("}\n\n")
+
+	g.W("func (c *webSocketClient) read(conn *%s.Conn) {\n", websocketPkg)
+	g.W("defer func() {\n")
+	g.W("_ = conn.Close()\n")
+	g.W("c.mu.Lock()\n")
+	g.W("defer c.mu.Unlock()\n")
+	g.W("if c.conn == conn {\n")
+	g.W("c.conn = nil\n")
+	g.W("}\n")
+	g.W("for id, ch := range c.pending {\n")
+	g.W("close(ch)\n")
+	g.W("delete(c.pending, id)\n")
+	g.W("}\n")
+	g.W("for id, stream := range c.streams {\n")
+	g.W("close(stream.events)\n")
+	g.W("delete(c.streams, id)\n")
+	g.W("}\n")
+	g.W("}()\n")
+	g.W("for {\n")
+	g.W("_, data, err := conn.ReadMessage()\n")
+	g.W("if err != nil {\n")
+	g.W("return\n")
+	g.W("}\n")
+	g.W("var responses []*webSocketResponse\n")
+	g.W("if len(data) > 0 && data[0] == '[' {\n")
+	g.W("err = %s.Unmarshal(data, &responses)\n", jsonPkg)
+	g.W("} else {\n")
+	g.W("responses = make([]*webSocketResponse, 1)\n")
+	g.W("err = %s.Unmarshal(data, &responses[0])\n", jsonPkg)
+	g.W("}\n")
+	g.W("if err != nil {\n")
+	g.W("continue\n")
+	g.W("}\n")
+	g.W("for _, response := range responses {\n")
+	g.W("c.dispatch(response)\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("}\n\n")
+
+	g.W("func (c *webSocketClient) dispatch(response *webSocketResponse) {\n")
+	g.W("if response.Params != nil {\n")
+	g.W("id, err := %s.ParseUint(string(response.Params.ID), 10, 64)\n", strconvPkg)
+	g.W("if err != nil {\n")
+	g.W("return\n")
+	g.W("}\n")
+	g.W("c.mu.Lock()\n")
+	g.W("stream, ok := c.streams[id]\n")
+	g.W("if ok && response.Params.Done {\n")
+	g.W("delete(c.streams, id)\n")
+	g.W("}\n")
+	g.W("c.mu.Unlock()\n")
+	g.W("if !ok {\n")
+	g.W("return\n")
+	g.W("}\n")
+	g.W("if response.Params.Done {\n")
+	g.W("close(stream.events)\n")
+	g.W("return\n")
+	g.W("}\n")
+	g.W("select {\n")
+	g.W("case stream.events <- response.Params.Result:\n")
+	g.W("case <-stream.done:\n")
+	g.W("default:\n")
+	g.W("}\n")
+	g.W("return\n")
+	g.W("}\n")
+	g.W("id, err := %s.ParseUint(string(response.ID), 10, 64)\n", strconvPkg)
+	g.W("if err != nil {\n")
+	g.W("return\n")
+	g.W("}\n")
+	g.W("c.mu.Lock()\n")
+	g.W("ch, ok := c.pending[id]\n")
+	g.W("delete(c.pending, id)\n")
+	g.W("c.mu.Unlock()\n")
+	g.W("if ok {\n")
+	g.W("ch <- response\n")
+	g.W("}\n")
+	g.W("}\n\n")
+
+	g.W("func (c *webSocketClient) call(ctx %s.Context, method string, params %s.RawMessage, stream *webSocketStream) (%[2]s.RawMessage, error) {\n", contextPkg, jsonPkg)
+	g.W("conn, err := c.dial(ctx)\n")
+	g.W("if err != nil {\n")
+	g.W("return nil, err\n")
+	g.W("}\n")
+	g.W("id := %s.AddUint64(&c.seq, 1)\n", atomicPkg)
+	g.W("ch := make(chan *webSocketResponse, 1)\n")
+	g.W("c.mu.Lock()\n")
+	g.W("c.pending[id] = ch\n")
+	g.W("if stream != nil {\n")
+	g.W("stream.id = id\n")
+	g.W("c.streams[id] = stream\n")
+	g.W("}\n")
+	g.W("c.mu.Unlock()\n")
+	g.W("forget := func() {\n")
+	g.W("c.mu.Lock()\n")
+	g.W("delete(c.pending, id)\n")
+	g.W("delete(c.streams, id)\n")
+	g.W("c.mu.Unlock()\n")
+	g.W("}\n")
+	g.W("c.wmu.Lock()\n")
+	g.W("err = conn.WriteJSON(webSocketRequest{\n")
+	g.W("JSONRPC: %s.Version,\n", jsonrpcPkg)
+	g.W("Method: method,\n")
+	g.W("Params: params,\n")
+	g.W("ID: %s.RawMessage(%s.FormatUint(id, 10)),\n", jsonPkg, strconvPkg)
+	g.W("})\n")
+	g.W("c.wmu.Unlock()\n")
+	g.W("if err != nil {\n")
+	g.W("forget()\n")
+	g.W("return nil, err\n")
+	g.W("}\n")
+	g.W("select {\n")
+	g.W("case <-ctx.Done():\n")
+	g.W("forget()\n")
+	g.W("return nil, ctx.Err()\n")
+	g.W("case response, ok := <-ch:\n")
+	g.W("if !ok {\n")
+	g.W("return nil, errWebSocketClosed\n")
+	g.W("}\n")
+	g.W("if response.Error != nil {\n")
+	g.W("forget()\n")
+	g.W("return nil, ErrorDecode(response.Error.Code, response.Error.Message, response.Error.Data)\n")
+	g.W("}\n")
+	g.W("return response.Result, nil\n")
+	g.W("}\n")
+	g.W("}\n\n")
+
+	g.W("func (c *webSocketClient) unsubscribe(stream *webSocketStream) {\n")
+	g.W("c.mu.Lock()\n")
+	g.W("_, active := c.streams[stream.id]\n")
+	g.W("delete(c.streams, stream.id)\n")
+	g.W("conn := c.conn\n")
+	g.W("c.mu.Unlock()\n")
+	g.W("close(stream.done)\n")
+	g.W("if !active || conn == nil {\n")
+	g.W("return\n")
+	g.W("}\n")
+	g.W("// the server stops sending the events of the canceled stream.\n")
+	g.W("c.wmu.Lock()\n")
+	g.W("_ = conn.WriteJSON(webSocketRequest{\n")
+	g.W("JSONRPC: %s.Version,\n", jsonrpcPkg)
+	g.W("Method: webSocketCancelMethod,\n")
+	g.W("Params: %s.RawMessage(`{\"id\":` + %s.FormatUint(stream.id, 10) + `}`),\n", jsonPkg, strconvPkg)
+	g.W("})\n")
+	g.W("c.wmu.Unlock()\n")
+	g.W("}\n\n")
+}
+
+func (g *httpTransport) writeDefaultPanicCounter() {
+	metricsPkg := g.i.Import("metrics", "github.com/go-kit/kit/metrics")
+
+	// the statsd and dogstatsd counters are created by the provider passed to the handler.
+	if !hasDefaultHTTPMetrics(g.o) {
+		name := "panics_total"
+		if g.o.Instrumenting.Subsystem != "" {
+			name = g.o.Instrumenting.Subsystem + "." + name
+		}
+		if g.o.Instrumenting.Namespace != "" {
+			name = g.o.Instrumenting.Namespace + "." + name
+		}
+		g.W("func defaultPanicCounter(provider %s) %s.Counter {\n", statsdProviderType(g.i, g.o.Instrumenting.Backend), metricsPkg)
+		g.W("return provider.NewCounter(%s, 1)\n", strconv.Quote(name))
+		g.W("}\n\n")
+		return
+	}
+
+	syncPkg := g.i.Import("sync", "sync")
+
+	g.W("var (\n")
+	g.W("defaultPanicCounterOnce %s.Once\n", syncPkg)
+	g.W("defaultPanicCount %s.Counter\n", metricsPkg)
+	g.W(")\n\n")
+
+	g.W("func defaultPanicCounter() %s.Counter {\n", metricsPkg)
+	g.W("defaultPanicCounterOnce.Do(func() {\n")
+	switch g.o.Instrumenting.Backend {
+	case "prometheus":
+		stdPrometheusPkg := g.i.Import("prometheus", "github.com/prometheus/client_golang/prometheus")
+		kitPrometheusPkg := g.i.Import("prometheus", "github.com/go-kit/kit/metrics/prometheus")
+
+		g.W("defaultPanicCount = %s.NewCounterFrom(%s.CounterOpts{\n", kitPrometheusPkg, stdPrometheusPkg)
+		g.W("Namespace: %s,\n", strconv.Quote(g.o.Instrumenting.Namespace))
+		g.W("Subsystem: %s,\n", strconv.Quote(g.o.Instrumenting.Subsystem))
+		g.W("Name: \"panics_total\",\n")
+		g.W("Help: \"Number of recovered panics.\",\n")
+		g.W("}, []string{\"method\"})\n")
+	case "expvar":
+		name := "panics_total"
+		if g.o.Instrumenting.Subsystem != "" {
+			name = g.o.Instrumenting.Subsystem + "_" + name
+		}
+		if g.o.Instrumenting.Namespace != "" {
+			name = g.o.Instrumenting.Namespace + "_" + name
+		}
+		g.W("defaultPanicCount = %s.NewCounter(%s)\n", g.i.Import("expvar", "github.com/go-kit/kit/metrics/expvar"), strconv.Quote(name))
+	default:
+		g.W("defaultPanicCount = %s.NewCounter()\n", g.i.Import("discard", "github.com/go-kit/kit/metrics/discard"))
+	}
+	g.W("})\n")
+	g.W("return defaultPanicCount\n")
+	g.W("}\n\n")
+}
+
+func (g *httpTransport) writeRecovery(httpPkg, serverOptType string) {
+	contextPkg := g.i.Import("context", "context")
+	endpointPkg := g.i.Import("endpoint", "github.com/go-kit/kit/endpoint")
+	fmtPkg := g.i.Import("fmt", "fmt")
+	debugPkg := g.i.Import("debug", "runtime/debug")
+
+	g.W("func recoveryMiddleware(method string, sopt *%s) %s.Middleware {\n", serverOptType, endpointPkg)
+	g.W("return func(next %[1]s.Endpoint) %[1]s.Endpoint {\n", endpointPkg)
+	g.W("return func(ctx %s.Context, request interface{}) (response interface{}, err error) {\n", contextPkg)
+	g.W("defer func() {\n")
+	g.W("if r := recover(); r != nil {\n")
+	g.W("logPanic(sopt, method, r)\n")
+	g.W("response, err = nil, errInternal()\n")
+	g.W("}\n")
+	g.W("}()\n")
+	g.W("return next(ctx, request)\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("}\n\n")
+
+	// the handler recovers the panics of the request decoders and the response encoders.
+	if g.o.Transport.JsonRPC.Enable {
+		g.W("const recoveryJSONRPCError = `{\"jsonrpc\":\"2.0\",\"error\":{\"code\":-32603,\"message\":\"internal error\"},\"id\":null}`\n\n")
+	}
+	if g.o.Transport.FastHTTP {
+		g.W("func recoveryHandler(next %[1]s.RequestHandler, sopt *%[2]s) %[1]s.RequestHandler {\n", httpPkg, serverOptType)
+		g.W("return func(ctx *%s.RequestCtx) {\n", httpPkg)
+		g.W("defer func() {\n")
+		g.W("if r := recover(); r != nil {\n")
+		g.W("logPanic(sopt, \"\", r)\n")
+		g.W("ctx.ResetBody()\n")
+		g.W("ctx.SetStatusCode(%s.StatusInternalServerError)\n", httpPkg)
+		if g.o.Transport.JsonRPC.Enable {
+			g.W("ctx.SetContentType(\"application/json; charset=utf-8\")\n")
+			g.W("ctx.SetBodyString(recoveryJSONRPCError)\n")
+		}
+		g.W("}\n")
+		g.W("}()\n")
+		g.W("next(ctx)\n")
+		g.W("}\n")
+		g.W("}\n\n")
+	} else {
+		g.W("func recoveryHandler(next %[1]s.Handler, sopt *%[2]s) %[1]s.Handler {\n", httpPkg, serverOptType)
+		g.W("return %s.HandlerFunc(func(w %s.ResponseWriter, r *%[2]s.Request) {\n", httpPkg, httpPkg)
+		g.W("defer func() {\n")
+		g.W("if rec := recover(); rec != nil {\n")
+		g.W("if rec == %s.ErrAbortHandler {\npanic(rec)\n}\n", httpPkg)
+		g.W("logPanic(sopt, \"\", rec)\n")
+		if g.o.Transport.JsonRPC.Enable {
+			g.W("w.Header().Set(\"Content-Type\", \"application/json; charset=utf-8\")\n")
+			g.W("w.WriteHeader(%s.StatusInternalServerError)\n", httpPkg)
+			g.W("_, _ = w.Write([]byte(recoveryJSONRPCError))\n")
+		} else {
+			g.W("w.WriteHeader(%s.StatusInternalServerError)\n", httpPkg)
+		}
+		g.W("}\n")
+		g.W("}()\n")
+		g.W("next.ServeHTTP(w, r)\n")
+		g.W("})\n")
+		g.W("}\n\n")
+	}
+
+	g.W("func logPanic(sopt *%s, method string, r interface{}) {\n", serverOptType)
+	switch g.o.Logging.Backend {
+	case "zap":
+		g.W("if sopt.panicLogger != nil {\n")
+		g.W("sopt.panicLogger.Sugar().Errorw(\"panic\", \"method\", method, \"panic\", %s.Sprint(r), \"stack\", string(%s.Stack()))\n", fmtPkg, debugPkg)
+		g.W("}\n")
+	case "zerolog":
+		g.W("sopt.panicLogger.Error().Str(\"method\", method).Str(\"panic\", %s.Sprint(r)).Bytes(\"stack\", %s.Stack()).Msg(\"panic\")\n", fmtPkg, debugPkg)
+	case "slog":
+		g.W("if sopt.panicLogger != nil {\n")
+		g.W("sopt.panicLogger.Error(\"panic\", \"method\", method, \"panic\", %s.Sprint(r), \"stack\", string(%s.Stack()))\n", fmtPkg, debugPkg)
+		g.W("}\n")
+	default:
+		g.W("if sopt.panicLogger != nil {\n")
+		g.W("_ = sopt.panicLogger.Log(\"method\", method, \"panic\", %s.Sprint(r), \"stack\", string(%s.Stack()))\n", fmtPkg, debugPkg)
+		g.W("}\n")
+	}
+	if g.o.Instrumenting.Enable {
+		g.W("if sopt.panicCount != nil {\n")
+		if g.o.Instrumenting.Backend == "statsd" {
+			// statsd has no tags, the method label would be dropped.
+			g.W("sopt.panicCount.Add(1)\n")
+		} else {
+			g.W("sopt.panicCount.With(\"method\", method).Add(1)\n")
+		}
+		g.W("}\n")
+	}
+	g.W("}\n\n")
+
+	g.W("func errInternal() error {\n")
+	if g.o.Transport.JsonRPC.Enable {
+		g.W("return &httpError{code: -32603, message: \"Internal error\"}\n")
+	} else {
+		g.W("return &httpError{code: %s.StatusInternalServerError}\n", httpPkg)
+	}
+	g.W("}\n\n")
+}
+
+func (g *httpTransport) writeDefaultHTTPMetrics() {
+	metricsPkg := g.i.Import("metrics", "github.com/go-kit/kit/metrics")
+	syncPkg := g.i.Import("sync", "sync")
+
+	labels := []string{"method", "route", "status"}
+	if g.o.Transport.JsonRPC.Enable {
+		labels = append(labels, "rpc_method", "rpc_code")
+	}
+
+	g.W("var (\n")
+	g.W("defaultHTTPMetricsOnce %s.Once\n", syncPkg)
+	g.W("defaultHTTPRequestCount %s.Counter\n", metricsPkg)
+	g.W("defaultHTTPRequestDuration %s.Histogram\n", metricsPkg)
+	g.W("defaultHTTPResponseSize %s.Histogram\n", metricsPkg)
+	g.W(")\n\n")
+
+	g.W("func defaultHTTPMetrics() (%[1]s.Counter, %[1]s.Histogram, %[1]s.Histogram) {\n", metricsPkg)
+	g.W("defaultHTTPMetricsOnce.Do(func() {\n")
+	switch g.o.Instrumenting.Backend {
+	case "none":
+		discardPkg := g.i.Import("discard", "github.com/go-kit/kit/metrics/discard")
+
+		g.W("defaultHTTPRequestCount = %s.NewCounter()\n", discardPkg)
+		g.W("defaultHTTPRequestDuration = %s.NewHistogram()\n", discardPkg)
+		g.W("defaultHTTPResponseSize = %s.NewHistogram()\n", discardPkg)
+	case "expvar":
+		expvarPkg := g.i.Import("expvar", "github.com/go-kit/kit/metrics/expvar")
+
+		name := func(name string) string {
+			parts := []string{name}
+			if g.o.Instrumenting.Subsystem != "" {
+				parts = append([]string{g.o.Instrumenting.Subsystem}, parts...)
+			}
+			if g.o.Instrumenting.Namespace != "" {
+				parts = append([]string{g.o.Instrumenting.Namespace}, parts...)
+			}
+			return strconv.Quote(strings.Join(parts, "_"))
+		}
+
+		// the expvar metrics have no labels.
+		g.W("defaultHTTPRequestCount = %s.NewCounter(%s)\n", expvarPkg, name("http_requests_total"))
+		g.W("defaultHTTPRequestDuration = %s.NewHistogram(%s, 50)\n", expvarPkg, name("http_request_duration_seconds"))
+		g.W("defaultHTTPResponseSize = %s.NewHistogram(%s, 50)\n", expvarPkg, name("http_response_size_bytes"))
+	default:
+		stdPrometheusPkg := g.i.Import("prometheus", "github.com/prometheus/client_golang/prometheus")
+		kitPrometheusPkg := g.i.Import("prometheus", "github.com/go-kit/kit/metrics/prometheus")
+
+		writeOpts := func(name, help string) {
+			g.W("Namespace: %s,\n", strconv.Quote(g.o.Instrumenting.Namespace))
+			g.W("Subsystem: %s,\n", strconv.Quote(g.o.Instrumenting.Subsystem))
+			g.W("Name: %s,\n", strconv.Quote(name))
+			g.W("Help: %s,\n", strconv.Quote(help))
+		}
+
+		g.W("defaultHTTPRequestCount = %s.NewCounterFrom(%s.CounterOpts{\n", kitPrometheusPkg, stdPrometheusPkg)
+		writeOpts("http_requests_total", "Number of HTTP requests received.")
+		g.W("}, %s)\n", stringSliceLiteral(labels))
+
+		g.W("defaultHTTPRequestDuration = %s.NewHistogramFrom(%s.HistogramOpts{\n", kitPrometheusPkg, stdPrometheusPkg)
+		writeOpts("http_request_duration_seconds", "Duration of HTTP requests in seconds.")
+		g.W("Buckets: %s.DefBuckets,\n", stdPrometheusPkg)
+		g.W("}, %s)\n", stringSliceLiteral(labels))
+
+		g.W("defaultHTTPResponseSize = %s.NewHistogramFrom(%s.HistogramOpts{\n", kitPrometheusPkg, stdPrometheusPkg)
+		writeOpts("http_response_size_bytes", "Size of HTTP responses in bytes.")
+		g.W("Buckets: %s.ExponentialBuckets(100, 10, 6),\n", stdPrometheusPkg)
+		g.W("}, %s)\n", stringSliceLiteral(labels))
+	}
+	g.W("})\n")
+	g.W("return defaultHTTPRequestCount, defaultHTTPRequestDuration, defaultHTTPResponseSize\n")
+	g.W("}\n\n")
+}
+
+func (g *httpTransport) writeObserve(httpPkg, serverOptType string) {
+	contextPkg := g.i.Import("context", "context")
+	timePkg := g.i.Import("time", "time")
+	transportOpt := g.o.Transport
+
+	g.W("type httpObservation struct {\n")
+	g.W("route string\n")
+	if transportOpt.JsonRPC.Enable {
+		g.W("rpcMethod string\n")
+		g.W("rpcCode int\n")
+	}
+	g.W("}\n\n")
+
+	if transportOpt.FastHTTP {
+		routerPkg := g.i.Import("routing", "github.com/qiangxue/fasthttp-routing")
+
+		g.W("const httpObservationContextKey = \"httpObservation\"\n\n")
+
+		g.W("func httpObservationFromContext(ctx %s.Context) *httpObservation {\n", contextPkg)
+		g.W("o, _ := ctx.Value(httpObservationContextKey).(*httpObservation)\n")
+		g.W("return o\n")
+		g.W("}\n\n")
+
+		g.W("func observeRoute(route string, next %[1]s.Handler) %[1]s.Handler {\n", routerPkg)
+		g.W("return func(c *%s.Context) error {\n", routerPkg)
+		g.W("if o := httpObservationFromContext(c.RequestCtx); o != nil {\n")
+		g.W("o.route = route\n")
+		g.W("}\n")
+		g.W("return next(c)\n")
+		g.W("}\n")
+		g.W("}\n\n")
+
+		g.W("func observeHandler(next %[1]s.RequestHandler, sopt *%[2]s) %[1]s.RequestHandler {\n", httpPkg, serverOptType)
+		g.W("return func(ctx *%s.RequestCtx) {\n", httpPkg)
+		g.W("begin := %s.Now()\n", timePkg)
+		g.W("o := &httpObservation{}\n")
+		g.W("ctx.SetUserValue(httpObservationContextKey, o)\n")
+		g.W("next(ctx)\n")
+		g.W("observeRequest(sopt, string(ctx.Method()), string(ctx.Path()), o, ctx.Response.StatusCode(), len(ctx.Response.Body()), %s.Since(begin))\n", timePkg)
+		g.W("}\n")
+		g.W("}\n\n")
+	} else {
+		g.W("type httpObservationContextKey struct{}\n\n")
+
+		g.W("func httpObservationFromContext(ctx %s.Context) *httpObservation {\n", contextPkg)
+		g.W("o, _ := ctx.Value(httpObservationContextKey{}).(*httpObservation)\n")
+		g.W("return o\n")
+		g.W("}\n\n")
+
+		g.W("func observeRoute(route string, next %[1]s.Handler) %[1]s.Handler {\n", httpPkg)
+		g.W("return %s.HandlerFunc(func(w %s.ResponseWriter, r *%[2]s.Request) {\n", httpPkg, httpPkg)
+		g.W("if o := httpObservationFromContext(r.Context()); o != nil {\n")
+		g.W("o.route = route\n")
+		g.W("}\n")
+		g.W("next.ServeHTTP(w, r)\n")
+		g.W("})\n")
+		g.W("}\n\n")
+
+		g.W("type observeResponseWriter struct {\n")
+		g.W("%s.ResponseWriter\n", httpPkg)
+		g.W("status int\n")
+		g.W("size int\n")
+		g.W("}\n\n")
+
+		g.W("func (w *observeResponseWriter) WriteHeader(status int) {\n")
+		g.W("w.status = status\n")
+		g.W("w.ResponseWriter.WriteHeader(status)\n")
+		g.W("}\n\n")
+
+		g.W("func (w *observeResponseWriter) Write(b []byte) (int, error) {\n")
+		g.W("n, err := w.ResponseWriter.Write(b)\n")
+		g.W("w.size += n\n")
+		g.W("return n, err\n")
+		g.W("}\n\n")
+
+		g.W("func (w *observeResponseWriter) Flush() {\n")
+		g.W("if f, ok := w.ResponseWriter.(%s.Flusher); ok {\n", httpPkg)
+		g.W("f.Flush()\n")
+		g.W("}\n")
+		g.W("}\n\n")
+
+		if transportOpt.JsonRPC.WebSocket.Enable {
+			netPkg := g.i.Import("net", "net")
+			bufioPkg := g.i.Import("bufio", "bufio")
+
+			g.W("func (w *observeResponseWriter) Hijack() (%s.Conn, *%s.ReadWriter, error) {\n", netPkg, bufioPkg)
+			g.W("h, ok := w.ResponseWriter.(%s.Hijacker)\n", httpPkg)
+			g.W("if !ok {\n")
+			g.W("return nil, nil, %s.Errorf(\"response writer %%T does not support hijacking\", w.ResponseWriter)\n", g.i.Import("fmt", "fmt"))
+			g.W("}\n")
+			g.W("w.status = %s.StatusSwitchingProtocols\n", httpPkg)
+			g.W("return h.Hijack()\n")
+			g.W("}\n\n")
+		}
+
+		g.W("func observeHandler(next %[1]s.Handler, sopt *%[2]s) %[1]s.Handler {\n", httpPkg, serverOptType)
+		g.W("return %s.HandlerFunc(func(w %s.ResponseWriter, r *%[2]s.Request) {\n", httpPkg, httpPkg)
+		g.W("begin := %s.Now()\n", timePkg)
+		g.W("o := &httpObservation{}\n")
+		g.W("ow := &observeResponseWriter{ResponseWriter: w, status: %s.StatusOK}\n", httpPkg)
+		g.W("next.ServeHTTP(ow, r.WithContext(%s.WithValue(r.Context(), httpObservationContextKey{}, o)))\n", contextPkg)
+		g.W("observeRequest(sopt, r.Method, r.URL.Path, o, ow.status, ow.size, %s.Since(begin))\n", timePkg)
+		g.W("})\n")
+		g.W("}\n\n")
+	}
+
+	if transportOpt.JsonRPC.Enable {
+		endpointPkg := g.i.Import("endpoint", "github.com/go-kit/kit/endpoint")
+
+		g.W("func observeEndpointMiddleware(rpcMethod string) %s.Middleware {\n", endpointPkg)
+		g.W("return func(next %[1]s.Endpoint) %[1]s.Endpoint {\n", endpointPkg)
+		g.W("return func(ctx %s.Context, request interface{}) (interface{}, error) {\n", contextPkg)
+		g.W("response, err := next(ctx, request)\n")
+		g.W("if o := httpObservationFromContext(ctx); o != nil {\n")
+		g.W("o.rpcMethod = rpcMethod\n")
+		g.W("if e, ok := err.(interface{ ErrorCode() int }); ok {\n")
+		g.W("o.rpcCode = e.ErrorCode()\n")
+		g.W("} else if err != nil {\n")
+		g.W("o.rpcCode = -32603\n")
+		g.W("}\n")
+		g.W("}\n")
+		g.W("return response, err\n")
+		g.W("}\n")
+		g.W("}\n")
+		g.W("}\n\n")
+	}
+
+	g.W("func observeRequest(sopt *%s, method, path string, o *httpObservation, status, size int, took %s.Duration) {\n", serverOptType, timePkg)
+	g.W("route := o.route\n")
+	g.W("if route == \"\" {\n")
+	g.W("route = \"unknown\"\n")
+	g.W("}\n")
+	if transportOpt.AccessLog.Enable {
+		g.W("if sopt.accessLogger != nil {\n")
+		g.W("keyvals := []interface{}{\"method\", method, \"path\", path, \"route\", route, \"status\", status, \"size\", size, \"took\", took}\n")
+		if transportOpt.JsonRPC.Enable {
+			g.W("if o.rpcMethod != \"\" {\n")
+			g.W("keyvals = append(keyvals, \"rpc_method\", o.rpcMethod, \"rpc_code\", o.rpcCode)\n")
+			g.W("}\n")
+		}
+		g.W("_ = sopt.accessLogger.Log(keyvals...)\n")
+		g.W("}\n")
+	}
+	if transportOpt.HTTPMetrics.Enable {
+		strconvPkg := g.i.Import("strconv", "strconv")
+
+		g.W("labelValues := []string{\"method\", method, \"route\", route, \"status\", %s.Itoa(status)", strconvPkg)
+		if transportOpt.JsonRPC.Enable {
+			g.W(", \"rpc_method\", o.rpcMethod, \"rpc_code\", %s.Itoa(o.rpcCode)", strconvPkg)
+		}
+		g.W("}\n")
+		g.W("if sopt.httpRequestCount != nil {\n")
+		g.W("sopt.httpRequestCount.With(labelValues...).Add(1)\n")
+		g.W("}\n")
+		g.W("if sopt.httpRequestDuration != nil {\n")
+		g.W("sopt.httpRequestDuration.With(labelValues...).Observe(took.Seconds())\n")
+		g.W("}\n")
+		g.W("if sopt.httpResponseSize != nil {\n")
+		g.W("sopt.httpResponseSize.With(labelValues...).Observe(float64(size))\n")
+		g.W("}\n")
+	}
+	g.W("}\n\n")
+}
+
+func (g *httpTransport) writeRateLimit(httpPkg string, code int64) {
+	contextPkg := g.i.Import("context", "context")
+	endpointPkg := g.i.Import("endpoint", "github.com/go-kit/kit/endpoint")
+	ratelimitPkg := g.i.Import("ratelimit", "github.com/go-kit/kit/ratelimit")
+
+	g.W("func errRateLimitExceeded() error {\n")
+	if e := g.transportError(code); e != nil {
+		// the service error with the same code is returned, so the client decodes the code to one type.
+		g.W("return %s\n", g.errorValue(e))
+		g.W("}\n\n")
+	} else {
+		g.W("return RateLimitExceededError{}\n")
+		g.W("}\n\n")
+
+		g.W("// RateLimitExceededError is returned when the method rate limit is exceeded.\n")
+		g.W("type RateLimitExceededError struct{}\n\n")
+		g.W("func (RateLimitExceededError) Error() string {\nreturn \"rate limit exceeded\"\n}\n\n")
+		if g.o.Transport.JsonRPC.Enable {
+			g.W("func (RateLimitExceededError) ErrorCode() int {\nreturn %d\n}\n\n", code)
+		} else {
+			g.W("func (RateLimitExceededError) StatusCode() int {\nreturn %s.StatusTooManyRequests\n}\n\n", httpPkg)
+		}
+	}
+
+	g.W("func rateLimitMiddleware(limiter %s.Allower) %s.Middleware {\n", ratelimitPkg, endpointPkg)
+	g.W("return func(next %[1]s.Endpoint) %[1]s.Endpoint {\n", endpointPkg)
+	g.W("return func(ctx %s.Context, request interface{}) (interface{}, error) {\n", contextPkg)
+	g.W("if !limiter.Allow() {\n")
+	g.W("return nil, errRateLimitExceeded()\n")
+	g.W("}\n")
+	g.W("return next(ctx, request)\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("}\n\n")
+}
+
+func (g *httpTransport) writeCircuitBreaker(httpPkg string, code int64) {
+	contextPkg := g.i.Import("context", "context")
+	endpointPkg := g.i.Import("endpoint", "github.com/go-kit/kit/endpoint")
+	gobreakerPkg := g.i.Import("gobreaker", "github.com/sony/gobreaker")
+
+	g.W("func errServiceUnavailable() error {\n")
+	if e := g.transportError(code); e != nil {
+		// the service error with the same code is returned, so the client decodes the code to one type.
+		g.W("return %s\n", g.errorValue(e))
+		g.W("}\n\n")
+	} else {
+		g.W("return ServiceUnavailableError{}\n")
+		g.W("}\n\n")
+
+		g.W("// ServiceUnavailableError is returned when the method circuit breaker is open.\n")
+		g.W("type ServiceUnavailableError struct{}\n\n")
+		g.W("func (ServiceUnavailableError) Error() string {\nreturn \"service unavailable\"\n}\n\n")
+		if g.o.Transport.JsonRPC.Enable {
+			g.W("func (ServiceUnavailableError) ErrorCode() int {\nreturn %d\n}\n\n", code)
+		} else {
+			g.W("func (ServiceUnavailableError) StatusCode() int {\nreturn %s.StatusServiceUnavailable\n}\n\n", httpPkg)
+		}
+	}
+
+	g.W("func circuitBreakerMiddleware(cb *%s.CircuitBreaker) %s.Middleware {\n", gobreakerPkg, endpointPkg)
+	g.W("return func(next %[1]s.Endpoint) %[1]s.Endpoint {\n", endpointPkg)
+	g.W("return func(ctx %s.Context, request interface{}) (interface{}, error) {\n", contextPkg)
+	g.W("var serviceErr error\n")
+	g.W("response, err := cb.Execute(func() (interface{}, error) {\n")
+	g.W("response, err := next(ctx, request)\n")
+	g.W("if err != nil && !isCircuitBreakerFailure(err) {\n")
+	g.W("serviceErr = err\n")
+	g.W("return response, nil\n")
+	g.W("}\n")
+	g.W("return response, err\n")
+	g.W("})\n")
+	g.W("if err == %[1]s.ErrOpenState || err == %[1]s.ErrTooManyRequests {\n", gobreakerPkg)
+	g.W("return nil, errServiceUnavailable()\n")
+	g.W("}\n")
+	g.W("if serviceErr != nil {\n")
+	g.W("return response, serviceErr\n")
+	g.W("}\n")
+	g.W("return response, err\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("}\n\n")
+
+	if g.o.Transport.JsonRPC.Enable {
+		g.W("// isCircuitBreakerFailure reports whether the error is counted by the circuit breaker,\n")
+		g.W("// the errors with the error code are the service errors, except the internal and the service unavailable errors.\n")
+		g.W("func isCircuitBreakerFailure(err error) bool {\n")
+		g.W("if e, ok := err.(interface{ ErrorCode() int }); ok {\n")
+		g.W("return e.ErrorCode() == -32603 || e.ErrorCode() == %d\n", code)
+		g.W("}\n")
+		g.W("return true\n")
+		g.W("}\n\n")
+	} else {
+		g.W("// isCircuitBreakerFailure reports whether the error is counted by the circuit breaker,\n")
+		g.W("// the errors with the status code less than 500 are the service errors.\n")
+		g.W("func isCircuitBreakerFailure(err error) bool {\n")
+		g.W("if e, ok := err.(interface{ StatusCode() int }); ok {\n")
+		g.W("return e.StatusCode() >= %s.StatusInternalServerError\n", httpPkg)
+		g.W("}\n")
+		g.W("return true\n")
+		g.W("}\n\n")
+	}
+}
+
+func (g *httpTransport) writeAuth(httpPkg string, code int64) {
+	contextPkg := g.i.Import("context", "context")
+	endpointPkg := g.i.Import("endpoint", "github.com/go-kit/kit/endpoint")
+	jwtPkg := g.i.Import("jwt", "github.com/dgrijalva/jwt-go")
+	kitjwtPkg := g.i.Import("jwt", "github.com/go-kit/kit/auth/jwt")
+
+	transportOpt := g.o.Transport
+
+	g.W("func authMiddleware(keyFunc %s.Keyfunc, newClaims %s.ClaimsFactory) %s.Middleware {\n", jwtPkg, kitjwtPkg, endpointPkg)
+	g.W("return func(next %[1]s.Endpoint) %[1]s.Endpoint {\n", endpointPkg)
+	g.W("return func(ctx %s.Context, request interface{}) (interface{}, error) {\n", contextPkg)
+	g.W("tokenString, ok := ctx.Value(%s.JWTTokenContextKey).(string)\n", kitjwtPkg)
+	g.W("if !ok {\n")
+	g.W("return nil, errUnauthorized()\n")
+	g.W("}\n")
+	g.W("token, err := %s.ParseWithClaims(tokenString, newClaims(), keyFunc)\n", jwtPkg)
+	g.W("if err != nil || !token.Valid {\n")
+	g.W("return nil, errUnauthorized()\n")
+	g.W("}\n")
+	g.W("ctx = %s.WithValue(ctx, %s.JWTClaimsContextKey, token.Claims)\n", contextPkg, kitjwtPkg)
+	g.W("return next(ctx, request)\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("}\n\n")
+
+	g.W("func errUnauthorized() error {\n")
+	if e := g.transportError(code); e != nil {
+		// the service error with the same code is returned, so the client decodes the code to one type.
+		g.W("return %s\n", g.errorValue(e))
+		g.W("}\n\n")
+	} else {
+		g.W("return UnauthorizedError{}\n")
+		g.W("}\n\n")
+
+		g.W("// UnauthorizedError is returned when the authentication token is missing or invalid.\n")
+		g.W("type UnauthorizedError struct{}\n\n")
+		g.W("func (UnauthorizedError) Error() string {\nreturn \"unauthorized\"\n}\n\n")
+		if transportOpt.JsonRPC.Enable {
+			g.W("func (UnauthorizedError) ErrorCode() int {\nreturn %d\n}\n\n", code)
+		} else {
+			g.W("func (UnauthorizedError) StatusCode() int {\nreturn %s.StatusUnauthorized\n}\n\n", httpPkg)
+		}
+	}
+
+	if transportOpt.FastHTTP {
+		stringsPkg := g.i.Import("strings", "strings")
+
+		g.W("func authFastHTTPToContext(ctx %s.Context, r *%s.Request) %s.Context {\n", contextPkg, httpPkg, contextPkg)
+		g.W("authHeader := string(r.Header.Peek(\"Authorization\"))\n")
+		g.W("if len(authHeader) < 7 || !%s.EqualFold(authHeader[:7], \"bearer \") {\n", stringsPkg)
+		g.W("return ctx\n")
+		g.W("}\n")
+		g.W("return %s.WithValue(ctx, %s.JWTTokenContextKey, authHeader[7:])\n", contextPkg, kitjwtPkg)
+		g.W("}\n\n")
+
+		g.W("func authContextToFastHTTP(ctx %s.Context, r *%s.Request) %s.Context {\n", contextPkg, httpPkg, contextPkg)
+		g.W("if token, ok := ctx.Value(%s.JWTTokenContextKey).(string); ok {\n", kitjwtPkg)
+		g.W("r.Header.Set(\"Authorization\", \"Bearer \"+token)\n")
+		g.W("}\n")
+		g.W("return ctx\n")
+		g.W("}\n\n")
+	}
+}
+
+func (g *httpTransport) writeRequestID(httpPkg string) {
+	contextPkg := g.i.Import("context", "context")
+
+	g.W("func requestIDHTTPToContext(ctx %s.Context, r *%s.Request) %s.Context {\n", contextPkg, httpPkg, contextPkg)
+	if g.o.Transport.FastHTTP {
+		g.W("if requestID := string(r.Header.Peek(\"X-Request-Id\")); requestID != \"\" {\n")
+	} else {
+		g.W("if requestID := r.Header.Get(\"X-Request-Id\"); requestID != \"\" {\n")
+	}
+	g.W("return ContextWithRequestID(ctx, requestID)\n")
+	g.W("}\n")
+	g.W("return ctx\n")
+	g.W("}\n\n")
+
+	g.W("func requestIDContextToHTTP(ctx %s.Context, r *%s.Request) %s.Context {\n", contextPkg, httpPkg, contextPkg)
+	g.W("if requestID, ok := RequestIDFromContext(ctx); ok {\n")
+	g.W("r.Header.Set(\"X-Request-Id\", requestID)\n")
+	g.W("}\n")
+	g.W("return ctx\n")
+	g.W("}\n\n")
+}
+
+func (g *httpTransport) writeTracing(httpPkg string) {
+	contextPkg := g.i.Import("context", "context")
+	propagationPkg := g.i.Import("propagation", "go.opentelemetry.io/otel/propagation")
+
+	if g.o.Transport.FastHTTP {
+		g.W("type tracingFastHTTPCarrier struct {\nh *%s.RequestHeader\n}\n\n", httpPkg)
+
+		g.W("func (c tracingFastHTTPCarrier) Get(key string) string {\n")
+		g.W("return string(c.h.Peek(key))\n")
+		g.W("}\n\n")
+
+		g.W("func (c tracingFastHTTPCarrier) Set(key string, value string) {\n")
+		g.W("c.h.Set(key, value)\n")
+		g.W("}\n\n")
+
+		g.W("func (c tracingFastHTTPCarrier) Keys() []string {\n")
+		g.W("var keys []string\n")
+		g.W("c.h.VisitAll(func(key, _ []byte) {\n")
+		g.W("keys = append(keys, string(key))\n")
+		g.W("})\n")
+		g.W("return keys\n")
+		g.W("}\n\n")
+
+		g.W("func tracingHTTPToContext(ctx %s.Context, r *%s.Request) %s.Context {\n", contextPkg, httpPkg, contextPkg)
+		g.W("return %s.TraceContext{}.Extract(ctx, tracingFastHTTPCarrier{h: &r.Header})\n", propagationPkg)
+		g.W("}\n\n")
+
+		g.W("func tracingContextToHTTP(ctx %s.Context, r *%s.Request) %s.Context {\n", contextPkg, httpPkg, contextPkg)
+		g.W("%s.TraceContext{}.Inject(ctx, tracingFastHTTPCarrier{h: &r.Header})\n", propagationPkg)
+		g.W("return ctx\n")
+		g.W("}\n\n")
+		return
+	}
+
+	g.W("func tracingHTTPToContext(ctx %s.Context, r *%s.Request) %s.Context {\n", contextPkg, httpPkg, contextPkg)
+	g.W("return %[1]s.TraceContext{}.Extract(ctx, %[1]s.HeaderCarrier(r.Header))\n", propagationPkg)
+	g.W("}\n\n")
+
+	g.W("func tracingContextToHTTP(ctx %s.Context, r *%s.Request) %s.Context {\n", contextPkg, httpPkg, contextPkg)
+	g.W("%[1]s.TraceContext{}.Inject(ctx, %[1]s.HeaderCarrier(r.Header))\n", propagationPkg)
+	g.W("return ctx\n")
+	g.W("}\n\n")
+}
+
+func (g *httpTransport) writeAuthorization(httpPkg string, code int64) {
+	contextPkg := g.i.Import("context", "context")
+	endpointPkg := g.i.Import("endpoint", "github.com/go-kit/kit/endpoint")
+
+	transportOpt := g.o.Transport
+
+	var hasPermissions, hasRoles bool
+	for _, mopt := range transportOpt.MethodOptions {
+		hasPermissions = hasPermissions || len(mopt.Permissions) > 0
+		hasRoles = hasRoles || len(mopt.Roles) > 0
+	}
+
+	g.W("func authorizationMiddleware(permissions, roles []string) %s.Middleware {\n", endpointPkg)
+	g.W("return func(next %[1]s.Endpoint) %[1]s.Endpoint {\n", endpointPkg)
+	g.W("return func(ctx %s.Context, request interface{}) (interface{}, error) {\n", contextPkg)
+	g.W("principal, err := ")
+	writer.WriteAST(g, g.i, transportOpt.Principal.Extractor)
+	g.W("(ctx)\n")
+	g.W("if err != nil {\n")
+	g.W("return nil, err\n")
+	g.W("}\n")
+	if hasPermissions {
+		g.W("for _, permission := range permissions {\n")
+		g.W("if !principal.HasPermission(permission) {\n")
+		g.W("return nil, errForbidden()\n")
+		g.W("}\n")
+		g.W("}\n")
+	}
+	if hasRoles {
+		g.W("if len(roles) > 0 {\n")
+		g.W("var hasRole bool\n")
+		g.W("for _, role := range roles {\n")
+		g.W("if principal.HasRole(role) {\n")
+		g.W("hasRole = true\n")
+		g.W("break\n")
+		g.W("}\n")
+		g.W("}\n")
+		g.W("if !hasRole {\n")
+		g.W("return nil, errForbidden()\n")
+		g.W("}\n")
+		g.W("}\n")
+	}
+	if !hasPermissions && !hasRoles {
+		g.W("_ = principal\n")
+	}
+	g.W("return next(ctx, request)\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("}\n\n")
+
+	g.W("func errForbidden() error {\n")
+	if e := g.transportError(code); e != nil {
+		// the service error with the same code is returned, so the client decodes the code to one type.
+		g.W("return %s\n", g.errorValue(e))
+		g.W("}\n\n")
+	} else {
+		g.W("return ForbiddenError{}\n")
+		g.W("}\n\n")
+
+		g.W("// ForbiddenError is returned when the principal has no required permission or role.\n")
+		g.W("type ForbiddenError struct{}\n\n")
+		g.W("func (ForbiddenError) Error() string {\nreturn \"forbidden\"\n}\n\n")
+		if transportOpt.JsonRPC.Enable {
+			g.W("func (ForbiddenError) ErrorCode() int {\nreturn %d\n}\n\n", code)
+		} else {
+			g.W("func (ForbiddenError) StatusCode() int {\nreturn %s.StatusForbidden\n}\n\n", httpPkg)
+		}
+	}
+}
+
+// transportError returns the service error with the code.
+func (g *httpTransport) transportError(code int64) *model.ErrorHTTPTransportOption {
+	var keys []uint32
+	for key := range g.o.Transport.Errors {
+		keys = append(keys, key)
+	}
+	sortkeys.Uint32s(keys)
+	for _, key := range keys {
+		if e := g.o.Transport.Errors[key]; e.Code == code {
+			return e
+		}
+	}
+	return nil
+}
+
+// errorValue returns the zero value of the service error.
+func (g *httpTransport) errorValue(e *model.ErrorHTTPTransportOption) string {
+	newPrefix := ""
+	if e.IsPointer {
+		newPrefix = "&"
+	}
+	return newPrefix + stdtypes.TypeString(e.Named, g.i.QualifyPkg) + "{}"
+}
+
 func (g *httpTransport) PkgName() string {
 	return ""
 }
@@ -3,7 +3,9 @@ package generator
 import (
 	"context"
 	stdtypes "go/types"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/swipe-io/swipe/pkg/domain/model"
 	"github.com/swipe-io/swipe/pkg/importer"
@@ -32,41 +34,80 @@ func (g *instrumenting) Process(ctx context.Context) error {
 	}
 	metricsPkg := g.i.Import("metrics", "github.com/go-kit/kit/metrics")
 	typeStr := stdtypes.TypeString(g.o.Type, g.i.QualifyPkg)
-	stdPrometheusPkg := g.i.Import("prometheus", "github.com/prometheus/client_golang/prometheus")
-	kitPrometheusPkg := g.i.Import("prometheus", "github.com/go-kit/kit/metrics/prometheus")
 
 	name := "instrumentingMiddleware" + g.o.ID
 	constructName := "NewInstrumentingMiddleware" + g.o.ID
+	// the legacy metrics have only the method label and no requests in flight gauge.
+	legacy := g.o.Instrumenting.LegacyNames
+	// statsd has no tags, the method is put into the metric name and the error label is not used.
+	statsd := g.o.Instrumenting.Backend == "statsd"
 
 	g.W("type %s struct {\n", name)
 	g.W("next %s\n", typeStr)
 	g.W("requestCount %s.Counter\n", metricsPkg)
 	g.W("requestLatency %s.Histogram\n", metricsPkg)
+	if !legacy {
+		g.W("requestsInFlight %s.Gauge\n", metricsPkg)
+	}
 	g.W("}\n")
 
 	for _, m := range g.o.Methods {
-		var params []string
+		var params, results []string
 
 		if m.ParamCtx != nil {
 			params = append(params, m.ParamCtx.Name(), stdtypes.TypeString(m.ParamCtx.Type(), g.i.QualifyPkg))
 		}
 
 		params = append(params, types.NameTypeParams(m.Params, g.i.QualifyPkg, nil)...)
-		results := types.NameTypeParams(m.Results, g.i.QualifyPkg, nil)
 
+		if len(m.Results) > 0 {
+			if m.ResultsNamed {
+				results = types.NameTypeParams(m.Results, g.i.QualifyPkg, nil)
+			} else {
+				results = append(results, "result", stdtypes.TypeString(m.Results[0].Type(), g.i.QualifyPkg))
+			}
+		}
+
+		var errName string
+		errLabel := strconv.Quote("none")
 		if m.ReturnErr != nil {
-			results = append(results, "", "error")
+			errName = m.ReturnErr.Name()
+			if errName == "" || errName == "_" {
+				errName = "err"
+			}
+			results = append(results, errName, "error")
+			errLabel = "errLabel"
+		}
+		labels := "\"method\", " + strconv.Quote(m.Name)
+		if !statsd {
+			labels += ", \"error\", " + errLabel
 		}
 
 		g.WriteFunc(m.Name, "s *"+name, params, results, func() {
-			g.WriteDefer(
-				[]string{"begin " + timePkg + ".Time"},
-				[]string{timePkg + ".Now()"},
-				func() {
-					g.W("s.requestCount.With(\"method\", \"%s\").Add(1)\n", m.Name)
-					g.W("s.requestLatency.With(\"method\", \"%s\").Observe(%s.Since(begin).Seconds())\n", m.Name, timePkg)
-				},
-			)
+			if legacy {
+				g.WriteDefer(
+					[]string{"begin " + timePkg + ".Time"},
+					[]string{timePkg + ".Now()"},
+					func() {
+						g.W("s.requestCount.With(\"method\", \"%s\").Add(1)\n", m.Name)
+						g.W("s.requestLatency.With(\"method\", \"%s\").Observe(%s.Since(begin).Seconds())\n", m.Name, timePkg)
+					},
+				)
+			} else {
+				g.W("s.requestsInFlight.With(\"method\", \"%s\").Add(1)\n", m.Name)
+				g.WriteDefer(
+					[]string{"begin " + timePkg + ".Time"},
+					[]string{timePkg + ".Now()"},
+					func() {
+						g.W("s.requestsInFlight.With(\"method\", \"%s\").Add(-1)\n", m.Name)
+						if errName != "" && !statsd {
+							g.W("errLabel := instrumentingErrorLabel(%s)\n", errName)
+						}
+						g.W("s.requestCount.With(%s).Add(1)\n", labels)
+						g.W("s.requestLatency.With(%s).Observe(%s.Since(begin).Seconds())\n", labels, timePkg)
+					},
+				)
+			}
 			if len(m.Results) > 0 || m.ReturnErr != nil {
 				g.W("return ")
 			}
@@ -79,35 +120,306 @@ func (g *instrumenting) Process(ctx context.Context) error {
 				if i > 0 {
 					g.W(",")
 				}
-				g.W(p.Name())
+				g.W("%s", p.Name())
 			}
 
 			g.W(")\n")
 		})
 	}
 
-	g.W("func %[1]s(s %[2]s, requestCount %[3]s.Counter, requestLatency %[3]s.Histogram) %[2]s {\n", constructName, typeStr, metricsPkg)
+	var providerParam string
+	switch g.o.Instrumenting.Backend {
+	case "statsd", "dogstatsd":
+		providerParam = "provider " + statsdProviderType(g.i, g.o.Instrumenting.Backend) + ", "
+	}
+
+	if legacy {
+		g.W("func %[1]s(s %[2]s, %[4]srequestCount %[3]s.Counter, requestLatency %[3]s.Histogram) %[2]s {\n", constructName, typeStr, metricsPkg, providerParam)
+	} else {
+		g.W("func %[1]s(s %[2]s, %[4]srequestCount %[3]s.Counter, requestLatency %[3]s.Histogram, requestsInFlight %[3]s.Gauge) %[2]s {\n", constructName, typeStr, metricsPkg, providerParam)
+	}
+	switch g.o.Instrumenting.Backend {
+	case "statsd", "dogstatsd":
+		g.writeStatsdDefaults()
+	case "expvar":
+		g.writeExpvarDefaults()
+	case "none":
+		g.writeDiscardDefaults()
+	default:
+		g.writePrometheusDefaults()
+	}
+	if legacy {
+		g.W("return &%s{next: s, requestCount: requestCount, requestLatency: requestLatency}\n}\n\n", name)
+	} else {
+		g.W("return &%s{next: s, requestCount: requestCount, requestLatency: requestLatency, requestsInFlight: requestsInFlight}\n}\n\n", name)
+
+		if !statsd {
+			g.writeErrorLabel()
+		}
+	}
+
+	if statsd {
+		g.writeStatsdMetrics(metricsPkg)
+	}
+	return nil
+}
+
+func (g *instrumenting) writePrometheusDefaults() {
+	stdPrometheusPkg := g.i.Import("prometheus", "github.com/prometheus/client_golang/prometheus")
+	kitPrometheusPkg := g.i.Import("prometheus", "github.com/go-kit/kit/metrics/prometheus")
+
+	requestCountName, requestCountHelp := g.requestCountName(), "Number of requests received."
+
+	writeOpts := func(name, help string) {
+		g.W("Namespace: %s,\n", strconv.Quote(g.o.Instrumenting.Namespace))
+		g.W("Subsystem: %s,\n", strconv.Quote(g.o.Instrumenting.Subsystem))
+		g.W("Name: %s,\n", strconv.Quote(name))
+		g.W("Help: %s,\n", strconv.Quote(help))
+		if len(g.o.Instrumenting.Labels) > 0 {
+			g.W("ConstLabels: %s.Labels{\n", stdPrometheusPkg)
+			for _, name := range g.labelNames() {
+				g.W("%s: %s,\n", strconv.Quote(name), strconv.Quote(g.o.Instrumenting.Labels[name]))
+			}
+			g.W("},\n")
+		}
+	}
+
+	labelNames := `[]string{"method", "error"}`
+	if g.o.Instrumenting.LegacyNames {
+		labelNames = `[]string{"method"}`
+	}
 
 	g.W("if requestCount == nil {\n")
 	g.W("requestCount = %s.NewCounterFrom(%s.CounterOpts{\n", kitPrometheusPkg, stdPrometheusPkg)
-	g.W("Namespace: %s,\n", strconv.Quote(g.o.Instrumenting.Namespace))
-	g.W("Subsystem: %s,\n", strconv.Quote(g.o.Instrumenting.Subsystem))
-	g.W("Name: %s,\n", strconv.Quote("request_count"))
-	g.W("Help: %s,\n", strconv.Quote("Number of requests received."))
-	g.W("}, []string{\"method\"})\n")
+	writeOpts(requestCountName, requestCountHelp)
+	g.W("}, %s)\n", labelNames)
 	g.W("\n}\n")
 
 	g.W("if requestLatency == nil {\n")
-	g.W("requestLatency = %s.NewSummaryFrom(%s.SummaryOpts{\n", kitPrometheusPkg, stdPrometheusPkg)
-	g.W("Namespace: %s,\n", strconv.Quote(g.o.Instrumenting.Namespace))
-	g.W("Subsystem: %s,\n", strconv.Quote(g.o.Instrumenting.Subsystem))
-	g.W("Name: %s,\n", strconv.Quote("request_latency_microseconds"))
-	g.W("Help: %s,\n", strconv.Quote("Total duration of requests in microseconds."))
+	if g.o.Instrumenting.LegacyNames {
+		g.W("requestLatency = %s.NewSummaryFrom(%s.SummaryOpts{\n", kitPrometheusPkg, stdPrometheusPkg)
+		writeOpts(g.requestLatencyName(), "Total duration of requests in microseconds.")
+	} else {
+		g.W("requestLatency = %s.NewHistogramFrom(%s.HistogramOpts{\n", kitPrometheusPkg, stdPrometheusPkg)
+		writeOpts(g.requestLatencyName(), "Duration of requests in seconds.")
+		if len(g.o.Instrumenting.Buckets) > 0 {
+			g.W("Buckets: []float64{")
+			for i, expr := range g.o.Instrumenting.Buckets {
+				if i > 0 {
+					g.W(", ")
+				}
+				writer.WriteAST(g, g.i, expr)
+			}
+			g.W("},\n")
+		} else {
+			g.W("Buckets: %s.DefBuckets,\n", stdPrometheusPkg)
+		}
+	}
+	g.W("}, %s)\n", labelNames)
+	g.W("\n}\n")
+
+	if g.o.Instrumenting.LegacyNames {
+		return
+	}
+	g.W("if requestsInFlight == nil {\n")
+	g.W("requestsInFlight = %s.NewGaugeFrom(%s.GaugeOpts{\n", kitPrometheusPkg, stdPrometheusPkg)
+	writeOpts("requests_in_flight", "Number of requests being processed.")
 	g.W("}, []string{\"method\"})\n")
 	g.W("\n}\n")
+}
 
-	g.W("return &%s{next: s, requestCount: requestCount, requestLatency: requestLatency}\n}\n", name)
-	return nil
+func (g *instrumenting) writeStatsdDefaults() {
+	if g.o.Instrumenting.Backend == "statsd" {
+		prefix := g.metricName("", ".")
+		if prefix != "" {
+			prefix += "."
+		}
+		prefix = strconv.Quote(prefix)
+
+		g.W("if requestCount == nil {\n")
+		g.W("requestCount = statsdCounter{provider: provider, prefix: %s, name: %s}\n", prefix, strconv.Quote(g.requestCountName()))
+		g.W("}\n")
+		g.W("if requestLatency == nil {\n")
+		g.W("requestLatency = statsdTiming{provider: provider, prefix: %s, name: %s}\n", prefix, strconv.Quote(g.requestLatencyName()))
+		g.W("}\n")
+		if g.o.Instrumenting.LegacyNames {
+			return
+		}
+		g.W("if requestsInFlight == nil {\n")
+		g.W("requestsInFlight = statsdGauge{provider: provider, prefix: %s, name: \"requests_in_flight\"}\n", prefix)
+		g.W("}\n")
+		return
+	}
+
+	name := func(name string) string {
+		return strconv.Quote(g.metricName(name, "."))
+	}
+	var labels string
+	if g.o.Instrumenting.Backend == "dogstatsd" && len(g.o.Instrumenting.Labels) > 0 {
+		var labelValues []string
+		for _, name := range g.labelNames() {
+			labelValues = append(labelValues, strconv.Quote(name), strconv.Quote(g.o.Instrumenting.Labels[name]))
+		}
+		labels = ".With(" + strings.Join(labelValues, ", ") + ")"
+	}
+
+	g.W("if requestCount == nil {\n")
+	g.W("requestCount = provider.NewCounter(%s, 1)%s\n", name(g.requestCountName()), labels)
+	g.W("}\n")
+
+	g.W("if requestLatency == nil {\n")
+	g.W("requestLatency = provider.NewHistogram(%s, 1)%s\n", name(g.requestLatencyName()), labels)
+	g.W("}\n")
+
+	if g.o.Instrumenting.LegacyNames {
+		return
+	}
+	g.W("if requestsInFlight == nil {\n")
+	g.W("requestsInFlight = provider.NewGauge(%s)%s\n", name("requests_in_flight"), labels)
+	g.W("}\n")
+}
+
+// writeStatsdMetrics writes the metrics that put the method label into the statsd metric name,
+// the statsd metrics drop the label values.
+func (g *instrumenting) writeStatsdMetrics(metricsPkg string) {
+	providerType := statsdProviderType(g.i, g.o.Instrumenting.Backend)
+
+	g.W("func statsdMethodName(prefix, name string, labelValues []string) string {\n")
+	g.W("for i := 0; i+1 < len(labelValues); i += 2 {\n")
+	g.W("if labelValues[i] == \"method\" {\nreturn prefix + labelValues[i+1] + \".\" + name\n}\n")
+	g.W("}\n")
+	g.W("return prefix + name\n")
+	g.W("}\n\n")
+
+	g.W("type statsdCounter struct {\nprovider %s\nprefix, name string\n}\n\n", providerType)
+	g.W("func (c statsdCounter) With(labelValues ...string) %s.Counter {\n", metricsPkg)
+	g.W("return c.provider.NewCounter(statsdMethodName(c.prefix, c.name, labelValues), 1)\n")
+	g.W("}\n\n")
+	g.W("func (c statsdCounter) Add(delta float64) {\n")
+	g.W("c.provider.NewCounter(c.prefix+c.name, 1).Add(delta)\n")
+	g.W("}\n\n")
+
+	// the timings are sent in milliseconds, the middleware observes seconds.
+	g.W("type statsdTiming struct {\nprovider %s\nprefix, name string\nlabelValues []string\n}\n\n", providerType)
+	g.W("func (h statsdTiming) With(labelValues ...string) %s.Histogram {\n", metricsPkg)
+	g.W("h.labelValues = labelValues\n")
+	g.W("return h\n")
+	g.W("}\n\n")
+	g.W("func (h statsdTiming) Observe(value float64) {\n")
+	g.W("h.provider.NewTiming(statsdMethodName(h.prefix, h.name, h.labelValues), 1).Observe(value * 1000)\n")
+	g.W("}\n\n")
+
+	g.W("type statsdGauge struct {\nprovider %s\nprefix, name string\n}\n\n", providerType)
+	g.W("func (g statsdGauge) With(labelValues ...string) %s.Gauge {\n", metricsPkg)
+	g.W("return g.provider.NewGauge(statsdMethodName(g.prefix, g.name, labelValues))\n")
+	g.W("}\n\n")
+	g.W("func (g statsdGauge) Set(value float64) {\n")
+	g.W("g.provider.NewGauge(g.prefix + g.name).Set(value)\n")
+	g.W("}\n\n")
+	g.W("func (g statsdGauge) Add(delta float64) {\n")
+	g.W("g.provider.NewGauge(g.prefix + g.name).Add(delta)\n")
+	g.W("}\n\n")
+}
+
+func (g *instrumenting) writeExpvarDefaults() {
+	expvarPkg := g.i.Import("expvar", "github.com/go-kit/kit/metrics/expvar")
+
+	name := func(name string) string {
+		return strconv.Quote(g.metricName(name, "_"))
+	}
+
+	g.W("if requestCount == nil {\n")
+	g.W("requestCount = %s.NewCounter(%s)\n", expvarPkg, name(g.requestCountName()))
+	g.W("}\n")
+
+	g.W("if requestLatency == nil {\n")
+	g.W("requestLatency = %s.NewHistogram(%s, 50)\n", expvarPkg, name(g.requestLatencyName()))
+	g.W("}\n")
+
+	if g.o.Instrumenting.LegacyNames {
+		return
+	}
+	g.W("if requestsInFlight == nil {\n")
+	g.W("requestsInFlight = %s.NewGauge(%s)\n", expvarPkg, name("requests_in_flight"))
+	g.W("}\n")
+}
+
+func (g *instrumenting) writeDiscardDefaults() {
+	discardPkg := g.i.Import("discard", "github.com/go-kit/kit/metrics/discard")
+
+	g.W("if requestCount == nil {\n")
+	g.W("requestCount = %s.NewCounter()\n", discardPkg)
+	g.W("}\n")
+
+	g.W("if requestLatency == nil {\n")
+	g.W("requestLatency = %s.NewHistogram()\n", discardPkg)
+	g.W("}\n")
+
+	if g.o.Instrumenting.LegacyNames {
+		return
+	}
+	g.W("if requestsInFlight == nil {\n")
+	g.W("requestsInFlight = %s.NewGauge()\n", discardPkg)
+	g.W("}\n")
+}
+
+func (g *instrumenting) metricName(name, sep string) string {
+	var parts []string
+	for _, part := range []string{g.o.Instrumenting.Namespace, g.o.Instrumenting.Subsystem, name} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+func (g *instrumenting) requestCountName() string {
+	if g.o.Instrumenting.LegacyNames {
+		return "request_count"
+	}
+	return "requests_total"
+}
+
+func (g *instrumenting) requestLatencyName() string {
+	if g.o.Instrumenting.LegacyNames {
+		return "request_latency_microseconds"
+	}
+	return "request_duration_seconds"
+}
+
+func (g *instrumenting) labelNames() []string {
+	names := make([]string, 0, len(g.o.Instrumenting.Labels))
+	for name := range g.o.Instrumenting.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (g *instrumenting) writeErrorLabel() {
+	errors := make([]*model.ErrorHTTPTransportOption, 0, len(g.o.Transport.Errors))
+	for _, e := range g.o.Transport.Errors {
+		errors = append(errors, e)
+	}
+	sort.Slice(errors, func(i, j int) bool {
+		return errors[i].Named.Obj().Name() < errors[j].Named.Obj().Name()
+	})
+
+	g.W("func instrumentingErrorLabel(err error) string {\n")
+	g.W("if err == nil {\nreturn \"none\"\n}\n")
+	if len(errors) > 0 {
+		g.W("switch err.(type) {\n")
+		for _, e := range errors {
+			typeName := stdtypes.TypeString(e.Named, g.i.QualifyPkg)
+			if e.IsPointer {
+				typeName = "*" + typeName
+			}
+			g.W("case %s:\nreturn %s\n", typeName, strconv.Quote(e.Named.Obj().Name()))
+		}
+		g.W("}\n")
+	}
+	g.W("return \"error\"\n")
+	g.W("}\n\n")
 }
 
 func (g *instrumenting) PkgName() string {
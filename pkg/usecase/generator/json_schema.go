@@ -0,0 +1,369 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	stdtypes "go/types"
+	"strconv"
+	stdstrings "strings"
+
+	"github.com/fatih/structtag"
+	"github.com/iancoleman/strcase"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/swipe-io/swipe/pkg/domain/model"
+	"github.com/swipe-io/swipe/pkg/openapi"
+)
+
+// jsonSchemaBuilder makes the JSON schemas of Go types, the named struct types are added
+// to the components once and referenced with $ref.
+type jsonSchemaBuilder struct {
+	components openapi.Schemas
+	comments   *typeutil.Map
+	enumOpts   *typeutil.Map
+	names      map[*stdtypes.TypeName]string
+}
+
+func newJSONSchemaBuilder(components openapi.Schemas, info model.GenerateInfo) *jsonSchemaBuilder {
+	return &jsonSchemaBuilder{
+		components: components,
+		comments:   info.CommentMap,
+		enumOpts:   info.EnumOptions,
+		names:      map[*stdtypes.TypeName]string{},
+	}
+}
+
+// enum makes the schema of the type with the Enum option, the values are listed with the const names.
+// Only the types with the Enum option are validated by the servers, so the other const groups are not listed.
+func (b *jsonSchemaBuilder) enum(named *stdtypes.Named, o model.EnumOption) *openapi.Schema {
+	if !o.JSONValue {
+		// the type is encoded with MarshalText, so the values are the text forms.
+		return enumTextSchema(o)
+	}
+	schema := b.schema(named.Underlying())
+	schema.Enum = nil
+	schema.EnumVarNames = nil
+	for _, e := range o.Enums {
+		var value interface{} = e.Value
+		if schema.Type == "integer" {
+			if v, err := strconv.ParseInt(e.Value, 10, 64); err == nil {
+				value = v
+			}
+		}
+		schema.Enum = append(schema.Enum, value)
+		schema.EnumVarNames = append(schema.EnumVarNames, e.Name)
+	}
+	if len(schema.Enum) > 0 {
+		schema.Example = schema.Enum[0]
+	}
+	return schema
+}
+
+// paramSchema makes the schema of the path, query or header parameter,
+// the enum types with the Enum option are always bound from the text form.
+func (b *jsonSchemaBuilder) paramSchema(t stdtypes.Type) *openapi.Schema {
+	if o, ok := enumOption(b.enumOpts, t); ok {
+		return enumTextSchema(o)
+	}
+	return b.schema(t)
+}
+
+func enumTextSchema(o model.EnumOption) *openapi.Schema {
+	schema := &openapi.Schema{Type: "string"}
+	for _, e := range o.Enums {
+		schema.Enum = append(schema.Enum, o.Text(e))
+		schema.EnumVarNames = append(schema.EnumVarNames, e.Name)
+	}
+	if len(schema.Enum) > 0 {
+		schema.Example = schema.Enum[0]
+	}
+	return schema
+}
+
+func (b *jsonSchemaBuilder) ref(named *stdtypes.Named) *openapi.Schema {
+	name, ok := b.names[named.Obj()]
+	if !ok {
+		name = named.Obj().Name()
+		if _, exists := b.components[name]; exists && named.Obj().Pkg() != nil {
+			name = strcase.ToCamel(named.Obj().Pkg().Name()) + name
+		}
+		b.names[named.Obj()] = name
+
+		// the component is added before the fields are walked, so recursive types refer to it.
+		schema := &openapi.Schema{}
+		b.components[name] = schema
+		*schema = *b.schema(named.Underlying())
+	}
+	return &openapi.Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (b *jsonSchemaBuilder) schema(t stdtypes.Type) (schema *openapi.Schema) {
+	schema = &openapi.Schema{}
+	switch v := t.(type) {
+	case *stdtypes.Pointer:
+		schema = b.schema(v.Elem())
+		if schema.Ref != "" {
+			// siblings of $ref are ignored, so the reference is wrapped to mark it nullable.
+			return &openapi.Schema{Nullable: true, AllOf: []openapi.Schema{*schema}}
+		}
+		schema.Nullable = true
+		return
+	case *stdtypes.Interface:
+		// TODO: not anyOf works in SwaggerUI, so the object type is used to display the field.
+		schema.Type = "object"
+		schema.Description = "Can be any value - string, number, boolean, array or object."
+		schema.Properties = openapi.Properties{}
+		schema.Example = json.RawMessage("null")
+		schema.AnyOf = []openapi.Schema{
+			{Type: "string", Example: "abc"},
+			{Type: "integer", Example: 1},
+			{Type: "number", Format: "float", Example: 1.11},
+			{Type: "boolean", Example: true},
+			{Type: "array"},
+			{Type: "object"},
+		}
+	case *stdtypes.Map:
+		schema.Type = "object"
+		schema.AdditionalProperties = b.schema(v.Elem())
+	case *stdtypes.Slice:
+		if vv, ok := v.Elem().(*stdtypes.Basic); ok && vv.Kind() == stdtypes.Byte {
+			schema.Type = "string"
+			schema.Format = "byte"
+			schema.Example = "U3dhZ2dlciByb2Nrcw=="
+		} else {
+			schema.Type = "array"
+			schema.Items = b.schema(v.Elem())
+		}
+	case *stdtypes.Basic:
+		switch v.Kind() {
+		case stdtypes.String:
+			schema.Type = "string"
+			schema.Format = "string"
+			schema.Example = "abc"
+		case stdtypes.Bool:
+			schema.Type = "boolean"
+			schema.Example = true
+		case stdtypes.Int,
+			stdtypes.Uint,
+			stdtypes.Uint8,
+			stdtypes.Uint16,
+			stdtypes.Int8,
+			stdtypes.Int16:
+			schema.Type = "integer"
+			schema.Example = 1
+		case stdtypes.Uint32, stdtypes.Int32:
+			schema.Type = "integer"
+			schema.Format = "int32"
+			schema.Example = 1
+		case stdtypes.Uint64, stdtypes.Int64:
+			schema.Type = "integer"
+			schema.Format = "int64"
+			schema.Example = 1
+		case stdtypes.Float32, stdtypes.Float64:
+			schema.Type = "number"
+			schema.Format = "float"
+			schema.Example = 1.11
+		}
+	case *stdtypes.Struct:
+		schema.Type = "object"
+		schema.Properties = openapi.Properties{}
+		b.populateStruct(schema, v)
+	case *stdtypes.Named:
+		switch stdtypes.TypeString(v, nil) {
+		case "encoding/json.RawMessage":
+			schema.Type = "object"
+			schema.Properties = openapi.Properties{}
+			return
+		case "time.Time":
+			schema.Type = "string"
+			schema.Format = "date-time"
+			schema.Example = "1985-04-02T01:30:00.00Z"
+			return
+		case "github.com/pborman/uuid.UUID",
+			"github.com/google/uuid.UUID":
+			schema.Type = "string"
+			schema.Format = "uuid"
+			schema.Example = "d5c02d83-6fbc-4dd7-8416-9f85ed80de46"
+			return
+		}
+		if o, ok := enumOption(b.enumOpts, v); ok && len(o.Enums) > 0 {
+			return b.enum(v, o)
+		}
+		if _, ok := v.Underlying().(*stdtypes.Struct); ok {
+			return b.ref(v)
+		}
+		return b.schema(v.Obj().Type().Underlying())
+	}
+	return
+}
+
+// populateStruct adds the fields of the struct as they are encoded by encoding/json.
+func (b *jsonSchemaBuilder) populateStruct(schema *openapi.Schema, st *stdtypes.Struct) {
+	var comments map[string]string
+	if b.comments != nil {
+		comments, _ = b.comments.At(st).(map[string]string)
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+
+		var (
+			name      = f.Name()
+			omitempty bool
+			asString  bool
+			tags, _   = structtag.Parse(st.Tag(i))
+		)
+		if tags != nil {
+			if tag, err := tags.Get("json"); err == nil {
+				if tag.Name == "-" && len(tag.Options) == 0 {
+					continue
+				}
+				if tag.Name != "" {
+					name = tag.Name
+				}
+				omitempty = tag.HasOption("omitempty")
+				asString = tag.HasOption("string")
+			}
+		}
+
+		if f.Embedded() && name == f.Name() {
+			t := f.Type()
+			if ptr, ok := t.(*stdtypes.Pointer); ok {
+				t = ptr.Elem()
+			}
+			if est, ok := t.Underlying().(*stdtypes.Struct); ok {
+				b.populateStruct(schema, est)
+				continue
+			}
+		}
+		if !f.Exported() {
+			continue
+		}
+
+		fieldSchema := b.schema(f.Type())
+		if asString {
+			fieldSchema = stringEncodedSchema(fieldSchema)
+		}
+
+		if comment := stdstrings.TrimSpace(comments[f.Name()]); comment != "" {
+			if fieldSchema.Ref != "" {
+				fieldSchema = &openapi.Schema{AllOf: []openapi.Schema{*fieldSchema}}
+			}
+			fieldSchema.Description = comment
+		}
+
+		required := !omitempty
+		if _, ok := f.Type().(*stdtypes.Pointer); ok {
+			required = false
+		}
+		if tags != nil {
+			if tag, err := tags.Get("example"); err == nil {
+				fieldSchema.Example = parseSchemaExample(fieldSchema, tag.Value())
+			}
+			if tag, err := tags.Get("validate"); err == nil {
+				for _, rule := range append([]string{tag.Name}, tag.Options...) {
+					if rule == "required" {
+						required = true
+						continue
+					}
+					applyValidateRule(fieldSchema, rule)
+				}
+			}
+		}
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = fieldSchema
+	}
+}
+
+// stringEncodedSchema returns the schema of the value encoded with the json ",string" option.
+func stringEncodedSchema(schema *openapi.Schema) *openapi.Schema {
+	switch schema.Type {
+	case "integer", "number", "boolean":
+		s := &openapi.Schema{Type: "string", Nullable: schema.Nullable}
+		if schema.Example != nil {
+			s.Example = fmt.Sprint(schema.Example)
+		}
+		return s
+	}
+	return schema
+}
+
+func parseSchemaExample(schema *openapi.Schema, value string) interface{} {
+	switch schema.Type {
+	case "integer":
+		if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return v
+		}
+	case "number":
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			return v
+		}
+	case "boolean":
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	case "array", "object":
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err == nil {
+			return v
+		}
+	}
+	return value
+}
+
+// applyValidateRule maps the go-playground/validator rule to the schema constraint.
+func applyValidateRule(schema *openapi.Schema, rule string) {
+	key, value := rule, ""
+	if idx := stdstrings.Index(rule, "="); idx != -1 {
+		key, value = rule[:idx], rule[idx+1:]
+	}
+	switch key {
+	case "email", "uuid", "url", "uri", "hostname", "ipv4", "ipv6":
+		if key == "url" {
+			key = "uri"
+		}
+		schema.Format = key
+	case "oneof":
+		if schema.Type == "string" {
+			for _, v := range stdstrings.Fields(value) {
+				schema.Enum = append(schema.Enum, v)
+			}
+			if len(schema.Enum) > 0 {
+				schema.Example = schema.Enum[0]
+			}
+		}
+	case "min", "max", "len", "gte", "lte":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return
+		}
+		isMin := key == "min" || key == "len" || key == "gte"
+		isMax := key == "max" || key == "len" || key == "lte"
+		switch schema.Type {
+		case "integer", "number":
+			if isMin {
+				schema.Minimum = &n
+			}
+			if isMax {
+				schema.Maximum = &n
+			}
+		case "string":
+			l := uint64(n)
+			if isMin {
+				schema.MinLength = &l
+			}
+			if isMax {
+				schema.MaxLength = &l
+			}
+		case "array":
+			l := uint64(n)
+			if isMin {
+				schema.MinItems = &l
+			}
+			if isMax {
+				schema.MaxItems = &l
+			}
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	stdtypes "go/types"
+	"reflect"
+	"testing"
+
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/swipe-io/swipe/pkg/domain/model"
+	"github.com/swipe-io/swipe/pkg/openapi"
+)
+
+// checkTestPackage type-checks the source of the package without the imports.
+func checkTestPackage(t *testing.T, path, src string) *stdtypes.Package {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "src.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := (&stdtypes.Config{}).Check(path, fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
+
+func TestJSONSchemaBuilderSchema(t *testing.T) {
+	pkg := checkTestPackage(t, "example.com/api", `package api
+
+type User struct {
+	Name   string
+	Friend *User
+	Groups map[string]Group
+	Avatar []byte
+	Age    *int
+}
+
+type Group struct {
+	ID int64
+}
+`)
+	other := checkTestPackage(t, "example.com/billing", `package billing
+
+type Group struct {
+	Total float64
+}
+`)
+
+	components := openapi.Schemas{}
+	b := newJSONSchemaBuilder(components, model.GenerateInfo{})
+
+	tests := []struct {
+		name string
+		t    stdtypes.Type
+		want *openapi.Schema
+	}{
+		{
+			name: "struct",
+			t:    pkg.Scope().Lookup("User").Type(),
+			want: &openapi.Schema{Ref: "#/components/schemas/User"},
+		},
+		{
+			name: "same type",
+			t:    pkg.Scope().Lookup("Group").Type(),
+			want: &openapi.Schema{Ref: "#/components/schemas/Group"},
+		},
+		{
+			name: "same name",
+			t:    other.Scope().Lookup("Group").Type(),
+			want: &openapi.Schema{Ref: "#/components/schemas/BillingGroup"},
+		},
+		{
+			name: "nullable struct",
+			t:    stdtypes.NewPointer(pkg.Scope().Lookup("Group").Type()),
+			want: &openapi.Schema{Nullable: true, AllOf: []openapi.Schema{{Ref: "#/components/schemas/Group"}}},
+		},
+		{
+			name: "slice",
+			t:    stdtypes.NewSlice(stdtypes.Typ[stdtypes.Int32]),
+			want: &openapi.Schema{Type: "array", Items: &openapi.Schema{Type: "integer", Format: "int32", Example: 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.schema(tt.t); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	user := components["User"]
+	if user == nil {
+		t.Fatal("the User component is not added")
+	}
+	wantUser := &openapi.Schema{
+		Type:     "object",
+		Required: []string{"Name", "Groups", "Avatar"},
+		Properties: openapi.Properties{
+			"Name":   {Type: "string", Format: "string", Example: "abc"},
+			"Friend": {Nullable: true, AllOf: []openapi.Schema{{Ref: "#/components/schemas/User"}}},
+			"Groups": {Type: "object", AdditionalProperties: &openapi.Schema{Ref: "#/components/schemas/Group"}},
+			"Avatar": {Type: "string", Format: "byte", Example: "U3dhZ2dlciByb2Nrcw=="},
+			"Age":    {Type: "integer", Nullable: true, Example: 1},
+		},
+	}
+	if !reflect.DeepEqual(user, wantUser) {
+		t.Fatalf("User: got %+v, want %+v", user, wantUser)
+	}
+	if len(components) != 3 {
+		t.Fatalf("components: got %d, want 3", len(components))
+	}
+}
+
+func TestJSONSchemaBuilderStructTags(t *testing.T) {
+	pkg := checkTestPackage(t, "example.com/api", `package api
+
+type Base struct {
+	ID int
+}
+
+type User struct {
+	Base
+	Name     string  `+"`json:\"name\" validate:\"required,min=1,max=32\" example:\"Rex\"`"+`
+	Email    string  `+"`json:\"email,omitempty\" validate:\"email\"`"+`
+	Role     string  `+"`json:\"role,omitempty\" validate:\"oneof=admin user\"`"+`
+	Score    int     `+"`json:\"score,string\"`"+`
+	Limit    int     `+"`json:\"limit\" validate:\"gte=1,lte=100\" example:\"10\"`"+`
+	Parent   *User   `+"`json:\"parent\"`"+`
+	Internal string  `+"`json:\"-\"`"+`
+	secret   string
+}
+`)
+	user := pkg.Scope().Lookup("User").Type().(*stdtypes.Named)
+	comments := new(typeutil.Map)
+	comments.Set(user.Underlying(), map[string]string{"Name": "The name of the user.", "Parent": "The parent user."})
+
+	components := openapi.Schemas{}
+	newJSONSchemaBuilder(components, model.GenerateInfo{CommentMap: comments}).schema(user)
+
+	min, max := uint64(1), uint64(32)
+	gte, lte := float64(1), float64(100)
+	tests := []struct {
+		name string
+		want *openapi.Schema
+	}{
+		{name: "ID", want: &openapi.Schema{Type: "integer", Example: 1}},
+		{name: "name", want: &openapi.Schema{Type: "string", Format: "string", Example: "Rex", Description: "The name of the user.", MinLength: &min, MaxLength: &max}},
+		{name: "email", want: &openapi.Schema{Type: "string", Format: "email", Example: "abc"}},
+		{name: "role", want: &openapi.Schema{Type: "string", Format: "string", Enum: []interface{}{"admin", "user"}, Example: "admin"}},
+		{name: "score", want: &openapi.Schema{Type: "string", Example: "1"}},
+		{name: "limit", want: &openapi.Schema{Type: "integer", Example: int64(10), Minimum: &gte, Maximum: &lte}},
+		{name: "parent", want: &openapi.Schema{Description: "The parent user.", Nullable: true, AllOf: []openapi.Schema{{Ref: "#/components/schemas/User"}}}},
+	}
+	schema := components["User"]
+	if len(schema.Properties) != len(tests) {
+		t.Fatalf("properties: got %d, want %d", len(schema.Properties), len(tests))
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := schema.Properties[tt.name]; !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+	if want := []string{"ID", "name", "score", "limit"}; !reflect.DeepEqual(schema.Required, want) {
+		t.Fatalf("required: got %v, want %v", schema.Required, want)
+	}
+}
+
+// TestJSONSchemaBuilderEnum checks only the types with the Enum option are listed as enums.
+func TestJSONSchemaBuilderEnum(t *testing.T) {
+	pkg := checkTestPackage(t, "example.com/api", `package api
+
+type Status int
+
+const (
+	StatusActive Status = iota + 1
+	StatusBlocked
+)
+
+type Flag int
+
+const (
+	FlagRead Flag = 1 << iota
+	FlagWrite
+)
+`)
+	status := pkg.Scope().Lookup("Status").Type().(*stdtypes.Named)
+	flag := pkg.Scope().Lookup("Flag").Type().(*stdtypes.Named)
+
+	enums := new(typeutil.Map)
+	enums.Set(status, []model.Enum{{Name: "StatusActive", Value: "1"}, {Name: "StatusBlocked", Value: "2"}})
+	enums.Set(flag, []model.Enum{{Name: "FlagRead", Value: "1"}, {Name: "FlagWrite", Value: "2"}})
+	enumOpts := new(typeutil.Map)
+	enumOpts.Set(status, model.EnumOption{Type: status, Enums: enums.At(status).([]model.Enum), TrimPrefix: "Status"})
+
+	b := newJSONSchemaBuilder(openapi.Schemas{}, model.GenerateInfo{Enums: enums, EnumOptions: enumOpts})
+
+	want := &openapi.Schema{Type: "string", Enum: []interface{}{"Active", "Blocked"}, EnumVarNames: []string{"StatusActive", "StatusBlocked"}, Example: "Active"}
+	if got := b.schema(status); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Status: got %+v, want %+v", got, want)
+	}
+	if got := b.schema(flag); got.Enum != nil || got.Type != "integer" {
+		t.Fatalf("Flag: got %+v, want the integer without the enum", got)
+	}
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/swipe-io/swipe/pkg/domain/model"
 	"github.com/swipe-io/swipe/pkg/importer"
+	"github.com/swipe-io/swipe/pkg/strings"
+	"github.com/swipe-io/swipe/pkg/types"
 	"github.com/swipe-io/swipe/pkg/writer"
 )
 
@@ -24,7 +26,6 @@ func (g *jsonRPCGoClient) Prepare(ctx context.Context) error {
 }
 
 func (g *jsonRPCGoClient) Process(ctx context.Context) error {
-	clientType := "client" + g.o.ID
 	typeStr := stdtypes.TypeString(g.o.Type, g.i.QualifyPkg)
 
 	g.W("func NewClient%s%s(tgt string", g.o.Transport.Prefix, g.o.ID)
@@ -33,41 +34,53 @@ func (g *jsonRPCGoClient) Process(ctx context.Context) error {
 
 	g.W(") (%s, error) {\n", typeStr)
 
-	g.W("c := &%s{}\n", clientType)
-
-	g.W("for _, o := range opts {\n")
-	g.W("o(c)\n")
-	g.W("}\n")
+	g.writeClientInit()
 
 	transportOpt := g.o.Transport
 
 	var (
 		jsonrpcPkg string
-		contextPkg string
-		ffjsonPkg  string
-		jsonPkg    string
-		fmtPkg     string
 		urlPkg     string
 		netPkg     string
 		stringsPkg string
 	)
 
 	if len(g.o.Methods) > 0 {
-		if transportOpt.FastHTTP {
-			jsonrpcPkg = g.i.Import("jsonrpc", "github.com/l-vitaly/go-kit/transport/fasthttp/jsonrpc")
-		} else {
-			jsonrpcPkg = g.i.Import("jsonrpc", "github.com/l-vitaly/go-kit/transport/http/jsonrpc")
-		}
+		jsonrpcPkg = g.jsonrpcPkg()
 		urlPkg = g.i.Import("url", "net/url")
-		contextPkg = g.i.Import("context", "context")
-		ffjsonPkg = g.i.Import("ffjson", "github.com/pquerna/ffjson/ffjson")
-		jsonPkg = g.i.Import("json", "encoding/json")
-		fmtPkg = g.i.Import("fmt", "fmt")
 		netPkg = g.i.Import("net", "net")
 		stringsPkg = g.i.Import("strings", "strings")
 	}
 
-	if len(g.o.Methods) > 0 {
+	if !transportOpt.FastHTTP && len(g.o.Methods) > 0 {
+		g.W("if c.httpClient != nil {\n")
+		g.W("c.genericClientOption = append([]%[1]s.ClientOption{%[1]s.SetClient(c.httpClient)}, c.genericClientOption...)\n", jsonrpcPkg)
+		g.W("}\n")
+	}
+
+	if g.o.Logging.Enable && len(g.o.Methods) > 0 {
+		g.W("c.genericClientOption = append([]%[1]s.ClientOption{%[1]s.ClientBefore(requestIDContextToHTTP)}, c.genericClientOption...)\n", jsonrpcPkg)
+	}
+
+	if g.o.Tracing.Enable && len(g.o.Methods) > 0 {
+		g.W("c.genericClientOption = append([]%[1]s.ClientOption{%[1]s.ClientBefore(tracingContextToHTTP)}, c.genericClientOption...)\n", jsonrpcPkg)
+	}
+
+	if transportOpt.Auth.Enable && len(g.o.Methods) > 0 {
+		if transportOpt.FastHTTP {
+			g.W("c.genericClientOption = append([]%[1]s.ClientOption{%[1]s.ClientBefore(authContextToFastHTTP)}, c.genericClientOption...)\n", jsonrpcPkg)
+		} else {
+			kitjwtPkg := g.i.Import("jwt", "github.com/go-kit/kit/auth/jwt")
+			g.W("c.genericClientOption = append([]%[1]s.ClientOption{%[1]s.ClientBefore(%[2]s.ContextToHTTP())}, c.genericClientOption...)\n", jsonrpcPkg, kitjwtPkg)
+		}
+	}
+
+	var hasHTTPMethods bool
+	for _, m := range g.o.Methods {
+		hasHTTPMethods = hasHTTPMethods || m.StreamElem == nil
+	}
+
+	if hasHTTPMethods {
 		g.W("if %s.HasPrefix(tgt, \"[\") {\n", stringsPkg)
 		g.W("host, port, err := %s.SplitHostPort(tgt)\n", netPkg)
 		g.WriteCheckErr(func() {
@@ -86,83 +99,591 @@ func (g *jsonRPCGoClient) Process(ctx context.Context) error {
 	}
 
 	for _, m := range g.o.Methods {
-		mopt := transportOpt.MethodOptions[m.Name]
+		if m.StreamElem != nil {
+			g.W("c.%sEndpoint = func(%s.Context, interface{}) (interface{}, error) {\n", m.LcName, g.i.Import("context", "context"))
+			g.W("return nil, %s.New(\"method %s is supported only by the WebSocket client\")\n", g.i.Import("errors", "errors"), m.LcName)
+			g.W("}\n")
+			continue
+		}
+
+		if isNotificationMethod(transportOpt, m) {
+			g.W("// the notification is sent without the id by the client of %sHTTPClient, the client options are not used.\n", g.o.ID)
+			g.W("c.%sEndpoint = func(ctx %s.Context, request interface{}) (interface{}, error) {\n", m.LcName, g.i.Import("context", "context"))
+			g.W("params, err := ")
+			g.writeRequestEncoder(m)
+			g.W("(ctx, request)\n")
+			g.W("if err != nil {\n")
+			g.W("return nil, err\n")
+			g.W("}\n")
+			g.W("if err := c.notifyJSONRPC(ctx, u.String(), %s, params); err != nil {\n", strconv.Quote(m.LcName))
+			g.W("return nil, err\n")
+			g.W("}\n")
+			switch {
+			case len(m.Results) == 0:
+				g.W("return nil, nil\n")
+			case m.ResultsNamed:
+				g.W("return %sResponse%s{}, nil\n", m.LcName, g.o.ID)
+			default:
+				g.W("var resp %s\n", stdtypes.TypeString(m.Results[0].Type(), g.i.QualifyPkg))
+				g.W("return resp, nil\n")
+			}
+			g.W("}\n")
+
+			g.writeEndpointMiddlewares(m)
+			continue
+		}
 
 		g.W("c.%[1]sClientOption = append(\nc.%[1]sClientOption,\n", m.LcName)
 
 		g.W("%s.ClientRequestEncoder(", jsonrpcPkg)
-		g.W("func(_ %s.Context, obj interface{}) (%s.RawMessage, error) {\n", contextPkg, jsonPkg)
+		g.writeRequestEncoder(m)
+		g.W("),\n")
+
+		g.W("%s.ClientResponseDecoder(", jsonrpcPkg)
+		g.writeResponseDecoder(m)
+		g.W("),\n")
+
+		g.W(")\n")
+
+		g.W("c.%sEndpoint = %s.NewClient(\n", m.LcName, jsonrpcPkg)
+		g.W("u,\n")
+		g.W("%s,\n", strconv.Quote(m.LcName))
+
+		g.W("append(c.genericClientOption, c.%sClientOption...)...,\n", m.LcName)
+
+		g.W(").Endpoint()\n")
+
+		g.writeEndpointMiddlewares(m)
+	}
+
+	g.W("return c, nil\n")
+	g.W("}\n\n")
 
-		if len(m.Params) > 0 {
-			g.W("req, ok := obj.(%sRequest%s)\n", m.LcName, g.o.ID)
+	if !transportOpt.FastHTTP {
+		g.writeBatch()
+	}
+
+	if transportOpt.JsonRPC.WebSocket.Enable {
+		g.writeWebSocketClient()
+	}
+	return nil
+}
+
+func (g *jsonRPCGoClient) writeWebSocketClient() {
+	typeStr := stdtypes.TypeString(g.o.Type, g.i.QualifyPkg)
+	contextPkg := g.i.Import("context", "context")
+	urlPkg := g.i.Import("url", "net/url")
+
+	g.W("// NewWebSocketClient%s%s returns a client that calls the methods over a single WebSocket connection,\n", g.o.Transport.Prefix, g.o.ID)
+	g.W("// the connection is dialed on the first call, redialed after a failure and closed when ctx is done.\n")
+	g.W("// The path of tgt defaults to %s.\n", g.o.Transport.JsonRPC.WebSocket.Path)
+	g.W("// The events of a stream are buffered, the events are dropped while the buffer of a slow consumer is full.\n")
+	g.W("// When the stream context is done, the server is asked to cancel the stream.\n")
+	g.W("func NewWebSocketClient%s%s(ctx %s.Context, tgt string, opts ...%sClientOption) (%s, error) {\n", g.o.Transport.Prefix, g.o.ID, contextPkg, g.o.ID, typeStr)
+
+	g.writeClientInit()
+
+	g.W("u, err := %s.Parse(tgt)\n", urlPkg)
+	g.WriteCheckErr(func() {
+		g.W("return nil, err")
+	})
+	g.W("switch u.Scheme {\n")
+	g.W("case \"http\":\n")
+	g.W("u.Scheme = \"ws\"\n")
+	g.W("case \"https\", \"\":\n")
+	g.W("u.Scheme = \"wss\"\n")
+	g.W("}\n")
+	g.W("if u.Path == \"\" {\n")
+	g.W("u.Path = %s\n", strconv.Quote(g.o.Transport.JsonRPC.WebSocket.Path))
+	g.W("}\n")
+
+	g.W("ws := newWebSocketClient(ctx, u.String())\n")
+
+	if g.o.Transport.Auth.Enable {
+		httpPkg := g.i.Import("http", "net/http")
+
+		g.W("ws.header = func(ctx %s.Context) (%s.Header, error) {\n", contextPkg, httpPkg)
+		g.W("header := %s.Header{}\n", httpPkg)
+		g.W("if c.tokenProvider != nil {\n")
+		g.W("token, err := c.tokenProvider(ctx)\n")
+		g.W("if err != nil {\n")
+		g.W("return nil, err\n")
+		g.W("}\n")
+		g.W("header.Set(\"Authorization\", \"Bearer \"+token)\n")
+		g.W("}\n")
+		g.W("return header, nil\n")
+		g.W("}\n")
+	}
+
+	for _, m := range g.o.Methods {
+		g.W("c.%sEndpoint = func(ctx %s.Context, request interface{}) (interface{}, error) {\n", m.LcName, contextPkg)
+		g.W("params, err := ")
+		g.writeRequestEncoder(m)
+		g.W("(ctx, request)\n")
+		g.W("if err != nil {\n")
+		g.W("return nil, err\n")
+		g.W("}\n")
+
+		if m.StreamElem != nil {
+			ffjsonPkg := g.i.Import("ffjson", "github.com/pquerna/ffjson/ffjson")
+			elemType := stdtypes.TypeString(m.StreamElem, g.i.QualifyPkg)
+
+			g.W("stream := &webSocketStream{events: make(chan []byte, webSocketStreamBuffer), done: make(chan struct{})}\n")
+			g.W("if _, err := ws.call(ctx, %s, params, stream); err != nil {\n", strconv.Quote(m.LcName))
+			g.W("return nil, err\n")
+			g.W("}\n")
+			g.W("events := make(chan %s)\n", elemType)
+			g.W("go func() {\n")
+			g.W("defer close(events)\n")
+			g.W("defer ws.unsubscribe(stream)\n")
+			g.W("for {\n")
+			g.W("select {\n")
+			g.W("case <-ctx.Done():\n")
+			g.W("return\n")
+			g.W("case data, ok := <-stream.events:\n")
 			g.W("if !ok {\n")
-			g.W("return nil, %s.Errorf(\"couldn't assert request as %sRequest%s, got %%T\", obj)\n", fmtPkg, m.LcName, g.o.ID)
+			g.W("return\n")
+			g.W("}\n")
+			g.W("var event %s\n", elemType)
+			g.W("if err := %s.Unmarshal(data, &event); err != nil {\n", ffjsonPkg)
+			g.W("continue\n")
+			g.W("}\n")
+			g.W("select {\n")
+			g.W("case events <- event:\n")
+			g.W("case <-ctx.Done():\n")
+			g.W("return\n")
 			g.W("}\n")
-			g.W("b, err := %s.Marshal(req)\n", ffjsonPkg)
-			g.W("if err != nil {\n")
-			g.W("return nil, %s.Errorf(\"couldn't marshal request %%T: %%s\", obj, err)\n", fmtPkg)
 			g.W("}\n")
-			g.W("return b, nil\n")
+			g.W("}\n")
+			g.W("}()\n")
+			g.W("return (%s)(events), nil\n", stdtypes.TypeString(m.Results[0].Type(), g.i.QualifyPkg))
 		} else {
-			g.W("return nil, nil\n")
+			jsonrpcPkg := g.jsonrpcPkg()
+
+			g.W("result, err := ws.call(ctx, %s, params, nil)\n", strconv.Quote(m.LcName))
+			g.W("if err != nil {\n")
+			g.W("return nil, err\n")
+			g.W("}\n")
+			g.W("return ")
+			g.writeResponseDecoder(m)
+			g.W("(ctx, %[1]s.Response{JSONRPC: %[1]s.Version, Result: result})\n", jsonrpcPkg)
 		}
-		g.W("}),\n")
+		g.W("}\n")
 
-		g.W("%s.ClientResponseDecoder(", jsonrpcPkg)
-		g.W("func(_ %s.Context, response %s.Response) (interface{}, error) {\n", contextPkg, jsonrpcPkg)
+		g.writeEndpointMiddlewares(m)
+	}
+
+	g.W("return c, nil\n")
+	g.W("}\n")
+}
+
+func (g *jsonRPCGoClient) writeBatch() {
+	contextPkg := g.i.Import("context", "context")
+	jsonPkg := g.i.Import("json", "encoding/json")
+	httpPkg := g.i.Import("http", "net/http")
+	bytesPkg := g.i.Import("bytes", "bytes")
+	errorsPkg := g.i.Import("errors", "errors")
+	jsonrpcPkg := g.jsonrpcPkg()
+	batchType := "Batch" + g.o.Transport.Prefix + g.o.ID
+
+	g.W("type jsonRPCBatchRequest struct {\n")
+	g.W("JSONRPC string `json:\"jsonrpc\"`\n")
+	g.W("Method string `json:\"method\"`\n")
+	g.W("Params %s.RawMessage `json:\"params,omitempty\"`\n", jsonPkg)
+	g.W("ID %s.RawMessage `json:\"id,omitempty\"`\n", jsonPkg)
+	g.W("}\n\n")
+
+	g.W("type jsonRPCBatchResponse struct {\n")
+	g.W("Result %s.RawMessage `json:\"result,omitempty\"`\n", jsonPkg)
+	g.W("Error *%s.Error `json:\"error,omitempty\"`\n", jsonrpcPkg)
+	g.W("ID %s.RawMessage `json:\"id\"`\n", jsonPkg)
+	g.W("}\n\n")
+
+	g.W("type jsonRPCBatchCall struct {\n")
+	g.W("request jsonRPCBatchRequest\n")
+	g.W("response jsonRPCBatchResponse\n")
+	g.W("sent bool\n")
+	g.W("err error\n")
+	g.W("}\n\n")
+
+	g.W("func (call *jsonRPCBatchCall) wait() (%s.Response, error) {\n", jsonrpcPkg)
+	g.W("if call.err != nil {\n")
+	g.W("return %s.Response{}, call.err\n", jsonrpcPkg)
+	g.W("}\n")
+	g.W("if !call.sent {\n")
+	g.W("return %s.Response{}, %s.New(\"the batch is not sent\")\n", jsonrpcPkg, errorsPkg)
+	g.W("}\n")
+	g.W("return %[1]s.Response{JSONRPC: %[1]s.Version, Result: call.response.Result, Error: call.response.Error}, nil\n", jsonrpcPkg)
+	g.W("}\n\n")
+
+	g.W("func (c *client%s) postJSONRPC(ctx %s.Context, u string, payload interface{}) ([]byte, error) {\n", g.o.ID, contextPkg)
+	g.W("data, err := %s.Marshal(payload)\n", jsonPkg)
+	g.W("if err != nil {\n")
+	g.W("return nil, err\n")
+	g.W("}\n")
+	g.W("req, err := %[1]s.NewRequest(%[1]s.MethodPost, u, %[2]s.NewReader(data))\n", httpPkg, bytesPkg)
+	g.W("if err != nil {\n")
+	g.W("return nil, err\n")
+	g.W("}\n")
+	g.W("req.Header.Set(\"Content-Type\", \"application/json; charset=utf-8\")\n")
+	if g.o.Logging.Enable {
+		g.W("ctx = requestIDContextToHTTP(ctx, req)\n")
+	}
+	if g.o.Tracing.Enable {
+		g.W("ctx = tracingContextToHTTP(ctx, req)\n")
+	}
+	if g.o.Transport.Auth.Enable {
+		g.W("ctx = %s.ContextToHTTP()(ctx, req)\n", g.i.Import("jwt", "github.com/go-kit/kit/auth/jwt"))
+	}
+	g.W("var client %s.HTTPClient = %s.DefaultClient\n", g.i.Import("http", "github.com/go-kit/kit/transport/http"), httpPkg)
+	g.W("if c.httpClient != nil {\n")
+	g.W("client = c.httpClient\n")
+	g.W("}\n")
+	g.W("resp, err := client.Do(req.WithContext(ctx))\n")
+	g.W("if err != nil {\n")
+	g.W("return nil, err\n")
+	g.W("}\n")
+	g.W("defer resp.Body.Close()\n")
+	g.W("if resp.StatusCode < 200 || resp.StatusCode > 299 {\n")
+	g.W("return nil, %s.Errorf(\"unexpected status code %%d\", resp.StatusCode)\n", g.i.Import("fmt", "fmt"))
+	g.W("}\n")
+	g.W("return %s.ReadAll(resp.Body)\n", g.i.Import("ioutil", "io/ioutil"))
+	g.W("}\n\n")
+
+	var hasNotification bool
+	for _, m := range g.o.Methods {
+		hasNotification = hasNotification || isNotificationMethod(g.o.Transport, m)
+	}
+	if hasNotification {
+		g.W("// notifyJSONRPC sends the request without the id, the server responds to the notification without the body.\n")
+		g.W("func (c *client%s) notifyJSONRPC(ctx %s.Context, u, method string, params %s.RawMessage) error {\n", g.o.ID, contextPkg, jsonPkg)
+		g.W("data, err := c.postJSONRPC(ctx, u, jsonRPCBatchRequest{JSONRPC: %s.Version, Method: method, Params: params})\n", jsonrpcPkg)
+		g.W("if err != nil {\n")
+		g.W("return err\n")
+		g.W("}\n")
+		g.W("if data = %s.TrimSpace(data); len(data) == 0 {\n", bytesPkg)
+		g.W("return nil\n")
+		g.W("}\n")
+		g.W("var response jsonRPCBatchResponse\n")
+		g.W("if err := %s.Unmarshal(data, &response); err != nil {\n", jsonPkg)
+		g.W("return err\n")
+		g.W("}\n")
 		g.W("if response.Error != nil {\n")
-		g.W("return nil, ErrorDecode(response.Error.Code, response.Error.Message, response.Error.Data)\n")
+		g.W("return ErrorDecode(response.Error.Code, response.Error.Message, response.Error.Data)\n")
 		g.W("}\n")
+		g.W("return nil\n")
+		g.W("}\n\n")
+	}
 
-		if len(m.Results) > 0 {
-			var responseType string
-			if m.ResultsNamed {
-				responseType = fmt.Sprintf("%sResponse%s", m.LcName, g.o.ID)
-			} else {
-				responseType = stdtypes.TypeString(m.Results[0].Type(), g.i.QualifyPkg)
+	g.W("// %s queues the calls and sends them as one JSON RPC batch request with the Do method,\n", batchType)
+	g.W("// each queued call returns the function that returns the typed result after Do.\n")
+	g.W("// Methods that are sent as notifications always return zero values.\n")
+	g.W("type %s struct {\n", batchType)
+	g.W("c *client%s\n", g.o.ID)
+	g.W("u string\n")
+	g.W("calls []*jsonRPCBatchCall\n")
+	g.W("}\n\n")
+
+	g.W("// NewBatch%s%s returns the batch. The batch is sent with the client of %sHTTPClient, http.DefaultClient by default,\n", g.o.Transport.Prefix, g.o.ID, g.o.ID)
+	g.W("// since the go-kit client sends a single request: the generic client options and the client options of methods are not used,\n")
+	g.W("// the token of the token provider, the request ID and the trace context are set from the context of Do.\n")
+	g.W("func NewBatch%s%s(tgt string, opts ...%sClientOption) (*%s, error) {\n", g.o.Transport.Prefix, g.o.ID, g.o.ID, batchType)
+	g.W("c := &client%s{}\n", g.o.ID)
+	g.W("for _, o := range opts {\n")
+	g.W("o(c)\n")
+	g.W("}\n")
+	g.W("u, err := %s.Parse(tgt)\n", g.i.Import("url", "net/url"))
+	g.WriteCheckErr(func() {
+		g.W("return nil, err")
+	})
+	g.W("if u.Scheme == \"\" {\n")
+	g.W("u.Scheme = \"https\"\n")
+	g.W("}\n")
+	g.W("return &%s{c: c, u: u.String()}, nil\n", batchType)
+	g.W("}\n\n")
+
+	g.W("func (b *%s) add(method string, params %s.RawMessage, err error, notification bool) *jsonRPCBatchCall {\n", batchType, jsonPkg)
+	g.W("call := &jsonRPCBatchCall{request: jsonRPCBatchRequest{JSONRPC: %s.Version, Method: method, Params: params}, err: err}\n", jsonrpcPkg)
+	g.W("if !notification {\n")
+	g.W("call.request.ID = %s.RawMessage(%s.Itoa(len(b.calls) + 1))\n", jsonPkg, g.i.Import("strconv", "strconv"))
+	g.W("}\n")
+	g.W("b.calls = append(b.calls, call)\n")
+	g.W("return call\n")
+	g.W("}\n\n")
+
+	g.W("// Do sends the queued calls and resets the queue.\n")
+	g.W("func (b *%s) Do(ctx %s.Context) error {\n", batchType, contextPkg)
+	g.W("calls := b.calls\n")
+	g.W("b.calls = nil\n")
+	g.W("var requests []jsonRPCBatchRequest\n")
+	g.W("var sent []*jsonRPCBatchCall\n")
+	g.W("pending := map[string]*jsonRPCBatchCall{}\n")
+	g.W("for _, call := range calls {\n")
+	g.W("if call.err != nil {\n")
+	g.W("continue\n")
+	g.W("}\n")
+	g.W("call.sent = true\n")
+	g.W("requests = append(requests, call.request)\n")
+	g.W("sent = append(sent, call)\n")
+	g.W("if call.request.ID != nil {\n")
+	g.W("pending[string(call.request.ID)] = call\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("if len(requests) == 0 {\n")
+	g.W("return nil\n")
+	g.W("}\n")
+	g.W("fail := func(err error) error {\n")
+	g.W("for _, call := range sent {\n")
+	g.W("call.err = err\n")
+	g.W("}\n")
+	g.W("return err\n")
+	g.W("}\n")
+	if g.o.Transport.Auth.Enable {
+		g.W("if b.c.tokenProvider != nil {\n")
+		g.W("token, err := b.c.tokenProvider(ctx)\n")
+		g.W("if err != nil {\n")
+		g.W("return fail(err)\n")
+		g.W("}\n")
+		g.W("ctx = %s.WithValue(ctx, %s.JWTTokenContextKey, token)\n", contextPkg, g.i.Import("jwt", "github.com/go-kit/kit/auth/jwt"))
+		g.W("}\n")
+	}
+	g.W("data, err := b.c.postJSONRPC(ctx, b.u, requests)\n")
+	g.W("if err != nil {\n")
+	g.W("return fail(err)\n")
+	g.W("}\n")
+	g.W("data = %s.TrimSpace(data)\n", bytesPkg)
+	g.W("var responses []jsonRPCBatchResponse\n")
+	g.W("if len(data) > 0 && data[0] == '[' {\n")
+	g.W("err = %s.Unmarshal(data, &responses)\n", jsonPkg)
+	g.W("} else if len(data) > 0 {\n")
+	g.W("responses = make([]jsonRPCBatchResponse, 1)\n")
+	g.W("err = %s.Unmarshal(data, &responses[0])\n", jsonPkg)
+	g.W("}\n")
+	g.W("if err != nil {\n")
+	g.W("return fail(err)\n")
+	g.W("}\n")
+	g.W("for _, response := range responses {\n")
+	g.W("if call, ok := pending[string(response.ID)]; ok {\n")
+	g.W("call.response = response\n")
+	g.W("delete(pending, string(response.ID))\n")
+	g.W("continue\n")
+	g.W("}\n")
+	g.W("if response.Error != nil {\n")
+	g.W("return fail(ErrorDecode(response.Error.Code, response.Error.Message, response.Error.Data))\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("for _, call := range pending {\n")
+	g.W("call.err = %s.New(\"the batch response does not contain the call result\")\n", errorsPkg)
+	g.W("}\n")
+	g.W("return nil\n")
+	g.W("}\n\n")
+
+	for _, m := range g.o.Methods {
+		if m.StreamElem != nil {
+			continue
+		}
+
+		var results []string
+		for _, r := range m.Results {
+			results = append(results, "", stdtypes.TypeString(r.Type(), g.i.QualifyPkg))
+		}
+		results = append(results, "", "error")
+
+		var resultType string
+		for i := 0; i < len(results); i += 2 {
+			if i > 0 {
+				resultType += ", "
 			}
+			resultType += results[i+1]
+		}
+		if len(results) > 2 {
+			resultType = "(" + resultType + ")"
+		}
 
-			if mopt.WrapResponse.Enable {
-				g.W("var resp struct {\n Data %s `json:\"%s\"`\n}\n", responseType, mopt.WrapResponse.Name)
+		var zeroValues string
+		for _, r := range m.Results {
+			zeroValues += types.ZeroValue(r.Type(), g.i.QualifyPkg) + ", "
+		}
+
+		g.WriteFunc(m.Name, "batch *"+batchType, types.NameTypeParams(m.Params, g.i.QualifyPkg, nil), []string{"", "func() " + resultType}, func() {
+			g.W("params, err := ")
+			g.writeRequestEncoder(m)
+			g.W("(%s.Background(), ", contextPkg)
+			if len(m.Params) > 0 {
+				g.W("%sRequest%s", m.LcName, g.o.ID)
+				g.WriteStructAssign(structKeyValue(m.Params, nil))
 			} else {
-				g.W("var resp %s\n", responseType)
+				g.W("nil")
 			}
+			g.W(")\n")
 
-			g.W("err := %s.Unmarshal(response.Result, &resp)\n", ffjsonPkg)
-			g.W("if err != nil {\n")
-			g.W("return nil, %s.Errorf(\"couldn't unmarshal body to %sResponse%s: %%s\", err)\n", fmtPkg, m.LcName, g.o.ID)
-			g.W("}\n")
+			g.W("call := batch.add(%s, params, err, %t)\n", strconv.Quote(m.LcName), isNotificationMethod(g.o.Transport, m))
 
-			if mopt.WrapResponse.Enable {
-				g.W("return resp.Data, nil\n")
+			g.W("return func() %s {\n", resultType)
+			if isNotificationMethod(g.o.Transport, m) {
+				g.W("_, err := call.wait()\n")
+				g.W("return %serr\n", zeroValues)
 			} else {
-				g.W("return resp, nil\n")
+				g.W("response, err := call.wait()\n")
+				g.W("if err != nil {\n")
+				g.W("return %serr\n", zeroValues)
+				g.W("}\n")
+				g.W("resp, err := ")
+				g.writeResponseDecoder(m)
+				g.W("(%s.Background(), response)\n", contextPkg)
+				g.W("if err != nil {\n")
+				g.W("return %serr\n", zeroValues)
+				g.W("}\n")
+				if m.ResultsNamed {
+					g.W("r := resp.(%sResponse%s)\n", m.LcName, g.o.ID)
+					g.W("return ")
+					for _, r := range m.Results {
+						g.W("r.%s, ", strings.UcFirst(r.Name()))
+					}
+					g.W("nil\n")
+				} else {
+					g.W("return resp.(%s), nil\n", stdtypes.TypeString(m.Results[0].Type(), g.i.QualifyPkg))
+				}
 			}
-		} else {
-			g.W("return nil, nil\n")
-		}
+			g.W("}\n")
+		})
+	}
+}
 
-		g.W("}),\n")
+func (g *jsonRPCGoClient) jsonrpcPkg() string {
+	if g.o.Transport.FastHTTP {
+		return g.i.Import("jsonrpc", "github.com/l-vitaly/go-kit/transport/fasthttp/jsonrpc")
+	}
+	return g.i.Import("jsonrpc", "github.com/l-vitaly/go-kit/transport/http/jsonrpc")
+}
 
-		g.W(")\n")
+func (g *jsonRPCGoClient) writeClientInit() {
+	g.W("c := &client%s{}\n", g.o.ID)
 
-		g.W("c.%sEndpoint = %s.NewClient(\n", m.LcName, jsonrpcPkg)
-		g.W("u,\n")
-		g.W("%s,\n", strconv.Quote(m.LcName))
+	for _, m := range g.o.Methods {
+		mopt := g.o.Transport.MethodOptions[m.Name]
+		if mopt.RateLimit.Enable {
+			g.W("c.%sRateLimiter = ", m.LcName)
+			writeRateLimiter(g.GoLangWriter, g.i, mopt.RateLimit)
+			g.W("\n")
+		}
+		if mopt.CircuitBreaker.Enable {
+			g.W("c.%sCircuitBreaker = ", m.LcName)
+			writeCircuitBreaker(g.GoLangWriter, g.i, m.Name, mopt.CircuitBreaker)
+			g.W("\n")
+		}
+		if mopt.Timeout.Enable {
+			g.W("c.%sTimeout = ", m.LcName)
+			writer.WriteAST(g, g.i, mopt.Timeout.Duration)
+			g.W("\n")
+		}
+		if mopt.Retry.Enable {
+			g.W("c.%sRetryMax = ", m.LcName)
+			writer.WriteAST(g, g.i, mopt.Retry.Max)
+			g.W("\n")
+			g.W("c.%sRetryBackoff = ", m.LcName)
+			writer.WriteAST(g, g.i, mopt.Retry.Backoff)
+			g.W("\n")
+		}
+	}
 
-		g.W("append(c.genericClientOption, c.%sClientOption...)...,\n", m.LcName)
+	g.W("for _, o := range opts {\n")
+	g.W("o(c)\n")
+	g.W("}\n")
+}
 
-		g.W(").Endpoint()\n")
+func (g *jsonRPCGoClient) writeRequestEncoder(m model.ServiceMethod) {
+	contextPkg := g.i.Import("context", "context")
+	jsonPkg := g.i.Import("json", "encoding/json")
 
-		g.W(
-			"c.%[1]sEndpoint = middlewareChain(append(c.genericEndpointMiddleware, c.%[1]sEndpointMiddleware...))(c.%[1]sEndpoint)\n",
-			m.LcName,
-		)
+	g.W("func(_ %s.Context, obj interface{}) (%s.RawMessage, error) {\n", contextPkg, jsonPkg)
+
+	if len(m.Params) > 0 {
+		ffjsonPkg := g.i.Import("ffjson", "github.com/pquerna/ffjson/ffjson")
+		fmtPkg := g.i.Import("fmt", "fmt")
+
+		g.W("req, ok := obj.(%sRequest%s)\n", m.LcName, g.o.ID)
+		g.W("if !ok {\n")
+		g.W("return nil, %s.Errorf(\"couldn't assert request as %sRequest%s, got %%T\", obj)\n", fmtPkg, m.LcName, g.o.ID)
+		g.W("}\n")
+		g.W("b, err := %s.Marshal(req)\n", ffjsonPkg)
+		g.W("if err != nil {\n")
+		g.W("return nil, %s.Errorf(\"couldn't marshal request %%T: %%s\", obj, err)\n", fmtPkg)
+		g.W("}\n")
+		g.W("return b, nil\n")
+	} else {
+		g.W("return nil, nil\n")
 	}
+	g.W("}")
+}
 
-	g.W("return c, nil\n")
+func (g *jsonRPCGoClient) writeResponseDecoder(m model.ServiceMethod) {
+	mopt := g.o.Transport.MethodOptions[m.Name]
+
+	g.W("func(_ %s.Context, response %s.Response) (interface{}, error) {\n", g.i.Import("context", "context"), g.jsonrpcPkg())
+	g.W("if response.Error != nil {\n")
+	g.W("return nil, ErrorDecode(response.Error.Code, response.Error.Message, response.Error.Data)\n")
 	g.W("}\n")
-	return nil
+
+	if len(m.Results) > 0 {
+		ffjsonPkg := g.i.Import("ffjson", "github.com/pquerna/ffjson/ffjson")
+		fmtPkg := g.i.Import("fmt", "fmt")
+
+		var responseType string
+		if m.ResultsNamed {
+			responseType = fmt.Sprintf("%sResponse%s", m.LcName, g.o.ID)
+		} else {
+			responseType = stdtypes.TypeString(m.Results[0].Type(), g.i.QualifyPkg)
+		}
+
+		if mopt.WrapResponse.Enable {
+			g.W("var resp struct {\n Data %s `json:\"%s\"`\n}\n", responseType, mopt.WrapResponse.Name)
+		} else {
+			g.W("var resp %s\n", responseType)
+		}
+
+		g.W("err := %s.Unmarshal(response.Result, &resp)\n", ffjsonPkg)
+		g.W("if err != nil {\n")
+		g.W("return nil, %s.Errorf(\"couldn't unmarshal body to %sResponse%s: %%s\", err)\n", fmtPkg, m.LcName, g.o.ID)
+		g.W("}\n")
+
+		if mopt.WrapResponse.Enable {
+			g.W("return resp.Data, nil\n")
+		} else {
+			g.W("return resp, nil\n")
+		}
+	} else {
+		g.W("return nil, nil\n")
+	}
+
+	g.W("}")
+}
+
+func (g *jsonRPCGoClient) writeEndpointMiddlewares(m model.ServiceMethod) {
+	mopt := g.o.Transport.MethodOptions[m.Name]
+
+	g.W(
+		"c.%[1]sEndpoint = middlewareChain(append(c.genericEndpointMiddleware, c.%[1]sEndpointMiddleware...))(c.%[1]sEndpoint)\n",
+		m.LcName,
+	)
+
+	if mopt.CircuitBreaker.Enable {
+		g.W("c.%[1]sEndpoint = circuitBreakerMiddleware(c.%[1]sCircuitBreaker)(c.%[1]sEndpoint)\n", m.LcName)
+	}
+	if mopt.Retry.Enable {
+		g.W("c.%[1]sEndpoint = retryMiddleware(c.%[1]sRetryMax, c.%[1]sRetryBackoff)(c.%[1]sEndpoint)\n", m.LcName)
+	}
+	if mopt.Timeout.Enable {
+		g.W("c.%[1]sEndpoint = timeoutMiddleware(c.%[1]sTimeout)(c.%[1]sEndpoint)\n", m.LcName)
+	}
+	if g.o.Transport.Auth.Enable && !mopt.Public {
+		g.W("if c.tokenProvider != nil {\n")
+		g.W("c.%[1]sEndpoint = authClientMiddleware(c.tokenProvider)(c.%[1]sEndpoint)\n", m.LcName)
+		g.W("}\n")
+	}
+	if mopt.RateLimit.Enable {
+		g.W("c.%[1]sEndpoint = rateLimitMiddleware(c.%[1]sRateLimiter)(c.%[1]sEndpoint)\n", m.LcName)
+	}
 }
 
 func (g *jsonRPCGoClient) PkgName() string {
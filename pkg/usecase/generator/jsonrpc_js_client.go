@@ -80,13 +80,20 @@ class JSONRPCClient {
 		params: params,
 	  };
 	}
-	__scheduleRequest(method, params) {
+	__scheduleRequest(method, params, listener) {
 	  const p = new Promise((resolve, reject) => {
 		const request = this.makeJSONRPCRequest(
 		  this.__requestIDGenerate(),
 		  method,
 		  params
 		);
+		if (listener) {
+		  if (!this._transport.subscribe) {
+			reject(new Error("the transport does not support notifications"));
+			return;
+		  }
+		  this._transport.subscribe(request.id, listener);
+		}
 		this._scheduleRequests[request.id] = {
 		  request,
 		  resolve,
@@ -105,6 +112,139 @@ class JSONRPCClient {
   }
 `
 
+const jsonRPCWebSocketTransport = `
+export class WebSocketTransport {
+  /**
+   * @param {string} url
+   * @param {{reconnectDelay: number, maxReconnectDelay: number}} [options]
+   */
+  constructor(url, options = {}) {
+    this._url = url;
+    this._reconnectDelay = options.reconnectDelay || 1000;
+    this._maxReconnectDelay = options.maxReconnectDelay || 30000;
+    this._attempts = 0;
+    this._token = null;
+    this._socket = null;
+    this._reconnectTimerID = null;
+    this._closed = false;
+    this._queue = [];
+    this._pending = {};
+    this._listeners = {};
+  }
+  subscribe(id, listener) {
+    this._listeners[id] = listener;
+  }
+  doRequest(requests, headers) {
+    if (headers && headers.Authorization) {
+      this._token = headers.Authorization.replace(/^Bearer /, "");
+    }
+    return new Promise((resolve, reject) => {
+      const batch = { size: requests.length, responses: [], resolve, reject };
+      for (let i = 0; i < requests.length; i++) {
+        this._pending[requests[i].id] = batch;
+      }
+      this.__send(JSON.stringify(requests));
+    });
+  }
+  close() {
+    this._closed = true;
+    if (this._reconnectTimerID) {
+      clearTimeout(this._reconnectTimerID);
+      this._reconnectTimerID = null;
+    }
+    if (this._socket) {
+      this._socket.close();
+    }
+  }
+  __send(data) {
+    if (this._socket && this._socket.readyState === WebSocket.OPEN) {
+      this._socket.send(data);
+      return;
+    }
+    this._queue.push(data);
+    this.__connect();
+  }
+  __connect() {
+    if (this._closed || this._socket || this._reconnectTimerID) {
+      return;
+    }
+    // browsers cannot set the Authorization header, the token is passed in the subprotocol.
+    const socket = this._token ? new WebSocket(this._url, ["jsonrpc", "bearer." + this._token]) : new WebSocket(this._url);
+    this._socket = socket;
+    socket.onopen = () => {
+      this._attempts = 0;
+      const queue = this._queue;
+      this._queue = [];
+      for (let i = 0; i < queue.length; i++) {
+        socket.send(queue[i]);
+      }
+    };
+    socket.onmessage = (event) => {
+      let message;
+      try {
+        message = JSON.parse(event.data);
+      } catch (e) {
+        return;
+      }
+      const messages = Array.isArray(message) ? message : [message];
+      for (let i = 0; i < messages.length; i++) {
+        this.__dispatch(messages[i]);
+      }
+    };
+    socket.onclose = () => {
+      this._socket = null;
+      const error = new Error("websocket connection closed");
+      const pending = this._pending;
+      const listeners = this._listeners;
+      this._pending = {};
+      this._listeners = {};
+      this._queue = [];
+      for (let id in pending) {
+        pending[id].reject(error);
+      }
+      for (let id in listeners) {
+        listeners[id](undefined, true);
+      }
+      if (this._closed) {
+        return;
+      }
+      const delay = Math.min(this._reconnectDelay * Math.pow(2, this._attempts++), this._maxReconnectDelay);
+      this._reconnectTimerID = setTimeout(() => {
+        this._reconnectTimerID = null;
+        this.__connect();
+      }, delay);
+    };
+  }
+  __dispatch(message) {
+    if (message.method && message.params) {
+      const listener = this._listeners[message.params.id];
+      if (!listener) {
+        return;
+      }
+      if (message.params.done) {
+        delete this._listeners[message.params.id];
+        listener(undefined, true);
+        return;
+      }
+      listener(message.params.result, false);
+      return;
+    }
+    const batch = this._pending[message.id];
+    if (!batch) {
+      return;
+    }
+    delete this._pending[message.id];
+    if (message.error) {
+      delete this._listeners[message.id];
+    }
+    batch.responses.push(message);
+    if (batch.responses.length === batch.size) {
+      batch.resolve(batch.responses);
+    }
+  }
+}
+`
+
 type jsonRPCJSClient struct {
 	writer.BaseWriter
 	filename string
@@ -119,8 +259,29 @@ func (g *jsonRPCJSClient) Prepare(_ context.Context) error {
 func (g *jsonRPCJSClient) Process(_ context.Context) error {
 	g.W(jsonRPCClientBase)
 
+	if g.o.Transport.JsonRPC.WebSocket.Enable {
+		g.W(jsonRPCWebSocketTransport)
+	}
+
 	g.W("export default class extends JSONRPCClient {\n")
 
+	if g.o.Transport.Auth.Enable {
+		g.W("/**\n")
+		g.W("* @param {function(): (string|PromiseLike<string>)} fn\n")
+		g.W("**/\n")
+		g.W("tokenProvider(fn) {\n")
+		g.W("this._tokenProvider = fn;\n")
+		g.W("}\n")
+		g.W("__doRequest(requests) {\n")
+		g.W("if (!this._tokenProvider) {\n")
+		g.W("return super.__doRequest(requests);\n")
+		g.W("}\n")
+		g.W("return Promise.resolve(this._tokenProvider()).then((token) =>\n")
+		g.W("this._transport.doRequest(requests, { Authorization: \"Bearer \" + token })\n")
+		g.W(");\n")
+		g.W("}\n")
+	}
+
 	for _, m := range g.o.Methods {
 		mopt := g.o.Transport.MethodOptions[m.Name]
 		g.W("/**\n")
@@ -136,7 +297,10 @@ func (g *jsonRPCJSClient) Process(_ context.Context) error {
 			g.W("* @param {%s} %s\n", g.getJSDocType(p.Type(), 0), p.Name())
 		}
 
-		if len(m.Results) > 0 {
+		if m.StreamElem != nil {
+			g.W("* @param {function(%s, boolean)} listener\n", g.getJSDocType(m.StreamElem, 0))
+			g.W("* @return {PromiseLike<null>}\n")
+		} else if len(m.Results) > 0 {
 			g.W("* @return {PromiseLike<")
 			if m.ResultsNamed {
 				if mopt.WrapResponse.Enable {
@@ -153,7 +317,7 @@ func (g *jsonRPCJSClient) Process(_ context.Context) error {
 				if m.ResultsNamed {
 					g.W("%s: ", p.Name())
 				}
-				g.W(g.getJSDocType(p.Type(), 0))
+				g.W("%s", g.getJSDocType(p.Type(), 0))
 			}
 			if m.ResultsNamed || mopt.WrapResponse.Enable {
 				g.W("}")
@@ -168,7 +332,14 @@ func (g *jsonRPCJSClient) Process(_ context.Context) error {
 			if i > 0 {
 				g.W(",")
 			}
-			g.W(p.Name())
+			g.W("%s", p.Name())
+		}
+
+		if m.StreamElem != nil {
+			if len(m.Params) > 0 {
+				g.W(",")
+			}
+			g.W("listener")
 		}
 
 		g.W(") {\n")
@@ -181,7 +352,11 @@ func (g *jsonRPCJSClient) Process(_ context.Context) error {
 			g.W("%[1]s:%[1]s", p.Name())
 		}
 
-		g.W("})\n")
+		g.W("}")
+		if m.StreamElem != nil {
+			g.W(", listener")
+		}
+		g.W(")\n")
 		g.W("}\n")
 	}
 
@@ -205,24 +380,38 @@ func (g *jsonRPCJSClient) Process(_ context.Context) error {
 	}
 	g.W("}\n}\n")
 
-	g.info.Enums.Iterate(func(key stdtypes.Type, value interface{}) {
-		if named, ok := key.(*stdtypes.Named); ok {
-			b, ok := named.Obj().Type().Underlying().(*stdtypes.Basic)
-			if !ok {
-				return
+	for _, group := range sortedEnums(g.info.Enums) {
+		b, ok := group.named.Obj().Type().Underlying().(*stdtypes.Basic)
+		if !ok {
+			continue
+		}
+		enums := group.enums
+		values := make([]string, 0, len(enums))
+		o, hasOption := enumOption(g.info.EnumOptions, group.named)
+		if hasOption && !o.JSONValue {
+			enums = o.Enums
+			for _, enum := range enums {
+				values = append(values, strconv.Quote(o.Text(enum)))
 			}
-			g.W("export const %sEnum = Object.freeze({\n", named.Obj().Name())
-
-			for _, enum := range value.([]model.Enum) {
+		} else {
+			for _, enum := range enums {
 				value := enum.Value
 				if b.Info() == stdtypes.IsString {
 					value = strconv.Quote(value)
 				}
-				g.W("%s: %s,\n", strconv.Quote(enum.Name), value)
+				values = append(values, value)
 			}
-			g.W("});\n")
 		}
-	})
+		// the typedef is written only for the types with the Enum option, since only they are validated by the servers.
+		if hasOption {
+			g.W("/**\n* @typedef {(%s)} %s\n*/\n", strings.Join(values, "|"), group.named.Obj().Name())
+		}
+		g.W("export const %sEnum = Object.freeze({\n", group.named.Obj().Name())
+		for i, enum := range enums {
+			g.W("%s: %s,\n", strconv.Quote(enum.Name), values[i])
+		}
+		g.W("});\n")
+	}
 
 	return nil
 }
@@ -265,6 +454,9 @@ func (g *jsonRPCJSClient) getJSDocType(t stdtypes.Type, nested int) string {
 		case "time.Time":
 			return "string"
 		}
+		if _, ok := enumOption(g.info.EnumOptions, v); ok {
+			return v.Obj().Name()
+		}
 		return g.getJSDocType(v.Obj().Type().Underlying(), nested)
 	case *stdtypes.Struct:
 		buf := new(bytes.Buffer)
@@ -141,6 +141,14 @@ func (g *jsonrpcMarkdownDoc) Process(ctx context.Context) error {
 
 		g.W("\n\n")
 
+		mopt := g.o.Transport.MethodOptions[method.Name]
+		if len(mopt.Permissions) > 0 {
+			g.W("**Permissions**: <code>%s</code>\n\n", strings.Join(mopt.Permissions, "</code>, <code>"))
+		}
+		if len(mopt.Roles) > 0 {
+			g.W("**Roles**: <code>%s</code>\n\n", strings.Join(mopt.Roles, "</code>, <code>"))
+		}
+
 		if len(method.Params) > 0 {
 			g.W("| Param | Type | Description |\n|------|------|------|\n")
 			for _, param := range method.Params {
@@ -3,8 +3,11 @@ package generator
 import (
 	"context"
 	stdtypes "go/types"
+	"strconv"
 	stdstrings "strings"
 
+	"github.com/pquerna/ffjson/ffjson"
+
 	"github.com/swipe-io/swipe/pkg/domain/model"
 	"github.com/swipe-io/swipe/pkg/importer"
 	"github.com/swipe-io/swipe/pkg/writer"
@@ -51,6 +54,14 @@ func (g *jsonRPCServer) Process(ctx context.Context) error {
 	g.W("return b, nil\n")
 	g.W("}\n\n")
 
+	if transportOpt.OpenRPC.Discover {
+		doc, err := ffjson.Marshal(makeOpenRPCDocument(g.info, g.o))
+		if err != nil {
+			return err
+		}
+		g.W("var openRPCDocument%s = %s.RawMessage(%s)\n\n", g.o.ID, jsonPkg, strconv.Quote(string(doc)))
+	}
+
 	stringsPkg := g.i.Import("strings", "strings")
 
 	g.W("func Make%sEndpointCodecMap(ep EndpointSet, ns ...string) %s.EndpointCodecMap {\n", g.o.ID, jsonrpcPkg)
@@ -64,6 +75,8 @@ func (g *jsonRPCServer) Process(ctx context.Context) error {
 
 	//g.W("return %[1]s.EndpointCodecMap{\n", jsonrpcPkg)
 
+	enums := newEnumValidator(nil, nil, g.info.EnumOptions)
+
 	for _, m := range g.o.Methods {
 		mopt := transportOpt.MethodOptions[m.Name]
 
@@ -86,6 +99,9 @@ func (g *jsonRPCServer) Process(ctx context.Context) error {
 				g.W("if err != nil {\n")
 				g.W("return nil, %s.Errorf(\"couldn't unmarshal body to %sRequest%s: %%s\", err)\n", fmtPkg, m.LcName, g.o.ID)
 				g.W("}\n")
+				if enums.hasEnums(m) {
+					g.W("if err := validate%sRequest%s(req); err != nil {\nreturn nil, err\n}\n", m.Name, g.o.ID)
+				}
 				g.W("return req, nil\n")
 
 			} else {
@@ -98,7 +114,14 @@ func (g *jsonRPCServer) Process(ctx context.Context) error {
 
 		g.W("Encode:")
 
-		if mopt.WrapResponse.Enable && len(m.Results) > 0 {
+		if m.StreamElem != nil {
+			g.W("func(ctx %s.Context, response interface{}) (%s.RawMessage, error) {\n", contextPkg, jsonPkg)
+			g.W("if _, ok := response.(%s); ok {\n", stdtypes.TypeString(m.Results[0].Type(), g.i.QualifyPkg))
+			g.W("return nil, %s.New(\"method %s is served only over WebSocket\")\n", g.i.Import("errors", "errors"), m.LcName)
+			g.W("}\n")
+			g.W("return encodeResponseJSONRPC%s(ctx, response)\n", g.o.ID)
+			g.W("},\n")
+		} else if mopt.WrapResponse.Enable && len(m.Results) > 0 {
 			jsonPkg := g.i.Import("json", "encoding/json")
 			g.W("func (ctx context.Context, response interface{}) (%s.RawMessage, error) {\n", jsonPkg)
 			g.W("return encodeResponseJSONRPC%s(ctx, map[string]interface{}{\"%s\": response})\n", g.o.ID, mopt.WrapResponse.Name)
@@ -109,10 +132,28 @@ func (g *jsonRPCServer) Process(ctx context.Context) error {
 		g.W("}\n}\n")
 	}
 
+	if transportOpt.OpenRPC.Discover {
+		g.W("ecm[\"rpc.discover\"] = %s.EndpointCodec{\n", jsonrpcPkg)
+		g.W("Endpoint: func(%s.Context, interface{}) (interface{}, error) {\n", contextPkg)
+		g.W("return openRPCDocument%s, nil\n", g.o.ID)
+		g.W("},\n")
+		g.W("Decode: func(%s.Context, %s.RawMessage) (interface{}, error) {\n", contextPkg, jsonPkg)
+		g.W("return nil, nil\n")
+		g.W("},\n")
+		g.W("Encode: encodeResponseJSONRPC%s,\n", g.o.ID)
+		g.W("}\n")
+	}
+
 	g.W("return ecm\n")
 
 	g.W("}\n")
 
+	if transportOpt.Openapi.Serve != "" {
+		if err := writeOpenapiServeConsts(g.GoLangWriter, g.info, g.o); err != nil {
+			return err
+		}
+	}
+
 	g.W("// HTTP %s Transport\n", transportOpt.Prefix)
 	g.W("func MakeHandler%s%s(s %s", g.o.Transport.Prefix, g.o.ID, typeStr)
 
@@ -128,8 +169,74 @@ func (g *jsonRPCServer) Process(ctx context.Context) error {
 
 	g.W("sopt := &server%sOpts{}\n", g.o.ID)
 
+	for _, m := range g.o.Methods {
+		mopt := transportOpt.MethodOptions[m.Name]
+		if mopt.RateLimit.Enable {
+			g.W("sopt.%sRateLimiter = ", m.LcName)
+			writeRateLimiter(g.GoLangWriter, g.i, mopt.RateLimit)
+			g.W("\n")
+		}
+		if mopt.CircuitBreaker.Enable {
+			g.W("sopt.%sCircuitBreaker = ", m.LcName)
+			writeCircuitBreaker(g.GoLangWriter, g.i, m.Name, mopt.CircuitBreaker)
+			g.W("\n")
+		}
+	}
+
+	if transportOpt.AccessLog.Enable {
+		logPkg := g.i.Import("log", "github.com/go-kit/kit/log")
+		g.W("sopt.accessLogger = %[1]s.NewLogfmtLogger(%[1]s.NewSyncWriter(%[2]s.Stdout))\n", logPkg, g.i.Import("os", "os"))
+	}
+	if transportOpt.HTTPMetrics.Enable && hasDefaultHTTPMetrics(g.o) {
+		g.W("sopt.httpRequestCount, sopt.httpRequestDuration, sopt.httpResponseSize = defaultHTTPMetrics()\n")
+	}
+	if transportOpt.PanicRecovery.Enable {
+		g.W("sopt.panicLogger = ")
+		writeDefaultPanicLogger(g.GoLangWriter, g.i, g.o.Logging.Backend)
+		g.W("\n")
+		if g.o.Instrumenting.Enable && hasDefaultHTTPMetrics(g.o) {
+			g.W("sopt.panicCount = defaultPanicCounter()\n")
+		}
+	}
+
+	if transportOpt.JsonRPC.WebSocket.Enable {
+		g.W("sopt.webSocketReadLimit = 1 << 20\n")
+		g.W("sopt.webSocketConcurrency = 16\n")
+	}
+
 	g.W("for _, o := range opts {\n o(sopt)\n }\n")
 
+	if transportOpt.HTTPMetrics.Enable && !hasDefaultHTTPMetrics(g.o) {
+		g.W("if sopt.httpRequestCount == nil || sopt.httpRequestDuration == nil || sopt.httpResponseSize == nil {\n")
+		g.W("return nil, %s.New(\"the HTTP metrics of the %s backend must be passed with the %sServerHTTPMetrics option\")\n", g.i.Import("errors", "errors"), g.o.Instrumenting.Backend, g.o.ID)
+		g.W("}\n")
+	}
+	if transportOpt.PanicRecovery.Enable && g.o.Instrumenting.Enable && !hasDefaultHTTPMetrics(g.o) {
+		g.W("if sopt.panicCount == nil {\n")
+		g.W("if sopt.metricsProvider == nil {\n")
+		g.W("return nil, %s.New(\"the panic counter of the %s backend must be passed with the %sServerMetricsProvider or %[3]sServerPanicCounter option\")\n", g.i.Import("errors", "errors"), g.o.Instrumenting.Backend, g.o.ID)
+		g.W("}\n")
+		g.W("sopt.panicCount = defaultPanicCounter(sopt.metricsProvider)\n")
+		g.W("}\n")
+	}
+
+	if g.o.Logging.Enable {
+		g.W("sopt.genericServerOption = append([]%[1]s.ServerOption{%[1]s.ServerBefore(requestIDHTTPToContext)}, sopt.genericServerOption...)\n", jsonrpcPkg)
+	}
+
+	if g.o.Tracing.Enable {
+		g.W("sopt.genericServerOption = append([]%[1]s.ServerOption{%[1]s.ServerBefore(tracingHTTPToContext)}, sopt.genericServerOption...)\n", jsonrpcPkg)
+	}
+
+	if transportOpt.Auth.Enable {
+		if transportOpt.FastHTTP {
+			g.W("sopt.genericServerOption = append([]%[1]s.ServerOption{%[1]s.ServerBefore(authFastHTTPToContext)}, sopt.genericServerOption...)\n", jsonrpcPkg)
+		} else {
+			kitjwtPkg := g.i.Import("jwt", "github.com/go-kit/kit/auth/jwt")
+			g.W("sopt.genericServerOption = append([]%[1]s.ServerOption{%[1]s.ServerBefore(%[2]s.HTTPToContext())}, sopt.genericServerOption...)\n", jsonrpcPkg, kitjwtPkg)
+		}
+	}
+
 	g.W("ep := MakeEndpointSet(s)\n")
 
 	for _, m := range g.o.Methods {
@@ -139,6 +246,59 @@ func (g *jsonRPCServer) Process(ctx context.Context) error {
 		)
 	}
 
+	for _, m := range g.o.Methods {
+		if transportOpt.MethodOptions[m.Name].CircuitBreaker.Enable {
+			g.W("ep.%[1]sEndpoint = circuitBreakerMiddleware(sopt.%[2]sCircuitBreaker)(ep.%[1]sEndpoint)\n", m.Name, m.LcName)
+		}
+	}
+
+	if transportOpt.Principal.Enable {
+		for _, m := range g.o.Methods {
+			mopt := transportOpt.MethodOptions[m.Name]
+			if len(mopt.Permissions) == 0 && len(mopt.Roles) == 0 {
+				continue
+			}
+			g.W("ep.%sEndpoint = authorizationMiddleware(%s, %s)(ep.%[1]sEndpoint)\n", m.Name, stringSliceLiteral(mopt.Permissions), stringSliceLiteral(mopt.Roles))
+		}
+	}
+
+	if transportOpt.Auth.Enable {
+		for _, m := range g.o.Methods {
+			if transportOpt.MethodOptions[m.Name].Public {
+				continue
+			}
+			g.W("ep.%sEndpoint = authMiddleware(", m.Name)
+			writer.WriteAST(g, g.i, transportOpt.Auth.KeyFunc)
+			g.W(", ")
+			if transportOpt.Auth.ClaimsFactory != nil {
+				writer.WriteAST(g, g.i, transportOpt.Auth.ClaimsFactory)
+			} else {
+				g.W("%s.MapClaimsFactory", g.i.Import("jwt", "github.com/go-kit/kit/auth/jwt"))
+			}
+			g.W(")(ep.%sEndpoint)\n", m.Name)
+		}
+	}
+
+	for _, m := range g.o.Methods {
+		if transportOpt.MethodOptions[m.Name].RateLimit.Enable {
+			g.W("ep.%[1]sEndpoint = rateLimitMiddleware(sopt.%[2]sRateLimiter)(ep.%[1]sEndpoint)\n", m.Name, m.LcName)
+		}
+	}
+
+	if transportOpt.PanicRecovery.Enable {
+		for _, m := range g.o.Methods {
+			g.W("ep.%sEndpoint = recoveryMiddleware(%s, sopt)(ep.%[1]sEndpoint)\n", m.Name, strconv.Quote(m.Name))
+		}
+	}
+
+	observe := transportOpt.AccessLog.Enable || transportOpt.HTTPMetrics.Enable
+
+	if observe {
+		for _, m := range g.o.Methods {
+			g.W("ep.%sEndpoint = observeEndpointMiddleware(%s)(ep.%[1]sEndpoint)\n", m.Name, strconv.Quote(m.LcName))
+		}
+	}
+
 	if transportOpt.FastHTTP {
 		g.W("r := %s.New()\n", routerPkg)
 	} else {
@@ -150,15 +310,53 @@ func (g *jsonRPCServer) Process(ctx context.Context) error {
 		r := stdstrings.NewReplacer("{", "<", "}", ">")
 		jsonRPCPath = r.Replace(jsonRPCPath)
 
-		g.W("r.Post(\"%s\", func(c *routing.Context) error {\nhandler.ServeFastHTTP(c.RequestCtx)\nreturn nil\n})\n", jsonRPCPath)
+		if observe {
+			g.W("r.Post(\"%s\", observeRoute(%s, func(c *routing.Context) error {\nhandler.ServeFastHTTP(c.RequestCtx)\nreturn nil\n}))\n", jsonRPCPath, strconv.Quote(transportOpt.JsonRPC.Path))
+		} else {
+			g.W("r.Post(\"%s\", func(c *routing.Context) error {\nhandler.ServeFastHTTP(c.RequestCtx)\nreturn nil\n})\n", jsonRPCPath)
+		}
 	} else {
-		g.W("r.Methods(\"POST\").Path(\"%s\").Handler(handler)\n", jsonRPCPath)
+		if observe {
+			g.W("r.Methods(\"POST\").Path(\"%s\").Handler(observeRoute(%s, handler))\n", jsonRPCPath, strconv.Quote(transportOpt.JsonRPC.Path))
+		} else {
+			g.W("r.Methods(\"POST\").Path(\"%s\").Handler(handler)\n", jsonRPCPath)
+		}
+		if transportOpt.JsonRPC.WebSocket.Enable {
+			var before []string
+			if g.o.Logging.Enable {
+				before = append(before, "requestIDHTTPToContext")
+			}
+			if g.o.Tracing.Enable {
+				before = append(before, "tracingHTTPToContext")
+			}
+			if transportOpt.Auth.Enable {
+				before = append(before, g.i.Import("jwt", "github.com/go-kit/kit/auth/jwt")+".HTTPToContext()")
+			}
+			wsHandler := "webSocketHandler(Make" + g.o.ID + "EndpointCodecMap(ep), sopt.webSocketCheckOrigin, sopt.webSocketReadLimit, sopt.webSocketConcurrency"
+			for _, b := range before {
+				wsHandler += ", " + b
+			}
+			wsHandler += ")"
+			if observe {
+				wsHandler = "observeRoute(" + strconv.Quote(transportOpt.JsonRPC.WebSocket.Path) + ", " + wsHandler + ")"
+			}
+			g.W("r.Methods(\"GET\").Path(\"%s\").Handler(%s)\n", transportOpt.JsonRPC.WebSocket.Path, wsHandler)
+		}
 	}
+	if transportOpt.Openapi.Serve != "" {
+		writeOpenapiServeRoutes(g.GoLangWriter, g.i, g.o)
+	}
+	handler := "r"
 	if transportOpt.FastHTTP {
-		g.W("return r.HandleRequest, nil")
-	} else {
-		g.W("return r, nil")
+		handler = "r.HandleRequest"
+	}
+	if transportOpt.PanicRecovery.Enable {
+		handler = "recoveryHandler(" + handler + ", sopt)"
+	}
+	if observe {
+		handler = "observeHandler(" + handler + ", sopt)"
 	}
+	g.W("return %s, nil", handler)
 	g.W("}\n\n")
 	return nil
 }
@@ -3,6 +3,7 @@ package generator
 import (
 	"context"
 	stdtypes "go/types"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -14,10 +15,11 @@ import (
 
 type logging struct {
 	*writer.GoLangWriter
-	filename string
-	info     model.GenerateInfo
-	o        model.ServiceOption
-	i        *importer.Importer
+	filename    string
+	info        model.GenerateInfo
+	o           model.ServiceOption
+	i           *importer.Importer
+	useLogValue bool
 }
 
 func (g *logging) Prepare(ctx context.Context) error {
@@ -31,7 +33,8 @@ func (g *logging) Process(ctx context.Context) error {
 	if len(g.o.Methods) > 0 {
 		timePkg = g.i.Import("time", "time")
 	}
-	loggerPkg := g.i.Import("log", "github.com/go-kit/kit/log")
+	contextPkg := g.i.Import("context", "context")
+	loggerType := g.loggerType()
 	typeStr := stdtypes.TypeString(g.o.Type, g.i.QualifyPkg)
 
 	name := "loggingMiddleware" + g.o.ID
@@ -41,18 +44,23 @@ func (g *logging) Process(ctx context.Context) error {
 		name,
 		[]string{
 			"next", typeStr,
-			"logger", loggerPkg + ".Logger",
+			"logger", loggerType,
 		},
 	)
 
 	for _, m := range g.o.Methods {
-		logParams := makeLogParams(m.Params...)
+		mopt, ok := g.o.Logging.MethodOptions[m.Name]
+		if !ok {
+			mopt = g.o.Logging.DefaultMethodOptions
+		}
+
+		logParams := g.makeLogParams(mopt, m.Params...)
 
 		if len(m.Results) > 0 {
 			if m.ResultsNamed {
-				logParams = append(logParams, makeLogParams(m.Results...)...)
-			} else {
-				logParams = append(logParams, strconv.Quote("result"), makeLogParam("result", m.Results[0].Type()))
+				logParams = append(logParams, g.makeLogParams(mopt, m.Results...)...)
+			} else if logParam := g.makeLogParam(mopt, "result", m.Results[0].Type()); logParam != "" {
+				logParams = append(logParams, strconv.Quote("result"), logParam)
 			}
 		}
 
@@ -72,21 +80,34 @@ func (g *logging) Process(ctx context.Context) error {
 			}
 		}
 
+		errName := "nil"
 		if m.ReturnErr != nil {
-			errName := m.ReturnErr.Name()
+			errName = m.ReturnErr.Name()
 			if errName == "" || errName == "_" {
 				errName = "err"
 			}
 			results = append(results, errName, "error")
-
-			logParams = append(logParams, strconv.Quote(errName), errName)
 		}
 
 		g.WriteFunc(m.Name, "s *"+name, params, results, func() {
-			if len(logParams) > 0 {
+			if len(logParams) > 0 || m.ReturnErr != nil {
 				g.WriteDefer([]string{"now " + timePkg + ".Time"}, []string{timePkg + ".Now()"}, func() {
-					g.W("s.logger.Log(\"method\",\"%s\",\"took\",%s.Since(now),", m.Name, timePkg)
-					g.W(strings.Join(logParams, ","))
+					if m.ParamCtx != nil {
+						g.W("s.writeLog(%s, ", m.ParamCtx.Name())
+					} else {
+						g.W("s.writeLog(%s.Background(), ", contextPkg)
+					}
+					g.W("%s, %s.Since(now), ", strconv.Quote(m.Name), timePkg)
+					if mopt.SlowThreshold != nil {
+						writer.WriteAST(g, g.i, mopt.SlowThreshold)
+					} else {
+						g.W("0")
+					}
+					g.W(", %s", errName)
+					if len(logParams) > 0 {
+						g.W(", ")
+						g.W("%s", strings.Join(logParams, ","))
+					}
 					g.W(")\n")
 				})
 			}
@@ -102,16 +123,279 @@ func (g *logging) Process(ctx context.Context) error {
 				if i > 0 {
 					g.W(",")
 				}
-				g.W(p.Name())
+				g.W("%s", p.Name())
 			}
 			g.W(")\n")
 		})
 	}
 
-	g.W("func %[1]s(s %[2]s, logger %[4]s.Logger) %[2]s {\n return &%[3]s{next: s, logger: logger}\n}\n", constructName, typeStr, name, loggerPkg)
+	if len(g.o.Methods) > 0 {
+		g.writeLog(name, timePkg, contextPkg)
+	}
+
+	g.W("func %[1]s(s %[2]s, logger %[4]s) %[2]s {\n return &%[3]s{next: s, logger: logger}\n}\n\n", constructName, typeStr, name, loggerType)
+
+	g.writeRequestID(contextPkg)
+
+	if g.useLogValue {
+		g.writeLogValue()
+	}
 	return nil
 }
 
+func (g *logging) loggerType() string {
+	return loggerType(g.i, g.o.Logging.Backend)
+}
+
+func (g *logging) writeLog(name, timePkg, contextPkg string) {
+	successLevel := "Info"
+	if g.o.Logging.SuccessLevel == "debug" {
+		successLevel = "Debug"
+	}
+
+	g.W("func (s *%s) writeLog(ctx %s.Context, method string, took, slowThreshold %s.Duration, err error, keyvals ...interface{}) {\n", name, contextPkg, timePkg)
+	if g.o.Logging.Backend == "kit" {
+		g.W("keyvals = append([]interface{}{\"method\", method, \"took\", took}, keyvals...)\n")
+	} else {
+		g.W("keyvals = append([]interface{}{\"took\", took}, keyvals...)\n")
+	}
+	g.W("if requestID, ok := RequestIDFromContext(ctx); ok {\n")
+	g.W("keyvals = append(keyvals, \"request_id\", requestID)\n")
+	g.W("}\n")
+	if g.o.Tracing.Enable {
+		tracePkg := g.i.Import("trace", "go.opentelemetry.io/otel/trace")
+
+		g.W("if sc := %s.SpanContextFromContext(ctx); sc.HasTraceID() {\n", tracePkg)
+		g.W("keyvals = append(keyvals, \"trace_id\", sc.TraceID().String())\n")
+		g.W("}\n")
+	}
+	g.W("if err != nil {\n")
+	g.W("keyvals = append(keyvals, \"err\", err)\n")
+	g.W("}\n")
+
+	switch g.o.Logging.Backend {
+	case "zap":
+		g.W("logger := s.logger.Sugar()\n")
+		g.W("switch {\n")
+		g.W("case err != nil:\nlogger.Errorw(method, keyvals...)\n")
+		g.W("case slowThreshold > 0 && took > slowThreshold:\nlogger.Warnw(method, keyvals...)\n")
+		g.W("default:\nlogger.%sw(method, keyvals...)\n", successLevel)
+		g.W("}\n")
+	case "zerolog":
+		g.W("switch {\n")
+		g.W("case err != nil:\ns.logger.Error().Fields(keyvals).Msg(method)\n")
+		g.W("case slowThreshold > 0 && took > slowThreshold:\ns.logger.Warn().Fields(keyvals).Msg(method)\n")
+		g.W("default:\ns.logger.%s().Fields(keyvals).Msg(method)\n", successLevel)
+		g.W("}\n")
+	case "slog":
+		slogPkg := g.i.Import("slog", "log/slog")
+
+		g.W("switch {\n")
+		g.W("case err != nil:\ns.logger.Log(ctx, %s.LevelError, method, keyvals...)\n", slogPkg)
+		g.W("case slowThreshold > 0 && took > slowThreshold:\ns.logger.Log(ctx, %s.LevelWarn, method, keyvals...)\n", slogPkg)
+		g.W("default:\ns.logger.Log(ctx, %s.Level%s, method, keyvals...)\n", slogPkg, successLevel)
+		g.W("}\n")
+	default:
+		levelPkg := g.i.Import("level", "github.com/go-kit/kit/log/level")
+
+		g.W("switch {\n")
+		g.W("case err != nil:\n_ = %s.Error(s.logger).Log(keyvals...)\n", levelPkg)
+		g.W("case slowThreshold > 0 && took > slowThreshold:\n_ = %s.Warn(s.logger).Log(keyvals...)\n", levelPkg)
+		g.W("default:\n_ = %s.%s(s.logger).Log(keyvals...)\n", levelPkg, successLevel)
+		g.W("}\n")
+	}
+	g.W("}\n\n")
+}
+
+func (g *logging) writeRequestID(contextPkg string) {
+	g.W("type requestIDContextKey struct{}\n\n")
+
+	g.W("// ContextWithRequestID returns a copy of ctx with the request ID, the request ID is written to the log.\n")
+	g.W("func ContextWithRequestID(ctx %s.Context, requestID string) %s.Context {\n", contextPkg, contextPkg)
+	g.W("return %s.WithValue(ctx, requestIDContextKey{}, requestID)\n", contextPkg)
+	g.W("}\n\n")
+
+	g.W("// RequestIDFromContext returns the request ID stored in ctx.\n")
+	g.W("func RequestIDFromContext(ctx %s.Context) (string, bool) {\n", contextPkg)
+	g.W("requestID, ok := ctx.Value(requestIDContextKey{}).(string)\n")
+	g.W("return requestID, ok\n")
+	g.W("}\n\n")
+}
+
+func (g *logging) makeLogParams(opt model.LoggingMethodOption, data ...*stdtypes.Var) (result []string) {
+	for _, v := range data {
+		if logParam := g.makeLogParam(opt, v.Name(), v.Type()); logParam != "" {
+			result = append(result, strconv.Quote(v.Name()), logParam)
+		}
+	}
+	return
+}
+
+func (g *logging) makeLogParam(opt model.LoggingMethodOption, name string, t stdtypes.Type) string {
+	if _, ok := opt.Skip[name]; ok {
+		return ""
+	}
+	if _, ok := opt.Redact[name]; ok {
+		return strconv.Quote("[REDACTED]")
+	}
+	if opt.MaxSize > 0 {
+		switch u := t.Underlying().(type) {
+		case *stdtypes.Basic:
+			if u.Info()&stdtypes.IsString == 0 {
+				return name
+			}
+		case *stdtypes.Chan:
+			return "len(" + name + ")"
+		case *stdtypes.Signature:
+			return name
+		}
+		g.useLogValue = true
+		return "logValue(" + name + ", " + strconv.Itoa(opt.MaxSize) + ")"
+	}
+	switch t.(type) {
+	case *stdtypes.Slice, *stdtypes.Array, *stdtypes.Map, *stdtypes.Chan:
+		return "len(" + name + ")"
+	}
+	if hasLogTags(t, map[stdtypes.Type]bool{}) {
+		g.useLogValue = true
+		return "logValue(" + name + ", 0)"
+	}
+	return name
+}
+
+func (g *logging) writeLogValue() {
+	fmtPkg := g.i.Import("fmt", "fmt")
+	reflectPkg := g.i.Import("reflect", "reflect")
+
+	g.W("func logValue(v interface{}, maxSize int) interface{} {\n")
+	g.W("return logReflectValue(%s.ValueOf(v), maxSize, 0)\n", reflectPkg)
+	g.W("}\n\n")
+
+	g.W("func logReflectValue(v %[1]s.Value, maxSize, depth int) interface{} {\n", reflectPkg)
+	g.W("if !v.IsValid() {\nreturn nil\n}\n")
+	g.W("if depth > 8 {\nreturn \"...\"\n}\n")
+	// the text form of the value is used, unless the log tags of the struct fields are applied.
+	g.W("if v.Kind() != %[1]s.Ptr && v.Kind() != %[1]s.Interface && v.CanInterface() && !logTagged(v.Type()) {\n", reflectPkg)
+	g.W("switch i := v.Interface().(type) {\n")
+	g.W("case %s.Stringer:\n", fmtPkg)
+	g.W("return logReflectValue(%s.ValueOf(i.String()), maxSize, depth+1)\n", reflectPkg)
+	g.W("case %s.TextMarshaler:\n", g.i.Import("encoding", "encoding"))
+	g.W("if b, err := i.MarshalText(); err == nil {\n")
+	g.W("return logReflectValue(%s.ValueOf(string(b)), maxSize, depth+1)\n", reflectPkg)
+	g.W("}\n")
+	g.W("case %s.Marshaler:\n", g.i.Import("json", "encoding/json"))
+	g.W("if b, err := i.MarshalJSON(); err == nil {\n")
+	g.W("return logReflectValue(%s.ValueOf(string(b)), maxSize, depth+1)\n", reflectPkg)
+	g.W("}\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("switch v.Kind() {\n")
+
+	g.W("case %[1]s.Ptr, %[1]s.Interface:\n", reflectPkg)
+	g.W("if v.IsNil() {\nreturn nil\n}\n")
+	g.W("return logReflectValue(v.Elem(), maxSize, depth+1)\n")
+
+	g.W("case %s.String:\n", reflectPkg)
+	g.W("s := v.String()\n")
+	g.W("if maxSize > 0 && len(s) > maxSize {\n")
+	g.W("return s[:maxSize] + %s.Sprintf(\"...(%%d bytes)\", len(s))\n", fmtPkg)
+	g.W("}\n")
+	g.W("return s\n")
+
+	g.W("case %[1]s.Slice, %[1]s.Array:\n", reflectPkg)
+	g.W("if v.Kind() == %s.Slice && v.IsNil() {\nreturn nil\n}\n", reflectPkg)
+	g.W("n := v.Len()\n")
+	g.W("if maxSize > 0 && n > maxSize {\nn = maxSize\n}\n")
+	g.W("if v.Type().Elem().Kind() == %s.Uint8 {\n", reflectPkg)
+	g.W("b := make([]byte, n)\n")
+	g.W("for i := 0; i < n; i++ {\nb[i] = byte(v.Index(i).Uint())\n}\n")
+	g.W("if n < v.Len() {\n")
+	g.W("return string(b) + %s.Sprintf(\"...(%%d bytes)\", v.Len())\n", fmtPkg)
+	g.W("}\n")
+	g.W("return string(b)\n")
+	g.W("}\n")
+	g.W("result := make([]interface{}, 0, n+1)\n")
+	g.W("for i := 0; i < n; i++ {\n")
+	g.W("result = append(result, logReflectValue(v.Index(i), maxSize, depth+1))\n")
+	g.W("}\n")
+	g.W("if n < v.Len() {\n")
+	g.W("result = append(result, %s.Sprintf(\"...(%%d more)\", v.Len()-n))\n", fmtPkg)
+	g.W("}\n")
+	g.W("return result\n")
+
+	g.W("case %s.Map:\n", reflectPkg)
+	g.W("if v.IsNil() {\nreturn nil\n}\n")
+	g.W("result := make(map[string]interface{}, v.Len())\n")
+	g.W("iter := v.MapRange()\n")
+	g.W("for iter.Next() {\n")
+	g.W("if maxSize > 0 && len(result) >= maxSize {\n")
+	g.W("result[\"...\"] = %s.Sprintf(\"%%d more\", v.Len()-maxSize)\n", fmtPkg)
+	g.W("break\n")
+	g.W("}\n")
+	g.W("result[%s.Sprint(iter.Key())] = logReflectValue(iter.Value(), maxSize, depth+1)\n", fmtPkg)
+	g.W("}\n")
+	g.W("return result\n")
+
+	g.W("case %s.Struct:\n", reflectPkg)
+	g.W("t := v.Type()\n")
+	g.W("result := make(map[string]interface{}, t.NumField())\n")
+	g.W("for i := 0; i < t.NumField(); i++ {\n")
+	g.W("f := t.Field(i)\n")
+	g.W("if f.PkgPath != \"\" {\ncontinue\n}\n")
+	g.W("switch f.Tag.Get(\"log\") {\n")
+	g.W("case \"-\":\ncontinue\n")
+	g.W("case \"redact\":\nresult[f.Name] = \"[REDACTED]\"\ncontinue\n")
+	g.W("}\n")
+	g.W("result[f.Name] = logReflectValue(v.Field(i), maxSize, depth+1)\n")
+	g.W("}\n")
+	g.W("if len(result) == 0 && v.CanInterface() {\nreturn v.Interface()\n}\n")
+	g.W("return result\n")
+
+	g.W("case %s.Chan:\n", reflectPkg)
+	g.W("return v.Len()\n")
+	g.W("}\n")
+	g.W("if v.CanInterface() {\nreturn v.Interface()\n}\n")
+	g.W("return nil\n")
+	g.W("}\n\n")
+
+	g.W("func logTagged(t %s.Type) bool {\n", reflectPkg)
+	g.W("if t.Kind() != %s.Struct {\nreturn false\n}\n", reflectPkg)
+	g.W("for i := 0; i < t.NumField(); i++ {\n")
+	g.W("if _, ok := t.Field(i).Tag.Lookup(\"log\"); ok {\nreturn true\n}\n")
+	g.W("}\n")
+	g.W("return false\n")
+	g.W("}\n\n")
+}
+
+func hasLogTags(t stdtypes.Type, visited map[stdtypes.Type]bool) bool {
+	switch v := t.(type) {
+	case *stdtypes.Named:
+		if visited[v] {
+			return false
+		}
+		visited[v] = true
+		return hasLogTags(v.Underlying(), visited)
+	case *stdtypes.Pointer:
+		return hasLogTags(v.Elem(), visited)
+	case *stdtypes.Slice:
+		return hasLogTags(v.Elem(), visited)
+	case *stdtypes.Array:
+		return hasLogTags(v.Elem(), visited)
+	case *stdtypes.Map:
+		return hasLogTags(v.Elem(), visited)
+	case *stdtypes.Struct:
+		for i := 0; i < v.NumFields(); i++ {
+			if _, ok := reflect.StructTag(v.Tag(i)).Lookup("log"); ok {
+				return true
+			}
+			if hasLogTags(v.Field(i).Type(), visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (g *logging) PkgName() string {
 	return ""
 }
@@ -4,14 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
-	stdtypes "go/types"
 	"path/filepath"
 	"strconv"
 	stdstrings "strings"
 
 	"github.com/iancoleman/strcase"
-	"github.com/pquerna/ffjson/ffjson"
 
 	"github.com/swipe-io/swipe/pkg/domain/model"
 	"github.com/swipe-io/swipe/pkg/openapi"
@@ -154,6 +151,35 @@ func getOpenapiJSONRPCErrorSchemas() openapi.Schemas {
 	}
 }
 
+func getOpenapiJSONRPCErrorSchema(code int64, message string) *openapi.Schema {
+	return &openapi.Schema{
+		Type: "object",
+		Properties: openapi.Properties{
+			"jsonrpc": &openapi.Schema{
+				Type:    "string",
+				Example: "2.0",
+			},
+			"id": &openapi.Schema{
+				Type:    "string",
+				Example: "1f1ecd1b-d729-40cd-b6f4-4011f69811fe",
+			},
+			"error": &openapi.Schema{
+				Type: "object",
+				Properties: openapi.Properties{
+					"code": &openapi.Schema{
+						Type:    "integer",
+						Example: code,
+					},
+					"message": &openapi.Schema{
+						Type:    "string",
+						Example: message,
+					},
+				},
+			},
+		},
+	}
+}
+
 func getOpenapiRestErrorSchema() *openapi.Schema {
 	return &openapi.Schema{
 		Type: "object",
@@ -170,6 +196,8 @@ type openapiDoc struct {
 	info      model.GenerateInfo
 	o         model.ServiceOption
 	outputDir string
+	schemas   *jsonSchemaBuilder
+	files     map[string][]byte
 }
 
 func (g *openapiDoc) Prepare(ctx context.Context) error {
@@ -182,6 +210,12 @@ func (g *openapiDoc) Prepare(ctx context.Context) error {
 }
 
 func (g *openapiDoc) Process(ctx context.Context) error {
+	files, err := encodeOpenapiDocument(g, g.makeDocument(), g.o.Transport.Openapi, g.typeName()+"_schemas")
+	g.files = files
+	return err
+}
+
+func (g *openapiDoc) makeDocument() openapi.OpenAPI {
 	opt := g.o.Transport.Openapi
 	swg := openapi.OpenAPI{
 		OpenAPI: "3.0.0",
@@ -199,6 +233,34 @@ func (g *openapiDoc) Process(ctx context.Context) error {
 		swg.Components.Schemas["Error"] = getOpenapiRestErrorSchema()
 	}
 
+	if g.o.Transport.Auth.Enable {
+		swg.Components.SecuritySchemes = openapi.SecuritySchemes{
+			"bearerAuth": &openapi.SecurityScheme{
+				Type:         "http",
+				Scheme:       "bearer",
+				BearerFormat: "JWT",
+			},
+		}
+		if g.o.Transport.JsonRPC.Enable {
+			swg.Components.Schemas["UnauthorizedError"] = getOpenapiJSONRPCErrorSchema(-32001, "Unauthorized")
+		}
+	}
+
+	if g.o.Transport.JsonRPC.Enable {
+		for _, mopt := range g.o.Transport.MethodOptions {
+			if mopt.RateLimit.Enable {
+				swg.Components.Schemas["RateLimitExceededError"] = getOpenapiJSONRPCErrorSchema(-32029, "rate limit exceeded")
+			}
+			if mopt.CircuitBreaker.Enable {
+				swg.Components.Schemas["ServiceUnavailableError"] = getOpenapiJSONRPCErrorSchema(-32053, "service unavailable")
+			}
+		}
+	}
+
+	if g.o.Transport.Principal.Enable && g.o.Transport.JsonRPC.Enable {
+		swg.Components.Schemas["ForbiddenError"] = getOpenapiJSONRPCErrorSchema(-32003, "Forbidden")
+	}
+
 	for _, ei := range g.o.Transport.Errors {
 		var s *openapi.Schema
 		if g.o.Transport.JsonRPC.Enable {
@@ -240,6 +302,8 @@ func (g *openapiDoc) Process(ctx context.Context) error {
 		swg.Components.Schemas[ei.Named.Obj().Name()] = s
 	}
 
+	g.schemas = newJSONSchemaBuilder(swg.Components.Schemas, g.info)
+
 	for _, m := range g.o.Methods {
 		mopt := g.o.Transport.MethodOptions[m.Name]
 
@@ -283,11 +347,48 @@ func (g *openapiDoc) Process(ctx context.Context) error {
 
 		o.Tags = tags
 
+		if mopt.RateLimit.Enable {
+			if g.o.Transport.JsonRPC.Enable {
+				o.Responses["x-32029"] = g.makeErrorResponse("Rate limit exceeded.", "RateLimitExceededError")
+			} else {
+				o.Responses["429"] = g.makeErrorResponse("Too Many Requests", "Error")
+			}
+		}
+
+		if mopt.CircuitBreaker.Enable {
+			if g.o.Transport.JsonRPC.Enable {
+				o.Responses["x-32053"] = g.makeErrorResponse("Service unavailable. The circuit breaker is open.", "ServiceUnavailableError")
+			} else {
+				o.Responses["503"] = g.makeErrorResponse("Service Unavailable", "Error")
+			}
+		}
+
+		if len(mopt.Permissions) > 0 || len(mopt.Roles) > 0 {
+			o.Permissions = &openapi.Permissions{
+				Require: mopt.Permissions,
+				Roles:   mopt.Roles,
+			}
+			if g.o.Transport.JsonRPC.Enable {
+				o.Responses["x-32003"] = g.makeErrorResponse("Forbidden. The principal does not have the required permissions.", "ForbiddenError")
+			} else {
+				o.Responses["403"] = g.makeErrorResponse("Forbidden", "Error")
+			}
+		}
+
+		if g.o.Transport.Auth.Enable && !mopt.Public {
+			o.Security = []openapi.SecurityRequirement{{"bearerAuth": []string{}}}
+			if g.o.Transport.JsonRPC.Enable {
+				o.Responses["x-32001"] = g.makeErrorResponse("Unauthorized. The authentication token is missing or invalid.", "UnauthorizedError")
+			} else {
+				o.Responses["401"] = g.makeErrorResponse("Unauthorized", "Error")
+			}
+		}
+
 		if _, ok := swg.Paths[pathStr]; !ok {
 			swg.Paths[pathStr] = &openapi.Path{}
 		}
 
-		switch mopt.MethodName {
+		switch restHTTPMethod(mopt) {
 		default:
 			swg.Paths[pathStr].Get = o
 		case "POST":
@@ -300,10 +401,41 @@ func (g *openapiDoc) Process(ctx context.Context) error {
 			swg.Paths[pathStr].Delete = o
 		}
 	}
-	if err := ffjson.NewEncoder(g).Encode(swg); err != nil {
-		return err
+	return swg
+}
+
+// Files returns the component schema files by the path relative to the output directory.
+func (g *openapiDoc) Files() map[string][]byte {
+	return g.files
+}
+
+func (g *openapiDoc) typeName() string {
+	if g.o.Transport.JsonRPC.Enable {
+		return "openapi_jsonrpc"
+	}
+	return "openapi_rest"
+}
+
+// methodDescription returns the comments of the method without the leading spaces.
+func methodDescription(m model.ServiceMethod) string {
+	lines := make([]string, 0, len(m.Comments))
+	for _, c := range m.Comments {
+		lines = append(lines, stdstrings.TrimSpace(c))
+	}
+	return stdstrings.Join(lines, "\n")
+}
+
+func (g *openapiDoc) makeErrorResponse(description, schemaName string) openapi.Response {
+	return openapi.Response{
+		Description: description,
+		Content: openapi.Content{
+			"application/json": {
+				Schema: &openapi.Schema{
+					Ref: "#/components/schemas/" + schemaName,
+				},
+			},
+		},
 	}
-	return nil
 }
 
 func (g *openapiDoc) PkgName() string {
@@ -315,11 +447,7 @@ func (g *openapiDoc) OutputDir() string {
 }
 
 func (g *openapiDoc) Filename() string {
-	typeName := "rest"
-	if g.o.Transport.JsonRPC.Enable {
-		typeName = "jsonrpc"
-	}
-	return fmt.Sprintf("openapi_%s_gen.json", typeName)
+	return g.typeName() + "_gen." + g.o.Transport.Openapi.Format
 }
 
 func (g *openapiDoc) Imports() []string {
@@ -341,7 +469,7 @@ func (g *openapiDoc) makeJSONRPCPath(opt model.OpenapiHTTPTransportOption, m mod
 
 	if len(m.Params) > 0 {
 		for _, p := range m.Params {
-			requestSchema.Properties[strcase.ToLowerCamel(p.Name())] = g.makeSwaggerSchema(p.Type())
+			requestSchema.Properties[strcase.ToLowerCamel(p.Name())] = g.schemas.schema(p.Type())
 		}
 	} else {
 		requestSchema.Example = json.RawMessage("null")
@@ -349,10 +477,10 @@ func (g *openapiDoc) makeJSONRPCPath(opt model.OpenapiHTTPTransportOption, m mod
 
 	if len(m.Results) > 1 {
 		for _, r := range m.Results {
-			responseSchema.Properties[strcase.ToLowerCamel(r.Name())] = g.makeSwaggerSchema(r.Type())
+			responseSchema.Properties[strcase.ToLowerCamel(r.Name())] = g.schemas.schema(r.Type())
 		}
 	} else if len(m.Results) == 1 {
-		responseSchema = g.makeSwaggerSchema(m.Results[0].Type())
+		responseSchema = g.schemas.schema(m.Results[0].Type())
 	} else {
 		responseSchema.Example = json.RawMessage("null")
 	}
@@ -392,14 +520,14 @@ func (g *openapiDoc) makeJSONRPCPath(opt model.OpenapiHTTPTransportOption, m mod
 			},
 			"method": &openapi.Schema{
 				Type: "string",
-				Enum: []string{strcase.ToLowerCamel(m.Name)},
+				Enum: []interface{}{strcase.ToLowerCamel(m.Name)},
 			},
 			"params": requestSchema,
 		},
 	}
 
 	return &openapi.Operation{
-		Description: stdstrings.Join(m.Comments, "\n"),
+		Description: methodDescription(m),
 		RequestBody: &openapi.RequestBody{
 			Required: true,
 			Content: map[string]openapi.Media{
@@ -471,114 +599,6 @@ func (g *openapiDoc) makeJSONRPCPath(opt model.OpenapiHTTPTransportOption, m mod
 	}
 }
 
-func (g *openapiDoc) makeSwaggerSchema(t stdtypes.Type) (schema *openapi.Schema) {
-	schema = &openapi.Schema{}
-	switch v := t.(type) {
-	case *stdtypes.Pointer:
-		return g.makeSwaggerSchema(v.Elem())
-	case *stdtypes.Interface:
-		// TODO: not anyOf works in SwaggerUI, so the object type is used to display the field.
-		schema.Type = "object"
-		schema.Description = "Can be any value - string, number, boolean, array or object."
-		schema.Properties = openapi.Properties{}
-		schema.Example = json.RawMessage("null")
-		schema.AnyOf = []openapi.Schema{
-			{Type: "string", Example: "abc"},
-			{Type: "integer", Example: 1},
-			{Type: "number", Format: "float", Example: 1.11},
-			{Type: "boolean", Example: true},
-			{Type: "array"},
-			{Type: "object"},
-		}
-	case *stdtypes.Map:
-		schema.Type = "object"
-		schema.Properties = openapi.Properties{
-			"key": g.makeSwaggerSchema(v.Elem()),
-		}
-	case *stdtypes.Slice:
-		if vv, ok := v.Elem().(*stdtypes.Basic); ok && vv.Kind() == stdtypes.Byte {
-			schema.Type = "string"
-			schema.Format = "byte"
-			schema.Example = "U3dhZ2dlciByb2Nrcw=="
-		} else {
-			schema.Type = "array"
-			schema.Items = g.makeSwaggerSchema(v.Elem())
-		}
-	case *stdtypes.Basic:
-		switch v.Kind() {
-		case stdtypes.String:
-			schema.Type = "string"
-			schema.Format = "string"
-			schema.Example = "abc"
-		case stdtypes.Bool:
-			schema.Type = "boolean"
-			schema.Example = true
-		case stdtypes.Int,
-			stdtypes.Uint,
-			stdtypes.Uint8,
-			stdtypes.Uint16,
-			stdtypes.Int8,
-			stdtypes.Int16:
-			schema.Type = "integer"
-			schema.Example = 1
-		case stdtypes.Uint32, stdtypes.Int32:
-			schema.Type = "integer"
-			schema.Format = "int32"
-			schema.Example = 1
-		case stdtypes.Uint64, stdtypes.Int64:
-			schema.Type = "integer"
-			schema.Format = "int64"
-			schema.Example = 1
-		case stdtypes.Float32, stdtypes.Float64:
-			schema.Type = "number"
-			schema.Format = "float"
-			schema.Example = 1.11
-		}
-	case *stdtypes.Struct:
-		schema.Type = "object"
-		schema.Properties = openapi.Properties{}
-
-		var populateSchema func(st *stdtypes.Struct)
-		populateSchema = func(st *stdtypes.Struct) {
-			for i := 0; i < st.NumFields(); i++ {
-				f := st.Field(i)
-				if !f.Embedded() {
-					schema.Properties[strcase.ToLowerCamel(f.Name())] = g.makeSwaggerSchema(f.Type())
-				} else {
-					var st *stdtypes.Struct
-					if ptr, ok := f.Type().(*stdtypes.Pointer); ok {
-						st = ptr.Elem().Underlying().(*stdtypes.Struct)
-					} else {
-						st = f.Type().Underlying().(*stdtypes.Struct)
-					}
-					populateSchema(st)
-				}
-			}
-		}
-		populateSchema(v)
-	case *stdtypes.Named:
-		switch stdtypes.TypeString(v, nil) {
-		case "encoding/json.RawMessage":
-			schema.Type = "object"
-			schema.Properties = openapi.Properties{}
-			return
-		case "time.Time":
-			schema.Type = "string"
-			schema.Format = "date-time"
-			schema.Example = "1985-04-02T01:30:00.00Z"
-			return
-		case "github.com/pborman/uuid.UUID",
-			"github.com/google/uuid.UUID":
-			schema.Type = "string"
-			schema.Format = "uuid"
-			schema.Example = "d5c02d83-6fbc-4dd7-8416-9f85ed80de46"
-			return
-		}
-		return g.makeSwaggerSchema(v.Obj().Type().Underlying())
-	}
-	return
-}
-
 func (g *openapiDoc) makeRestPath(opt model.OpenapiHTTPTransportOption, m model.ServiceMethod) *openapi.Operation {
 	mopt := g.o.Transport.MethodOptions[m.Name]
 
@@ -605,15 +625,15 @@ func (g *openapiDoc) makeRestPath(opt model.OpenapiHTTPTransportOption, m model.
 		if types.IsContext(p.Type()) {
 			continue
 		}
-		requestSchema.Properties[strcase.ToLowerCamel(p.Name())] = g.makeSwaggerSchema(p.Type())
+		requestSchema.Properties[strcase.ToLowerCamel(p.Name())] = g.schemas.schema(p.Type())
 	}
 
 	if len(m.Results) > 1 {
 		for _, r := range m.Results {
-			responseSchema.Properties[strcase.ToLowerCamel(r.Name())] = g.makeSwaggerSchema(r.Type())
+			responseSchema.Properties[strcase.ToLowerCamel(r.Name())] = g.schemas.schema(r.Type())
 		}
 	} else if len(m.Results) == 1 {
-		responseSchema = g.makeSwaggerSchema(m.Results[0].Type())
+		responseSchema = g.schemas.schema(m.Results[0].Type())
 	}
 
 	if mopt.WrapResponse.Enable {
@@ -625,7 +645,8 @@ func (g *openapiDoc) makeRestPath(opt model.OpenapiHTTPTransportOption, m model.
 	}
 
 	o := &openapi.Operation{
-		Summary: m.Name,
+		Summary:     m.Name,
+		Description: methodDescription(m),
 		Responses: map[string]openapi.Response{
 			"200": {
 				Description: "OK",
@@ -647,25 +668,39 @@ func (g *openapiDoc) makeRestPath(opt model.OpenapiHTTPTransportOption, m model.
 			},
 		},
 	}
+	if m.StreamElem != nil {
+		o.Responses["200"] = openapi.Response{
+			Description: "OK. Server-sent events stream, the data of each event is the JSON encoded schema.",
+			Content: openapi.Content{
+				"text/event-stream": {
+					Schema: g.schemas.schema(m.StreamElem),
+				},
+			},
+		}
+	}
 	for _, p := range m.Params {
 		var in string
+		// the query and header parameters are named as they are sent.
+		name := p.Name()
 		if _, ok := mopt.PathVars[p.Name()]; ok {
 			in = "path"
-		} else if _, ok := mopt.HeaderVars[p.Name()]; ok {
+		} else if headerName, ok := mopt.HeaderVars[p.Name()]; ok {
 			in = "header"
-		} else if _, ok := mopt.QueryVars[p.Name()]; ok {
+			name = headerName
+		} else if queryName, ok := mopt.QueryVars[p.Name()]; ok {
 			in = "query"
+			name = queryName
 		}
 		if in != "" {
 			o.Parameters = append(o.Parameters, openapi.Parameter{
 				In:       in,
-				Name:     p.Name(),
+				Name:     name,
 				Required: true,
-				Schema:   g.makeSwaggerSchema(p.Type()),
+				Schema:   g.schemas.paramSchema(p.Type()),
 			})
 		}
 	}
-	switch mopt.MethodName {
+	switch restHTTPMethod(mopt) {
 	case "POST", "PUT", "PATCH":
 		o.RequestBody = &openapi.RequestBody{
 			Required: true,
@@ -0,0 +1,156 @@
+package generator
+
+import (
+	"bytes"
+	"io"
+	stdstrings "strings"
+
+	"github.com/pquerna/ffjson/ffjson"
+	"gopkg.in/yaml.v3"
+
+	"github.com/swipe-io/swipe/pkg/domain/model"
+	"github.com/swipe-io/swipe/pkg/openapi"
+)
+
+const componentsSchemasRef = "#/components/schemas/"
+
+// walkOpenapiSchemas replaces the schemas of the document with the result of fn,
+// the nested schemas are replaced before the schema that contains them.
+func walkOpenapiSchemas(doc *openapi.OpenAPI, fn func(*openapi.Schema) *openapi.Schema) {
+	for name, s := range doc.Components.Schemas {
+		doc.Components.Schemas[name] = walkOpenapiSchema(s, fn)
+	}
+	for _, p := range doc.Paths {
+		for _, o := range []*openapi.Operation{p.Get, p.Post, p.Patch, p.Put, p.Delete} {
+			if o == nil {
+				continue
+			}
+			for i := range o.Parameters {
+				o.Parameters[i].Schema = walkOpenapiSchema(o.Parameters[i].Schema, fn)
+			}
+			if o.RequestBody != nil {
+				walkOpenapiContent(o.RequestBody.Content, fn)
+			}
+			for _, r := range o.Responses {
+				walkOpenapiContent(r.Content, fn)
+			}
+		}
+	}
+}
+
+func walkOpenapiContent(content openapi.Content, fn func(*openapi.Schema) *openapi.Schema) {
+	for mediaType, m := range content {
+		m.Schema = walkOpenapiSchema(m.Schema, fn)
+		content[mediaType] = m
+	}
+}
+
+func walkOpenapiSchema(s *openapi.Schema, fn func(*openapi.Schema) *openapi.Schema) *openapi.Schema {
+	if s == nil {
+		return nil
+	}
+	for name, p := range s.Properties {
+		s.Properties[name] = walkOpenapiSchema(p, fn)
+	}
+	s.AdditionalProperties = walkOpenapiSchema(s.AdditionalProperties, fn)
+	s.Items = walkOpenapiSchema(s.Items, fn)
+	for i := range s.AllOf {
+		s.AllOf[i] = *walkOpenapiSchema(&s.AllOf[i], fn)
+	}
+	for i := range s.AnyOf {
+		s.AnyOf[i] = *walkOpenapiSchema(&s.AnyOf[i], fn)
+	}
+	return fn(s)
+}
+
+// convertOpenapi31 converts the 3.0 document to 3.1, the nullable schemas are replaced
+// with anyOf the null type and the example with the examples.
+func convertOpenapi31(doc *openapi.OpenAPI) {
+	doc.OpenAPI = "3.1.0"
+	walkOpenapiSchemas(doc, func(s *openapi.Schema) *openapi.Schema {
+		if s.Example != nil {
+			s.Examples = []interface{}{s.Example}
+			s.Example = nil
+		}
+		if !s.Nullable {
+			return s
+		}
+		value := *s
+		value.Nullable = false
+		value.Description = ""
+		if len(value.AllOf) == 1 && value.Type == "" && value.Ref == "" && len(value.Properties) == 0 {
+			// the nullable $ref is wrapped with allOf in 3.0, 3.1 refers to it directly.
+			value = value.AllOf[0]
+		}
+		return &openapi.Schema{
+			Description: s.Description,
+			AnyOf:       []openapi.Schema{value, {Type: "null"}},
+		}
+	})
+}
+
+// splitOpenapiSchemas moves the component schemas of the document to the files in the dir,
+// the components refer to the files and the schemas refer to each other relative to the dir.
+func splitOpenapiSchemas(doc *openapi.OpenAPI, dir, ext string) map[string]*openapi.Schema {
+	files := map[string]*openapi.Schema{}
+	for name, s := range doc.Components.Schemas {
+		files[dir+"/"+name+"_gen."+ext] = walkOpenapiSchema(s, func(s *openapi.Schema) *openapi.Schema {
+			if stdstrings.HasPrefix(s.Ref, componentsSchemasRef) {
+				s.Ref = "./" + stdstrings.TrimPrefix(s.Ref, componentsSchemasRef) + "_gen." + ext
+			}
+			return s
+		})
+		doc.Components.Schemas[name] = &openapi.Schema{Ref: "./" + dir + "/" + name + "_gen." + ext}
+	}
+	return files
+}
+
+// encodeOpenapiDocument writes the document in the version and format of the option,
+// the split component schemas are returned by the path relative to the document.
+func encodeOpenapiDocument(w io.Writer, doc openapi.OpenAPI, opt model.OpenapiHTTPTransportOption, schemasDir string) (map[string][]byte, error) {
+	if opt.Version == "3.1" {
+		convertOpenapi31(&doc)
+	}
+	var files map[string][]byte
+	if opt.Split {
+		files = map[string][]byte{}
+		for filename, schema := range splitOpenapiSchemas(&doc, schemasDir, opt.Format) {
+			buf := new(bytes.Buffer)
+			if err := encodeOpenapi(buf, schema, opt.Format); err != nil {
+				return nil, err
+			}
+			files[filename] = buf.Bytes()
+		}
+	}
+	return files, encodeOpenapi(w, doc, opt.Format)
+}
+
+// encodeOpenapi writes the value in the JSON or YAML format, the YAML keeps the order of the JSON keys.
+func encodeOpenapi(w io.Writer, v interface{}, format string) error {
+	if format != "yaml" {
+		return ffjson.NewEncoder(w).Encode(v)
+	}
+	data, err := ffjson.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	resetYAMLStyle(&node)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// resetYAMLStyle removes the JSON flow and quoted styles, so the nodes are written in the block style.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, n := range node.Content {
+		resetYAMLStyle(n)
+	}
+}
@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/swipe-io/swipe/pkg/openapi"
+)
+
+func TestConvertOpenapi31(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *openapi.Schema
+		want   *openapi.Schema
+	}{
+		{
+			name:   "example",
+			schema: &openapi.Schema{Type: "string", Example: "abc"},
+			want:   &openapi.Schema{Type: "string", Examples: []interface{}{"abc"}},
+		},
+		{
+			name:   "nullable",
+			schema: &openapi.Schema{Type: "integer", Nullable: true, Description: "The age."},
+			want: &openapi.Schema{
+				Description: "The age.",
+				AnyOf:       []openapi.Schema{{Type: "integer"}, {Type: "null"}},
+			},
+		},
+		{
+			name:   "nullable ref",
+			schema: &openapi.Schema{Nullable: true, AllOf: []openapi.Schema{{Ref: "#/components/schemas/User"}}},
+			want: &openapi.Schema{
+				AnyOf: []openapi.Schema{{Ref: "#/components/schemas/User"}, {Type: "null"}},
+			},
+		},
+		{
+			name: "nested",
+			schema: &openapi.Schema{Type: "array", Items: &openapi.Schema{
+				Type: "string", Nullable: true, Example: "abc",
+			}},
+			want: &openapi.Schema{Type: "array", Items: &openapi.Schema{
+				AnyOf: []openapi.Schema{{Type: "string", Examples: []interface{}{"abc"}}, {Type: "null"}},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := openapi.OpenAPI{OpenAPI: "3.0.0", Components: openapi.Components{Schemas: openapi.Schemas{"Value": tt.schema}}}
+			convertOpenapi31(&doc)
+			if doc.OpenAPI != "3.1.0" {
+				t.Fatalf("version: got %q, want %q", doc.OpenAPI, "3.1.0")
+			}
+			if got := doc.Components.Schemas["Value"]; !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitOpenapiSchemas(t *testing.T) {
+	doc := openapi.OpenAPI{
+		Paths: map[string]*openapi.Path{
+			"/users": {Get: &openapi.Operation{Responses: openapi.Responses{
+				"200": {Content: openapi.Content{"application/json": {Schema: &openapi.Schema{Ref: "#/components/schemas/User"}}}},
+			}}},
+		},
+		Components: openapi.Components{Schemas: openapi.Schemas{
+			"User": {Type: "object", Properties: openapi.Properties{
+				"group": {Ref: "#/components/schemas/Group"},
+			}},
+			"Group": {Type: "object"},
+		}},
+	}
+	files := splitOpenapiSchemas(&doc, "schemas", "yaml")
+
+	wantFiles := map[string]*openapi.Schema{
+		"schemas/User_gen.yaml": {Type: "object", Properties: openapi.Properties{
+			"group": {Ref: "./Group_gen.yaml"},
+		}},
+		"schemas/Group_gen.yaml": {Type: "object"},
+	}
+	if !reflect.DeepEqual(files, wantFiles) {
+		t.Fatalf("files: got %+v, want %+v", files, wantFiles)
+	}
+	wantComponents := openapi.Schemas{
+		"User":  {Ref: "./schemas/User_gen.yaml"},
+		"Group": {Ref: "./schemas/Group_gen.yaml"},
+	}
+	if !reflect.DeepEqual(doc.Components.Schemas, wantComponents) {
+		t.Fatalf("components: got %+v, want %+v", doc.Components.Schemas, wantComponents)
+	}
+	// the paths refer to the components of the document.
+	if ref := doc.Paths["/users"].Get.Responses["200"].Content["application/json"].Schema.Ref; ref != "#/components/schemas/User" {
+		t.Fatalf("path ref: got %q", ref)
+	}
+}
@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"bytes"
+	"strconv"
+	stdstrings "strings"
+
+	"github.com/swipe-io/swipe/pkg/domain/model"
+	"github.com/swipe-io/swipe/pkg/importer"
+	"github.com/swipe-io/swipe/pkg/writer"
+)
+
+// openapiServePage renders the document with the Swagger UI, the scripts and styles are served
+// from the github.com/swaggo/files/v2 module embedded to the binary, the {{base}} placeholder is replaced
+// with the serve path.
+const openapiServePage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>API</title>
+<link rel="stylesheet" href="{{base}}/swagger-ui.css">
+<link rel="icon" type="image/png" href="{{base}}/favicon-32x32.png">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="{{base}}/swagger-ui-bundle.js"></script>
+<script src="{{base}}/swagger-ui-standalone-preset.js"></script>
+<script>
+window.onload = function () {
+  window.ui = SwaggerUIBundle({
+    url: "{{base}}/openapi.json",
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    layout: "StandaloneLayout"
+  });
+};
+</script>
+</body>
+</html>
+`
+
+// openapiServeAssets are the Swagger UI files used by the docs page.
+var openapiServeAssets = []string{"swagger-ui.css", "swagger-ui-bundle.js", "swagger-ui-standalone-preset.js", "favicon-32x32.png"}
+
+// writeOpenapiServeConsts writes the document and the docs page served by the handler.
+func writeOpenapiServeConsts(w *writer.GoLangWriter, info model.GenerateInfo, o model.ServiceOption) error {
+	opt := o.Transport.Openapi
+	opt.Format = "json"
+	opt.Split = false
+
+	buf := new(bytes.Buffer)
+	if _, err := encodeOpenapiDocument(buf, (&openapiDoc{info: info, o: o}).makeDocument(), opt, ""); err != nil {
+		return err
+	}
+	doc := stdstrings.TrimSpace(buf.String())
+	page := stdstrings.ReplaceAll(openapiServePage, "{{base}}", stdstrings.TrimSuffix(o.Transport.Openapi.Serve, "/"))
+
+	w.W("const openapiDocument%s = %s\n\n", o.ID, strconv.Quote(doc))
+	w.W("const openapiPage%s = %s\n\n", o.ID, strconv.Quote(page))
+	return nil
+}
+
+// writeOpenapiServeRoutes registers the docs page, the Swagger UI files and the document on the router.
+func writeOpenapiServeRoutes(w *writer.GoLangWriter, i *importer.Importer, o model.ServiceOption) {
+	servePath := o.Transport.Openapi.Serve
+	basePath := stdstrings.TrimSuffix(servePath, "/")
+	docPath := basePath + "/openapi.json"
+
+	fsPkg := i.Import("fs", "io/fs")
+	mimePkg := i.Import("mime", "mime")
+	pathPkg := i.Import("path", "path")
+	swaggerFilesPkg := i.Import("swaggerFiles", "github.com/swaggo/files/v2")
+
+	w.W("for _, name := range %#v {\n", openapiServeAssets)
+	w.W("data, err := %s.ReadFile(%s.FS, name)\n", fsPkg, swaggerFilesPkg)
+	w.W("if err != nil {\n")
+	w.W("return nil, err\n")
+	w.W("}\n")
+	w.W("contentType := %s.TypeByExtension(%s.Ext(name))\n", mimePkg, pathPkg)
+
+	if o.Transport.FastHTTP {
+		routerPkg := i.Import("routing", "github.com/qiangxue/fasthttp-routing")
+
+		w.W("r.Get(%s+name, func(c *%s.Context) error {\n", strconv.Quote(basePath+"/"), routerPkg)
+		w.W("c.SetContentType(contentType)\n")
+		w.W("c.SetBody(data)\n")
+		w.W("return nil\n")
+		w.W("})\n")
+		w.W("}\n")
+
+		w.W("r.Get(%s, func(c *%s.Context) error {\n", strconv.Quote(servePath), routerPkg)
+		w.W("c.SetContentType(\"text/html; charset=utf-8\")\n")
+		w.W("c.SetBodyString(openapiPage%s)\n", o.ID)
+		w.W("return nil\n")
+		w.W("})\n")
+		w.W("r.Get(%s, func(c *%s.Context) error {\n", strconv.Quote(docPath), routerPkg)
+		w.W("c.SetContentType(\"application/json; charset=utf-8\")\n")
+		w.W("c.SetBodyString(openapiDocument%s)\n", o.ID)
+		w.W("return nil\n")
+		w.W("})\n")
+		return
+	}
+
+	httpPkg := i.Import("http", "net/http")
+	ioPkg := i.Import("io", "io")
+
+	w.W("r.Methods(\"GET\").Path(%s+name).HandlerFunc(func(w %s.ResponseWriter, _ *%[2]s.Request) {\n", strconv.Quote(basePath+"/"), httpPkg)
+	w.W("w.Header().Set(\"Content-Type\", contentType)\n")
+	w.W("_, _ = w.Write(data)\n")
+	w.W("})\n")
+	w.W("}\n")
+
+	w.W("r.Methods(\"GET\").Path(%s).HandlerFunc(func(w %s.ResponseWriter, _ *%[2]s.Request) {\n", strconv.Quote(servePath), httpPkg)
+	w.W("w.Header().Set(\"Content-Type\", \"text/html; charset=utf-8\")\n")
+	w.W("_, _ = %s.WriteString(w, openapiPage%s)\n", ioPkg, o.ID)
+	w.W("})\n")
+	w.W("r.Methods(\"GET\").Path(%s).HandlerFunc(func(w %s.ResponseWriter, _ *%[2]s.Request) {\n", strconv.Quote(docPath), httpPkg)
+	w.W("w.Header().Set(\"Content-Type\", \"application/json; charset=utf-8\")\n")
+	w.W("_, _ = %s.WriteString(w, openapiDocument%s)\n", ioPkg, o.ID)
+	w.W("})\n")
+}
@@ -0,0 +1,155 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"sort"
+	stdstrings "strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/pquerna/ffjson/ffjson"
+
+	"github.com/swipe-io/swipe/pkg/domain/model"
+	"github.com/swipe-io/swipe/pkg/openapi"
+	"github.com/swipe-io/swipe/pkg/openrpc"
+)
+
+func makeOpenRPCDocument(info model.GenerateInfo, o model.ServiceOption) openrpc.OpenRPC {
+	opt := o.Transport.OpenRPC
+
+	doc := openrpc.OpenRPC{
+		OpenRPC: "1.2.6",
+		Info:    opt.Info,
+		Servers: opt.Servers,
+		Methods: []openrpc.Method{},
+		Components: openrpc.Components{
+			Schemas: openapi.Schemas{},
+		},
+	}
+	if doc.Info.Title == "" {
+		doc.Info.Title = o.ID
+	}
+	if doc.Info.Version == "" {
+		doc.Info.Version = "1.0.0"
+	}
+
+	b := newJSONSchemaBuilder(doc.Components.Schemas, info)
+
+	for _, m := range o.Methods {
+		mopt := o.Transport.MethodOptions[m.Name]
+
+		method := openrpc.Method{
+			Name:           m.LcName,
+			Description:    stdstrings.TrimSpace(stdstrings.Join(m.Comments, "\n")),
+			ParamStructure: "by-name",
+			Params:         []openrpc.ContentDescriptor{},
+		}
+		for _, p := range m.Params {
+			method.Params = append(method.Params, openrpc.ContentDescriptor{
+				Name:     strcase.ToLowerCamel(p.Name()),
+				Required: true,
+				Schema:   b.schema(p.Type()),
+			})
+		}
+
+		result := &openrpc.ContentDescriptor{Name: "result"}
+		switch {
+		case m.StreamElem != nil:
+			result.Description = "The events are sent as the notifications over WebSocket."
+			result.Schema = &openapi.Schema{Type: "null"}
+		case len(m.Results) > 1:
+			result.Schema = &openapi.Schema{Type: "object", Properties: openapi.Properties{}}
+			for _, r := range m.Results {
+				result.Schema.Properties[strcase.ToLowerCamel(r.Name())] = b.schema(r.Type())
+			}
+		case len(m.Results) == 1:
+			result.Schema = b.schema(m.Results[0].Type())
+		default:
+			result.Schema = &openapi.Schema{Type: "null"}
+		}
+		if mopt.WrapResponse.Enable && len(m.Results) > 0 && m.StreamElem == nil {
+			result.Schema = &openapi.Schema{
+				Type:       "object",
+				Properties: openapi.Properties{mopt.WrapResponse.Name: result.Schema},
+			}
+		}
+		method.Result = result
+
+		if o.Transport.Auth.Enable && !mopt.Public {
+			method.Errors = append(method.Errors, openrpc.Error{Code: -32001, Message: "Unauthorized"})
+		}
+		if o.Transport.Principal.Enable && (len(mopt.Permissions) > 0 || len(mopt.Roles) > 0) {
+			method.Errors = append(method.Errors, openrpc.Error{Code: -32003, Message: "Forbidden"})
+		}
+		if mopt.RateLimit.Enable {
+			method.Errors = append(method.Errors, openrpc.Error{Code: -32029, Message: "rate limit exceeded"})
+		}
+		if mopt.CircuitBreaker.Enable {
+			method.Errors = append(method.Errors, openrpc.Error{Code: -32053, Message: "service unavailable"})
+		}
+		// the errors returned by the method, as in the openapi document.
+		var errors []openrpc.Error
+		for _, ei := range m.Errors {
+			errors = append(errors, openrpc.Error{Code: ei.Code, Message: ei.Named.Obj().Name()})
+		}
+		sort.Slice(errors, func(i, j int) bool {
+			return errors[i].Code < errors[j].Code
+		})
+		for _, e := range errors {
+			var exists bool
+			for _, me := range method.Errors {
+				if me.Code == e.Code {
+					exists = true
+					break
+				}
+			}
+			if !exists {
+				method.Errors = append(method.Errors, e)
+			}
+		}
+
+		doc.Methods = append(doc.Methods, method)
+	}
+	return doc
+}
+
+type openrpcDoc struct {
+	bytes.Buffer
+	info      model.GenerateInfo
+	o         model.ServiceOption
+	outputDir string
+}
+
+func (g *openrpcDoc) Prepare(ctx context.Context) error {
+	outputDir, err := filepath.Abs(filepath.Join(g.info.BasePath, g.o.Transport.OpenRPC.Output))
+	if err != nil {
+		return err
+	}
+	g.outputDir = outputDir
+	return nil
+}
+
+func (g *openrpcDoc) Process(ctx context.Context) error {
+	return ffjson.NewEncoder(g).Encode(makeOpenRPCDocument(g.info, g.o))
+}
+
+func (g *openrpcDoc) PkgName() string {
+	return ""
+}
+
+func (g *openrpcDoc) OutputDir() string {
+	return g.outputDir
+}
+
+func (g *openrpcDoc) Filename() string {
+	return "openrpc_gen.json"
+}
+
+func (g *openrpcDoc) Imports() []string {
+	return nil
+}
+
+func NewOpenRPC(info model.GenerateInfo, o model.ServiceOption) Generator {
+	return &openrpcDoc{info: info, o: o}
+}
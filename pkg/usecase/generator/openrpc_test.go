@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"go/token"
+	stdtypes "go/types"
+	"reflect"
+	"testing"
+
+	"github.com/swipe-io/swipe/pkg/domain/model"
+	"github.com/swipe-io/swipe/pkg/openrpc"
+)
+
+func newTestError(pkg *stdtypes.Package, name string, code int64) *model.ErrorHTTPTransportOption {
+	obj := stdtypes.NewTypeName(token.NoPos, pkg, name, nil)
+	return &model.ErrorHTTPTransportOption{Named: stdtypes.NewNamed(obj, stdtypes.NewStruct(nil, nil), nil), Code: code}
+}
+
+// TestMakeOpenRPCDocumentErrors checks the method lists the transport errors and the errors returned by the method,
+// the transport error with the same code as the error of the method is listed once.
+func TestMakeOpenRPCDocumentErrors(t *testing.T) {
+	pkg := stdtypes.NewPackage("example.com/testdata/openrpc", "openrpc")
+	notFound := newTestError(pkg, "ErrNotFound", -32004)
+	conflict := newTestError(pkg, "ErrConflict", -32009)
+	unauthorized := newTestError(pkg, "ErrUnauthorized", -32001)
+
+	o := model.ServiceOption{
+		ID: "Service",
+		Transport: model.TransportOption{
+			Errors: map[uint32]*model.ErrorHTTPTransportOption{1: notFound, 2: conflict, 3: unauthorized},
+			MethodOptions: map[string]model.MethodHTTPTransportOption{
+				"Create": {RateLimit: model.RateLimitHTTPTransportOption{Enable: true}},
+			},
+			Auth: model.AuthHTTPTransportOption{Enable: true},
+		},
+		Methods: []model.ServiceMethod{
+			{Name: "Get", LcName: "get", Errors: map[uint32]*model.ErrorHTTPTransportOption{1: notFound}},
+			{Name: "Create", LcName: "create", Errors: map[uint32]*model.ErrorHTTPTransportOption{2: conflict, 3: unauthorized}},
+			{Name: "Ping", LcName: "ping"},
+		},
+	}
+
+	tests := []struct {
+		method string
+		want   []openrpc.Error
+	}{
+		{
+			method: "get",
+			want: []openrpc.Error{
+				{Code: -32001, Message: "Unauthorized"},
+				{Code: -32004, Message: "ErrNotFound"},
+			},
+		},
+		{
+			method: "create",
+			want: []openrpc.Error{
+				{Code: -32001, Message: "Unauthorized"},
+				{Code: -32029, Message: "rate limit exceeded"},
+				{Code: -32009, Message: "ErrConflict"},
+			},
+		},
+		{
+			method: "ping",
+			want: []openrpc.Error{
+				{Code: -32001, Message: "Unauthorized"},
+			},
+		},
+	}
+
+	doc := makeOpenRPCDocument(model.GenerateInfo{}, o)
+	if len(doc.Methods) != len(tests) {
+		t.Fatalf("methods: got %d, want %d", len(doc.Methods), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			m := doc.Methods[i]
+			if m.Name != tt.method {
+				t.Fatalf("name: got %q, want %q", m.Name, tt.method)
+			}
+			if !reflect.DeepEqual(m.Errors, tt.want) {
+				t.Fatalf("errors: got %+v, want %+v", m.Errors, tt.want)
+			}
+		})
+	}
+}
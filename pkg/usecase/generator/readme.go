@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/swipe-io/swipe/pkg/domain/model"
 	"github.com/swipe-io/swipe/pkg/writer"
@@ -24,6 +25,15 @@ go run ./cmd/service
 
 ToDo.
 
+{{if .Permissions -}}
+## Permissions
+
+| Method | Permissions | Roles |
+|------|------|------|
+{{range .Permissions -}}
+|{{.Method}}|{{.Permissions}}|{{.Roles}}|
+{{end}}
+{{end -}}
 ## Contributing
 
 ToDo.
@@ -103,10 +113,23 @@ func (g *readme) Prepare(ctx context.Context) (err error) {
 }
 
 func (g *readme) Process(ctx context.Context) (err error) {
+	var permissions []map[string]string
+	for _, m := range g.o.Methods {
+		mopt := g.o.Transport.MethodOptions[m.Name]
+		if len(mopt.Permissions) == 0 && len(mopt.Roles) == 0 {
+			continue
+		}
+		permissions = append(permissions, map[string]string{
+			"Method":      m.Name,
+			"Permissions": strings.Join(mopt.Permissions, ", "),
+			"Roles":       strings.Join(mopt.Roles, ", "),
+		})
+	}
 	return g.t.Execute(g, map[string]interface{}{
 		"ID":          g.o.RawID,
 		"ServiceName": g.o.ID,
 		"BasePkgPath": g.info.BasePkgPath,
+		"Permissions": permissions,
 		"GIT": map[string]interface{}{
 			"Tags": g.info.GitTags,
 		},
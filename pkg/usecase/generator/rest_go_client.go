@@ -31,12 +31,10 @@ func (g *restGoClient) Process(ctx context.Context) error {
 		kithttpPkg string
 		contextPkg string
 		httpPkg    string
-		jsonPkg    string
 		fmtPkg     string
 		urlPkg     string
 		netPkg     string
 		stringsPkg string
-		pkgIO      string
 	)
 
 	transportOpt := g.o.Transport
@@ -52,8 +50,6 @@ func (g *restGoClient) Process(ctx context.Context) error {
 		} else {
 			httpPkg = g.i.Import("http", "net/http")
 		}
-		jsonPkg = g.i.Import("ffjson", "github.com/pquerna/ffjson/ffjson")
-		pkgIO = g.i.Import("io", "io")
 		fmtPkg = g.i.Import("fmt", "fmt")
 		contextPkg = g.i.Import("context", "context")
 		urlPkg = g.i.Import("url", "net/url")
@@ -72,10 +68,54 @@ func (g *restGoClient) Process(ctx context.Context) error {
 
 	g.W("c := &%s{}\n", clientType)
 
+	for _, m := range g.o.Methods {
+		mopt := g.o.Transport.MethodOptions[m.Name]
+		if mopt.RateLimit.Enable {
+			g.W("c.%sRateLimiter = ", m.LcName)
+			writeRateLimiter(g.GoLangWriter, g.i, mopt.RateLimit)
+			g.W("\n")
+		}
+		if mopt.CircuitBreaker.Enable {
+			g.W("c.%sCircuitBreaker = ", m.LcName)
+			writeCircuitBreaker(g.GoLangWriter, g.i, m.Name, mopt.CircuitBreaker)
+			g.W("\n")
+		}
+		if mopt.Timeout.Enable {
+			g.W("c.%sTimeout = ", m.LcName)
+			writer.WriteAST(g, g.i, mopt.Timeout.Duration)
+			g.W("\n")
+		}
+		if mopt.Retry.Enable {
+			g.W("c.%sRetryMax = ", m.LcName)
+			writer.WriteAST(g, g.i, mopt.Retry.Max)
+			g.W("\n")
+			g.W("c.%sRetryBackoff = ", m.LcName)
+			writer.WriteAST(g, g.i, mopt.Retry.Backoff)
+			g.W("\n")
+		}
+	}
+
 	g.W("for _, o := range opts {\n")
 	g.W("o(c)\n")
 	g.W("}\n")
 
+	if g.o.Logging.Enable && len(g.o.Methods) > 0 {
+		g.W("c.genericClientOption = append([]%[1]s.ClientOption{%[1]s.ClientBefore(requestIDContextToHTTP)}, c.genericClientOption...)\n", kithttpPkg)
+	}
+
+	if g.o.Tracing.Enable && len(g.o.Methods) > 0 {
+		g.W("c.genericClientOption = append([]%[1]s.ClientOption{%[1]s.ClientBefore(tracingContextToHTTP)}, c.genericClientOption...)\n", kithttpPkg)
+	}
+
+	if transportOpt.Auth.Enable && len(g.o.Methods) > 0 {
+		if transportOpt.FastHTTP {
+			g.W("c.genericClientOption = append([]%[1]s.ClientOption{%[1]s.ClientBefore(authContextToFastHTTP)}, c.genericClientOption...)\n", kithttpPkg)
+		} else {
+			kitjwtPkg := g.i.Import("jwt", "github.com/go-kit/kit/auth/jwt")
+			g.W("c.genericClientOption = append([]%[1]s.ClientOption{%[1]s.ClientBefore(%[2]s.ContextToHTTP())}, c.genericClientOption...)\n", kithttpPkg, kitjwtPkg)
+		}
+	}
+
 	if len(g.o.Methods) > 0 {
 		g.W("if %s.HasPrefix(tgt, \"[\") {\n", stringsPkg)
 		g.W("host, port, err := %s.SplitHostPort(tgt)\n", netPkg)
@@ -101,14 +141,7 @@ func (g *restGoClient) Process(ctx context.Context) error {
 
 		mopt := transportOpt.MethodOptions[m.Name]
 
-		httpMethod := mopt.MethodName
-		if httpMethod == "" {
-			if len(m.Params) > 0 {
-				httpMethod = "POST"
-			} else {
-				httpMethod = "GET"
-			}
-		}
+		httpMethod := restHTTPMethod(mopt)
 
 		pathStr := mopt.Path
 		if pathStr == "" {
@@ -127,11 +160,11 @@ func (g *restGoClient) Process(ctx context.Context) error {
 					regexp = ":" + regexp
 				}
 				pathStr = stdstrings.Replace(pathStr, "{"+p.Name()+regexp+"}", "%s", -1)
-				pathVars = append(pathVars, g.GetFormatType(g.i.Import, "req."+strings.UcFirst(p.Name()), p))
+				pathVars = append(pathVars, g.formatParam(p))
 			} else if qName, ok := mopt.QueryVars[p.Name()]; ok {
-				queryVars = append(queryVars, strconv.Quote(qName), g.GetFormatType(g.i.Import, "req."+strings.UcFirst(p.Name()), p))
+				queryVars = append(queryVars, strconv.Quote(qName), g.formatParam(p))
 			} else if hName, ok := mopt.HeaderVars[p.Name()]; ok {
-				headerVars = append(headerVars, strconv.Quote(hName), g.GetFormatType(g.i.Import, "req."+strings.UcFirst(p.Name()), p))
+				headerVars = append(headerVars, strconv.Quote(hName), g.formatParam(p))
 			}
 		}
 
@@ -139,7 +172,7 @@ func (g *restGoClient) Process(ctx context.Context) error {
 		if mopt.Expr != nil {
 			writer.WriteAST(g, g.i, mopt.Expr)
 		} else {
-			g.W(strconv.Quote(httpMethod))
+			g.W("%s", strconv.Quote(httpMethod))
 		}
 		g.W(",\n")
 		g.W("u,\n")
@@ -149,7 +182,13 @@ func (g *restGoClient) Process(ctx context.Context) error {
 		} else {
 			g.W("func(_ %s.Context, r *%s.Request, request interface{}) error {\n", contextPkg, httpPkg)
 
-			if len(m.Params) > 0 {
+			var hasBody bool
+			switch httpMethod {
+			case "POST", "PUT", "PATCH":
+				hasBody = true
+			}
+			// the request without the body sends only the path, query and header vars.
+			if len(m.Params) > 0 && (hasBody || len(pathVars) > 0 || len(queryVars) > 0 || len(headerVars) > 0) {
 				g.W("req, ok := request.(%sRequest%s)\n", m.LcName, g.o.ID)
 				g.W("if !ok {\n")
 				g.W("return %s.Errorf(\"couldn't assert request as %sRequest%s, got %%T\", request)\n", fmtPkg, m.LcName, g.o.ID)
@@ -164,7 +203,7 @@ func (g *restGoClient) Process(ctx context.Context) error {
 			if mopt.Expr != nil {
 				writer.WriteAST(g, g.i, mopt.Expr)
 			} else {
-				g.W(strconv.Quote(httpMethod))
+				g.W("%s", strconv.Quote(httpMethod))
 			}
 			if transportOpt.FastHTTP {
 				g.W(")")
@@ -205,8 +244,7 @@ func (g *restGoClient) Process(ctx context.Context) error {
 				g.W("r.Header.Add(%s, %s)\n", headerVars[i], headerVars[i+1])
 			}
 
-			switch stdstrings.ToUpper(httpMethod) {
-			case "POST", "PUT", "PATCH":
+			if hasBody && len(m.Params) > 0 {
 				jsonPkg := g.i.Import("ffjson", "github.com/pquerna/ffjson/ffjson")
 
 				g.W("data, err := %s.Marshal(req)\n", jsonPkg)
@@ -230,6 +268,8 @@ func (g *restGoClient) Process(ctx context.Context) error {
 
 		if mopt.ClientResponseFunc.Expr != nil {
 			writer.WriteAST(g, g.i, mopt.ClientResponseFunc.Expr)
+		} else if m.StreamElem != nil {
+			g.writeEventStreamDecoder(m, httpPkg, contextPkg)
 		} else {
 			g.W("func(_ %s.Context, r *%s.Response) (interface{}, error) {\n", contextPkg, httpPkg)
 
@@ -243,6 +283,8 @@ func (g *restGoClient) Process(ctx context.Context) error {
 			g.W("}\n")
 
 			if len(m.Results) > 0 {
+				jsonPkg := g.i.Import("ffjson", "github.com/pquerna/ffjson/ffjson")
+
 				var responseType string
 				if m.ResultsNamed {
 					responseType = fmt.Sprintf("%sResponse%s", m.LcName, g.o.ID)
@@ -268,7 +310,7 @@ func (g *restGoClient) Process(ctx context.Context) error {
 
 				g.W("&resp)\n")
 
-				g.W("if err != nil && err != %s.EOF {\n", pkgIO)
+				g.W("if err != nil && err != %s.EOF {\n", g.i.Import("io", "io"))
 				g.W("return nil, %s.Errorf(\"couldn't unmarshal body to %sResponse%s: %%s\", err)\n", fmtPkg, m.LcName, g.o.ID)
 				g.W("}\n")
 
@@ -286,7 +328,11 @@ func (g *restGoClient) Process(ctx context.Context) error {
 
 		g.W(",\n")
 
-		g.W("append(c.genericClientOption, c.%sClientOption...)...,\n", m.LcName)
+		if m.StreamElem != nil {
+			g.W("append(append(c.genericClientOption, c.%sClientOption...), %s.BufferedStream(true))...,\n", m.LcName, kithttpPkg)
+		} else {
+			g.W("append(c.genericClientOption, c.%sClientOption...)...,\n", m.LcName)
+		}
 
 		g.W(").Endpoint()\n")
 
@@ -294,6 +340,24 @@ func (g *restGoClient) Process(ctx context.Context) error {
 			"c.%[1]sEndpoint = middlewareChain(append(c.genericEndpointMiddleware, c.%[1]sEndpointMiddleware...))(c.%[1]sEndpoint)\n",
 			m.LcName,
 		)
+
+		if mopt.CircuitBreaker.Enable {
+			g.W("c.%[1]sEndpoint = circuitBreakerMiddleware(c.%[1]sCircuitBreaker)(c.%[1]sEndpoint)\n", m.LcName)
+		}
+		if mopt.Retry.Enable {
+			g.W("c.%[1]sEndpoint = retryMiddleware(c.%[1]sRetryMax, c.%[1]sRetryBackoff)(c.%[1]sEndpoint)\n", m.LcName)
+		}
+		if mopt.Timeout.Enable {
+			g.W("c.%[1]sEndpoint = timeoutMiddleware(c.%[1]sTimeout)(c.%[1]sEndpoint)\n", m.LcName)
+		}
+		if transportOpt.Auth.Enable && !mopt.Public {
+			g.W("if c.tokenProvider != nil {\n")
+			g.W("c.%[1]sEndpoint = authClientMiddleware(c.tokenProvider)(c.%[1]sEndpoint)\n", m.LcName)
+			g.W("}\n")
+		}
+		if mopt.RateLimit.Enable {
+			g.W("c.%[1]sEndpoint = rateLimitMiddleware(c.%[1]sRateLimiter)(c.%[1]sEndpoint)\n", m.LcName)
+		}
 	}
 
 	g.W("return c, nil\n")
@@ -301,6 +365,53 @@ func (g *restGoClient) Process(ctx context.Context) error {
 	return nil
 }
 
+func (g *restGoClient) writeEventStreamDecoder(m model.ServiceMethod, httpPkg, contextPkg string) {
+	jsonPkg := g.i.Import("ffjson", "github.com/pquerna/ffjson/ffjson")
+	elemType := stdtypes.TypeString(m.StreamElem, g.i.QualifyPkg)
+
+	g.W("func(ctx %s.Context, r *%s.Response) (interface{}, error) {\n", contextPkg, httpPkg)
+	g.W("if statusCode := r.StatusCode; statusCode != %s.StatusOK {\n", httpPkg)
+	g.W("r.Body.Close()\n")
+	g.W("return nil, ErrorDecode(statusCode)\n")
+	g.W("}\n")
+	g.W("events := make(chan %s)\n", elemType)
+	g.W("go func() {\n")
+	g.W("defer close(events)\n")
+	g.W("defer r.Body.Close()\n")
+	g.W("err := readEventStream(r.Body, func(data []byte) error {\n")
+	g.W("var event %s\n", elemType)
+	g.W("if err := %s.Unmarshal(data, &event); err != nil {\n", jsonPkg)
+	g.W("return err\n")
+	g.W("}\n")
+	g.W("select {\n")
+	g.W("case events <- event:\n")
+	g.W("return nil\n")
+	g.W("case <-ctx.Done():\n")
+	g.W("return ctx.Err()\n")
+	g.W("}\n")
+	g.W("})\n")
+	g.W("// the terminal error is reported before the events channel is closed.\n")
+	g.W("if err != nil && err != %s.EOF && c.%sStreamErrors != nil {\n", g.i.Import("io", "io"), m.LcName)
+	g.W("select {\n")
+	g.W("case c.%sStreamErrors <- err:\n", m.LcName)
+	g.W("default:\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("}()\n")
+	g.W("return (%s)(events), nil\n", stdtypes.TypeString(m.Results[0].Type(), g.i.QualifyPkg))
+	g.W("}")
+}
+
+// formatParam returns the expression that formats the request field for the path, query or header,
+// the enum types with the Enum option are sent in the text form.
+func (g *restGoClient) formatParam(p *stdtypes.Var) string {
+	fieldName := "req." + strings.UcFirst(p.Name())
+	if _, ok := enumOption(g.info.EnumOptions, p.Type()); ok {
+		return fieldName + ".String()"
+	}
+	return g.GetFormatType(g.i.Import, fieldName, p)
+}
+
 func (g *restGoClient) PkgName() string {
 	return ""
 }
@@ -0,0 +1,442 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+
+	"github.com/swipe-io/swipe/pkg/domain/model"
+	"github.com/swipe-io/swipe/pkg/openapi"
+	"github.com/swipe-io/swipe/pkg/types"
+	"github.com/swipe-io/swipe/pkg/writer"
+)
+
+var restPathVarRegexp = regexp.MustCompile(`\{([^}:]+)(:[^}]+)?\}`)
+
+type restMarkdownDoc struct {
+	writer.BaseWriter
+	info      model.GenerateInfo
+	o         model.ServiceOption
+	outputDir string
+	schemas   *jsonSchemaBuilder
+}
+
+func (g *restMarkdownDoc) Prepare(ctx context.Context) error {
+	outputDir, err := filepath.Abs(filepath.Join(g.info.BasePath, g.o.Transport.MarkdownDoc.OutputDir))
+	if err != nil {
+		return err
+	}
+	g.outputDir = outputDir
+	g.schemas = newJSONSchemaBuilder(openapi.Schemas{}, g.info)
+	return nil
+}
+
+func (g *restMarkdownDoc) Process(ctx context.Context) error {
+	baseURL := "http://127.0.0.1"
+	if len(g.o.Transport.Openapi.Servers) > 0 {
+		baseURL = strings.TrimSuffix(g.o.Transport.Openapi.Servers[0].URL, "/")
+	}
+
+	g.W("# %s REST API\n\n", g.o.ID)
+	g.W("The errors are responded with the status code of the error and the body `{\"error\": \"message\"}`.\n\n")
+	g.W("## Methods\n\n")
+
+	for _, m := range g.o.Methods {
+		httpMethod, path := g.route(m)
+		g.W("* <a href=\"#%[1]s\">%[1]s</a> <code>%[2]s %[3]s</code>\n", m.Name, httpMethod, path)
+	}
+	g.W("\n")
+
+	for _, m := range g.o.Methods {
+		g.writeMethod(m, baseURL)
+	}
+
+	if len(g.schemas.components) > 0 {
+		g.W("## Types\n\n")
+
+		names := make([]string, 0, len(g.schemas.components))
+		for name := range g.schemas.components {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			g.W("### <a name=\"%[1]s\"></a> %[1]s\n\n", name)
+			g.writeFields(g.schemas.components[name])
+		}
+	}
+	return nil
+}
+
+// route returns the HTTP method and the path of the method without the path variable patterns.
+func (g *restMarkdownDoc) route(m model.ServiceMethod) (string, string) {
+	mopt := g.o.Transport.MethodOptions[m.Name]
+	path := mopt.Path
+	if path == "" {
+		path = "/" + strings.ToLower(m.Name)
+	}
+	return restHTTPMethod(mopt), restPathVarRegexp.ReplaceAllString(path, "{$1}")
+}
+
+func (g *restMarkdownDoc) writeMethod(m model.ServiceMethod, baseURL string) {
+	mopt := g.o.Transport.MethodOptions[m.Name]
+	httpMethod, path := g.route(m)
+
+	g.W("### <a name=\"%[1]s\"></a> %[1]s\n\n", m.Name)
+	g.W("<code>%s %s</code>\n\n", httpMethod, path)
+
+	var description []string
+	paramsComment := make(map[string]string, len(m.Params))
+	for _, comment := range m.Comments {
+		comment = strings.TrimSpace(comment)
+		if strings.HasPrefix(comment, "@") {
+			matches := paramCommentRegexp.FindAllStringSubmatch(comment, -1)
+			if len(matches) == 1 && len(matches[0]) == 3 {
+				paramsComment[matches[0][1]] = matches[0][2]
+			}
+			continue
+		}
+		if comment != "" {
+			description = append(description, comment)
+		}
+	}
+	if len(description) > 0 {
+		g.W("%s\n\n", strings.Join(description, "\n"))
+	}
+
+	if g.o.Transport.Auth.Enable && !mopt.Public {
+		g.W("**Authorization**: <code>Bearer</code> token\n\n")
+	}
+	if len(mopt.Permissions) > 0 {
+		g.W("**Permissions**: <code>%s</code>\n\n", strings.Join(mopt.Permissions, "</code>, <code>"))
+	}
+	if len(mopt.Roles) > 0 {
+		g.W("**Roles**: <code>%s</code>\n\n", strings.Join(mopt.Roles, "</code>, <code>"))
+	}
+
+	var (
+		params  [][4]string
+		query   = url.Values{}
+		headers []string
+		body    = &openapi.Schema{Type: "object", Properties: openapi.Properties{}}
+		hasBody bool
+	)
+	switch httpMethod {
+	case "POST", "PUT", "PATCH":
+		hasBody = true
+	}
+
+	examplePath := path
+	for _, p := range m.Params {
+		if types.IsContext(p.Type()) {
+			continue
+		}
+		var in, name = "", p.Name()
+		if _, ok := mopt.PathVars[p.Name()]; ok {
+			in = "path"
+		} else if headerName, ok := mopt.HeaderVars[p.Name()]; ok {
+			in, name = "header", headerName
+		} else if queryName, ok := mopt.QueryVars[p.Name()]; ok {
+			in, name = "query", queryName
+		}
+		if in == "" {
+			if hasBody {
+				s := g.schemas.schema(p.Type())
+				if comment := paramsComment[p.Name()]; comment != "" {
+					if s.Ref != "" {
+						s = &openapi.Schema{AllOf: []openapi.Schema{*s}}
+					}
+					s.Description = comment
+				}
+				body.Properties[strcase.ToLowerCamel(p.Name())] = s
+			}
+			continue
+		}
+		s := g.schemas.paramSchema(p.Type())
+		params = append(params, [4]string{name, in, g.schemaType(s), paramsComment[p.Name()]})
+
+		value := g.paramExample(s)
+		switch in {
+		case "path":
+			examplePath = strings.Replace(examplePath, "{"+p.Name()+"}", url.PathEscape(value), 1)
+		case "header":
+			headers = append(headers, name+": "+value)
+		case "query":
+			query.Set(name, value)
+		}
+	}
+
+	if len(params) > 0 {
+		g.W("**Parameters**:\n\n")
+		g.W("| Name | In | Type | Description |\n|------|------|------|------|\n")
+		for _, p := range params {
+			g.W("|%s|%s|%s|%s|\n", p[0], p[1], p[2], markdownCell(p[3]))
+		}
+		g.W("\n")
+	}
+
+	if hasBody {
+		g.W("**Request body**:\n\n")
+		g.writeFields(body)
+	}
+
+	var response *openapi.Schema
+	if len(m.Results) > 1 {
+		response = &openapi.Schema{Type: "object", Properties: openapi.Properties{}}
+		for _, r := range m.Results {
+			response.Properties[strcase.ToLowerCamel(r.Name())] = g.schemas.schema(r.Type())
+		}
+	} else if len(m.Results) == 1 {
+		response = g.schemas.schema(m.Results[0].Type())
+	}
+	if response != nil && mopt.WrapResponse.Enable {
+		response = &openapi.Schema{Type: "object", Properties: openapi.Properties{mopt.WrapResponse.Name: response}}
+	}
+
+	switch {
+	case m.StreamElem != nil:
+		g.W("**Response** <code>200</code>: server-sent events stream, the data of each event is %s\n\n", g.schemaType(g.schemas.schema(m.StreamElem)))
+	case response != nil:
+		g.W("**Response** <code>200</code>:\n\n")
+		g.writeFields(response)
+	default:
+		g.W("**Response** <code>200</code>: empty\n\n")
+	}
+
+	g.writeErrors(m)
+
+	g.W("**Example**:\n\n```shell script\ncurl")
+	if httpMethod != "GET" {
+		g.W(" -X %s", httpMethod)
+	}
+	target := baseURL + examplePath
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	g.W(" %s", shellQuote(target))
+	if g.o.Transport.Auth.Enable && !mopt.Public {
+		g.W(" \\\n  -H \"Authorization: Bearer $TOKEN\"")
+	}
+	for _, h := range headers {
+		g.W(" \\\n  -H %s", shellQuote(h))
+	}
+	if hasBody {
+		data, _ := json.Marshal(g.example(body, nil))
+		g.W(" \\\n  -H 'Content-Type: application/json' \\\n  -d %s", shellQuote(string(data)))
+	}
+	g.W("\n```\n\n")
+
+	if response != nil && m.StreamElem == nil {
+		data, _ := json.MarshalIndent(g.example(response, nil), "", "  ")
+		g.W("```json\n%s\n```\n\n", data)
+	}
+}
+
+// writeErrors writes the errors of the method and the errors responded by the transport options.
+func (g *restMarkdownDoc) writeErrors(m model.ServiceMethod) {
+	mopt := g.o.Transport.MethodOptions[m.Name]
+
+	type methodError struct {
+		code int64
+		name string
+	}
+	var errs []methodError
+	for _, e := range m.Errors {
+		errs = append(errs, methodError{code: e.Code, name: "<code>" + e.Named.Obj().Name() + "</code>"})
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		if errs[i].code != errs[j].code {
+			return errs[i].code < errs[j].code
+		}
+		return errs[i].name < errs[j].name
+	})
+	// the errors of the transport options are listed unless the method returns the error with the same code.
+	appendStatus := func(code int64, name string) {
+		for _, e := range errs {
+			if e.code == code {
+				return
+			}
+		}
+		errs = append(errs, methodError{code, name})
+	}
+	if g.o.Transport.Auth.Enable && !mopt.Public {
+		appendStatus(401, "Unauthorized")
+	}
+	if len(mopt.Permissions) > 0 || len(mopt.Roles) > 0 {
+		appendStatus(403, "Forbidden")
+	}
+	if mopt.RateLimit.Enable {
+		appendStatus(429, "Too Many Requests")
+	}
+	appendStatus(500, "Internal Server Error")
+	if mopt.CircuitBreaker.Enable {
+		appendStatus(503, "Service Unavailable")
+	}
+
+	g.W("**Errors**:\n\n")
+	g.W("| Code | Error |\n|------|------|\n")
+	for _, e := range errs {
+		g.W("|%d|%s|\n", e.code, e.name)
+	}
+	g.W("\n")
+}
+
+// writeFields writes the table of the object fields, the other schemas are written as the type.
+func (g *restMarkdownDoc) writeFields(s *openapi.Schema) {
+	if s.Ref != "" || s.Type != "object" || s.AdditionalProperties != nil || len(s.AnyOf) > 0 {
+		g.W("%s\n\n", g.schemaType(s))
+		return
+	}
+	if len(s.Properties) == 0 {
+		g.W("empty object\n\n")
+		return
+	}
+	required := make(map[string]bool, len(s.Required))
+	for _, name := range s.Required {
+		required[name] = true
+	}
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	g.W("| Field | Type | Required | Description |\n|------|------|------|------|\n")
+	for _, name := range names {
+		fs := s.Properties[name]
+		isRequired := "no"
+		if required[name] {
+			isRequired = "yes"
+		}
+		g.W("|%s|%s|%s|%s|\n", name, g.schemaType(fs), isRequired, markdownCell(fs.Description))
+	}
+	g.W("\n")
+}
+
+// schemaType returns the type of the schema with the links to the types.
+func (g *restMarkdownDoc) schemaType(s *openapi.Schema) string {
+	var t string
+	switch {
+	case s.Ref != "":
+		t = fmt.Sprintf("<a href=\"#%[1]s\">%[1]s</a>", strings.TrimPrefix(s.Ref, "#/components/schemas/"))
+	case len(s.AllOf) > 0:
+		t = g.schemaType(&s.AllOf[0])
+	case len(s.AnyOf) > 0:
+		t = "any"
+	case s.Type == "array":
+		t = "array of " + g.schemaType(s.Items)
+	case s.Type == "object" && s.AdditionalProperties != nil:
+		t = "map of " + g.schemaType(s.AdditionalProperties)
+	default:
+		t = "<code>" + s.Type + "</code>"
+		if s.Format != "" && s.Format != s.Type {
+			t += " (" + s.Format + ")"
+		}
+	}
+	if len(s.Enum) > 0 {
+		values := make([]string, 0, len(s.Enum))
+		for _, v := range s.Enum {
+			values = append(values, fmt.Sprintf("<code>%v</code>", v))
+		}
+		t += ", one of " + strings.Join(values, ", ")
+	}
+	if s.Nullable {
+		t += ", nullable"
+	}
+	return t
+}
+
+// example returns the example value of the schema, the recursive references are empty objects.
+func (g *restMarkdownDoc) example(s *openapi.Schema, seen []string) interface{} {
+	if s.Ref != "" {
+		for _, ref := range seen {
+			if ref == s.Ref {
+				return map[string]interface{}{}
+			}
+		}
+		seen = append(seen, s.Ref)
+		component, ok := g.schemas.components[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+		if !ok {
+			return map[string]interface{}{}
+		}
+		s = component
+	}
+	if s.Example != nil {
+		return s.Example
+	}
+	if len(s.Enum) > 0 {
+		return s.Enum[0]
+	}
+	if len(s.AllOf) > 0 {
+		return g.example(&s.AllOf[0], seen)
+	}
+	switch s.Type {
+	case "object":
+		o := map[string]interface{}{}
+		for name, p := range s.Properties {
+			o[name] = g.example(p, seen)
+		}
+		if s.AdditionalProperties != nil {
+			o["key"] = g.example(s.AdditionalProperties, seen)
+		}
+		return o
+	case "array":
+		return []interface{}{g.example(s.Items, seen)}
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return true
+	case "string":
+		return ""
+	}
+	return nil
+}
+
+// paramExample returns the example of the path, query or header parameter as it is sent.
+func (g *restMarkdownDoc) paramExample(s *openapi.Schema) string {
+	switch v := g.example(s, nil).(type) {
+	case string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			values = append(values, fmt.Sprint(e))
+		}
+		return strings.Join(values, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (g *restMarkdownDoc) PkgName() string {
+	return ""
+}
+
+func (g *restMarkdownDoc) OutputDir() string {
+	return g.outputDir
+}
+
+func (g *restMarkdownDoc) Filename() string {
+	return fmt.Sprintf("rest_%s_doc_gen.md", strings.ToLower(g.o.ID))
+}
+
+// markdownCell returns the text that does not break the table row.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func NewRestMarkdownDoc(info model.GenerateInfo, o model.ServiceOption) Generator {
+	return &restMarkdownDoc{info: info, o: o}
+}
@@ -98,6 +98,12 @@ func (g *restServer) Process(ctx context.Context) error {
 	g.W("return nil\n")
 	g.W("}\n\n")
 
+	if transportOpt.Openapi.Serve != "" {
+		if err := writeOpenapiServeConsts(g.GoLangWriter, g.info, g.o); err != nil {
+			return err
+		}
+	}
+
 	g.W("// HTTP %s Transport\n", transportOpt.Prefix)
 	g.W("func MakeHandler%s%s(s %s", transportOpt.Prefix, g.o.ID, typeStr)
 	g.W(", opts ...%sServerOption", g.o.ID)
@@ -112,29 +118,149 @@ func (g *restServer) Process(ctx context.Context) error {
 
 	g.W("sopt := &server%sOpts{}\n", g.o.ID)
 
+	for _, m := range g.o.Methods {
+		mopt := transportOpt.MethodOptions[m.Name]
+		if mopt.RateLimit.Enable {
+			g.W("sopt.%sRateLimiter = ", m.LcName)
+			writeRateLimiter(g.GoLangWriter, g.i, mopt.RateLimit)
+			g.W("\n")
+		}
+		if mopt.CircuitBreaker.Enable {
+			g.W("sopt.%sCircuitBreaker = ", m.LcName)
+			writeCircuitBreaker(g.GoLangWriter, g.i, m.Name, mopt.CircuitBreaker)
+			g.W("\n")
+		}
+	}
+
+	if transportOpt.AccessLog.Enable {
+		logPkg := g.i.Import("log", "github.com/go-kit/kit/log")
+		g.W("sopt.accessLogger = %[1]s.NewLogfmtLogger(%[1]s.NewSyncWriter(%[2]s.Stdout))\n", logPkg, g.i.Import("os", "os"))
+	}
+	if transportOpt.HTTPMetrics.Enable && hasDefaultHTTPMetrics(g.o) {
+		g.W("sopt.httpRequestCount, sopt.httpRequestDuration, sopt.httpResponseSize = defaultHTTPMetrics()\n")
+	}
+	for _, m := range g.o.Methods {
+		if m.StreamElem != nil {
+			g.W("sopt.eventStreamHeartbeat = 15 * %s.Second\n", g.i.Import("time", "time"))
+			break
+		}
+	}
+	if transportOpt.PanicRecovery.Enable {
+		g.W("sopt.panicLogger = ")
+		writeDefaultPanicLogger(g.GoLangWriter, g.i, g.o.Logging.Backend)
+		g.W("\n")
+		if g.o.Instrumenting.Enable && hasDefaultHTTPMetrics(g.o) {
+			g.W("sopt.panicCount = defaultPanicCounter()\n")
+		}
+	}
+
 	g.W("for _, o := range opts {\n o(sopt)\n }\n")
 
+	if transportOpt.HTTPMetrics.Enable && !hasDefaultHTTPMetrics(g.o) {
+		g.W("if sopt.httpRequestCount == nil || sopt.httpRequestDuration == nil || sopt.httpResponseSize == nil {\n")
+		g.W("return nil, %s.New(\"the HTTP metrics of the %s backend must be passed with the %sServerHTTPMetrics option\")\n", g.i.Import("errors", "errors"), g.o.Instrumenting.Backend, g.o.ID)
+		g.W("}\n")
+	}
+	if transportOpt.PanicRecovery.Enable && g.o.Instrumenting.Enable && !hasDefaultHTTPMetrics(g.o) {
+		g.W("if sopt.panicCount == nil {\n")
+		g.W("if sopt.metricsProvider == nil {\n")
+		g.W("return nil, %s.New(\"the panic counter of the %s backend must be passed with the %sServerMetricsProvider or %[3]sServerPanicCounter option\")\n", g.i.Import("errors", "errors"), g.o.Instrumenting.Backend, g.o.ID)
+		g.W("}\n")
+		g.W("sopt.panicCount = defaultPanicCounter(sopt.metricsProvider)\n")
+		g.W("}\n")
+	}
+
+	if g.o.Logging.Enable {
+		g.W("sopt.genericServerOption = append([]%[1]s.ServerOption{%[1]s.ServerBefore(requestIDHTTPToContext)}, sopt.genericServerOption...)\n", kithttpPkg)
+	}
+
+	if g.o.Tracing.Enable {
+		g.W("sopt.genericServerOption = append([]%[1]s.ServerOption{%[1]s.ServerBefore(tracingHTTPToContext)}, sopt.genericServerOption...)\n", kithttpPkg)
+	}
+
+	if transportOpt.Auth.Enable {
+		if transportOpt.FastHTTP {
+			g.W("sopt.genericServerOption = append([]%[1]s.ServerOption{%[1]s.ServerBefore(authFastHTTPToContext)}, sopt.genericServerOption...)\n", kithttpPkg)
+		} else {
+			kitjwtPkg := g.i.Import("jwt", "github.com/go-kit/kit/auth/jwt")
+			g.W("sopt.genericServerOption = append([]%[1]s.ServerOption{%[1]s.ServerBefore(%[2]s.HTTPToContext())}, sopt.genericServerOption...)\n", kithttpPkg, kitjwtPkg)
+		}
+	}
+
 	g.W("ep := MakeEndpointSet(s)\n")
 
 	for _, m := range g.o.Methods {
 		g.W("ep.%[1]sEndpoint = middlewareChain(append(sopt.genericEndpointMiddleware, sopt.%[2]sEndpointMiddleware...))(ep.%[1]sEndpoint)\n", m.Name, m.LcName)
 	}
 
+	for _, m := range g.o.Methods {
+		if transportOpt.MethodOptions[m.Name].CircuitBreaker.Enable {
+			g.W("ep.%[1]sEndpoint = circuitBreakerMiddleware(sopt.%[2]sCircuitBreaker)(ep.%[1]sEndpoint)\n", m.Name, m.LcName)
+		}
+	}
+
+	if transportOpt.Principal.Enable {
+		for _, m := range g.o.Methods {
+			mopt := transportOpt.MethodOptions[m.Name]
+			if len(mopt.Permissions) == 0 && len(mopt.Roles) == 0 {
+				continue
+			}
+			g.W("ep.%sEndpoint = authorizationMiddleware(%s, %s)(ep.%[1]sEndpoint)\n", m.Name, stringSliceLiteral(mopt.Permissions), stringSliceLiteral(mopt.Roles))
+		}
+	}
+
+	if transportOpt.Auth.Enable {
+		for _, m := range g.o.Methods {
+			if transportOpt.MethodOptions[m.Name].Public {
+				continue
+			}
+			g.W("ep.%sEndpoint = authMiddleware(", m.Name)
+			writer.WriteAST(g, g.i, transportOpt.Auth.KeyFunc)
+			g.W(", ")
+			if transportOpt.Auth.ClaimsFactory != nil {
+				writer.WriteAST(g, g.i, transportOpt.Auth.ClaimsFactory)
+			} else {
+				g.W("%s.MapClaimsFactory", g.i.Import("jwt", "github.com/go-kit/kit/auth/jwt"))
+			}
+			g.W(")(ep.%sEndpoint)\n", m.Name)
+		}
+	}
+
+	for _, m := range g.o.Methods {
+		if transportOpt.MethodOptions[m.Name].RateLimit.Enable {
+			g.W("ep.%[1]sEndpoint = rateLimitMiddleware(sopt.%[2]sRateLimiter)(ep.%[1]sEndpoint)\n", m.Name, m.LcName)
+		}
+	}
+
+	if transportOpt.PanicRecovery.Enable {
+		for _, m := range g.o.Methods {
+			g.W("ep.%sEndpoint = recoveryMiddleware(%s, sopt)(ep.%[1]sEndpoint)\n", m.Name, strconv.Quote(m.Name))
+		}
+	}
+
 	if transportOpt.FastHTTP {
 		g.W("r := %s.New()\n", routerPkg)
 	} else {
 		g.W("r := %s.NewRouter()\n", routerPkg)
 	}
+	observe := transportOpt.AccessLog.Enable || transportOpt.HTTPMetrics.Enable
+	enums := newEnumValidator(nil, nil, g.info.EnumOptions)
+
 	for _, m := range g.o.Methods {
 		mopt := transportOpt.MethodOptions[m.Name]
 
+		routePath := mopt.Path
+		if routePath == "" {
+			routePath = "/" + stdstrings.ToLower(m.Name)
+		}
+
 		if transportOpt.FastHTTP {
 			g.W("r.To(")
 
 			if mopt.MethodName != "" {
 				writer.WriteAST(g, g.i, mopt.Expr)
 			} else {
-				g.W(strconv.Quote("GET"))
+				g.W("%s", strconv.Quote("GET"))
 			}
 
 			g.W(", ")
@@ -143,9 +269,9 @@ func (g *restServer) Process(ctx context.Context) error {
 				// replace brace indices for fasthttp router
 				urlPath := stdstrings.ReplaceAll(mopt.Path, "{", "<")
 				urlPath = stdstrings.ReplaceAll(urlPath, "}", ">")
-				g.W(strconv.Quote(urlPath))
+				g.W("%s", strconv.Quote(urlPath))
 			} else {
-				g.W(strconv.Quote("/" + m.LcName))
+				g.W("%s", strconv.Quote("/"+m.LcName))
 			}
 			g.W(", ")
 		} else {
@@ -153,20 +279,24 @@ func (g *restServer) Process(ctx context.Context) error {
 			if mopt.MethodName != "" {
 				writer.WriteAST(g, g.i, mopt.Expr)
 			} else {
-				g.W(strconv.Quote("GET"))
+				g.W("%s", strconv.Quote("GET"))
 			}
 			g.W(").")
 			g.W("Path(")
 			if mopt.Path != "" {
-				g.W(strconv.Quote(mopt.Path))
+				g.W("%s", strconv.Quote(mopt.Path))
 			} else {
-				g.W(strconv.Quote("/" + stdstrings.ToLower(m.Name)))
+				g.W("%s", strconv.Quote("/"+stdstrings.ToLower(m.Name)))
 			}
 			g.W(").")
 
 			g.W("Handler(")
 		}
 
+		if observe {
+			g.W("observeRoute(%s, ", strconv.Quote(routePath))
+		}
+
 		g.W(
 			"%s.NewServer(\nep.%sEndpoint,\n",
 			kithttpPkg,
@@ -180,7 +310,7 @@ func (g *restServer) Process(ctx context.Context) error {
 
 			if len(m.Params) > 0 {
 				g.W("var req %sRequest%s\n", m.LcName, g.o.ID)
-				switch stdstrings.ToUpper(mopt.MethodName) {
+				switch restHTTPMethod(mopt) {
 				case "POST", "PUT", "PATCH":
 					fmtPkg := g.i.Import("fmt", "fmt")
 					jsonPkg := g.i.Import("ffjson", "github.com/pquerna/ffjson/ffjson")
@@ -229,7 +359,7 @@ func (g *restServer) Process(ctx context.Context) error {
 						} else {
 							valueID = "vars[" + strconv.Quote(p.Name()) + "]"
 						}
-						g.WriteConvertType(g.i.Import, "req."+strings.UcFirst(p.Name()), valueID, p, "", false, "")
+						g.writeConvertParam(p, valueID)
 					} else if queryName, ok := mopt.QueryVars[p.Name()]; ok {
 						var valueID string
 						if transportOpt.FastHTTP {
@@ -237,7 +367,7 @@ func (g *restServer) Process(ctx context.Context) error {
 						} else {
 							valueID = "q.Get(" + strconv.Quote(queryName) + ")"
 						}
-						g.WriteConvertType(g.i.Import, "req."+strings.UcFirst(p.Name()), valueID, p, "", false, "")
+						g.writeConvertParam(p, valueID)
 					} else if headerName, ok := mopt.HeaderVars[p.Name()]; ok {
 						var valueID string
 						if transportOpt.FastHTTP {
@@ -245,9 +375,12 @@ func (g *restServer) Process(ctx context.Context) error {
 						} else {
 							valueID = "r.Header.Get(" + strconv.Quote(headerName) + ")"
 						}
-						g.WriteConvertType(g.i.Import, "req."+strings.UcFirst(p.Name()), valueID, p, "", false, "")
+						g.writeConvertParam(p, valueID)
 					}
 				}
+				if enums.hasEnums(m) {
+					g.W("if err := validate%sRequest%s(req); err != nil {\nreturn nil, err\n}\n", m.Name, g.o.ID)
+				}
 				g.W("return req, nil\n")
 			} else {
 				g.W("return nil, nil\n")
@@ -261,6 +394,8 @@ func (g *restServer) Process(ctx context.Context) error {
 		} else {
 			if transportOpt.JsonRPC.Enable {
 				g.W("encodeResponseJSONRPC%s", g.o.ID)
+			} else if m.StreamElem != nil {
+				g.writeEventStreamEncoder(m, httpPkg, contextPkg)
 			} else {
 				if mopt.WrapResponse.Enable {
 					var responseWriterType string
@@ -285,19 +420,85 @@ func (g *restServer) Process(ctx context.Context) error {
 		if transportOpt.FastHTTP {
 			g.W(".RouterHandle()")
 		}
+		if observe {
+			g.W(")")
+		}
 		g.W(")\n")
 	}
+	if transportOpt.Openapi.Serve != "" {
+		writeOpenapiServeRoutes(g.GoLangWriter, g.i, g.o)
+	}
+	handler := "r"
 	if transportOpt.FastHTTP {
-		g.W("return r.HandleRequest, nil")
-	} else {
-		g.W("return r, nil")
+		handler = "r.HandleRequest"
+	}
+	if transportOpt.PanicRecovery.Enable {
+		handler = "recoveryHandler(" + handler + ", sopt)"
 	}
+	if observe {
+		handler = "observeHandler(" + handler + ", sopt)"
+	}
+	g.W("return %s, nil", handler)
 
 	g.W("}\n\n")
 
 	return nil
 }
 
+// writeConvertParam writes the conversion of the path, query or header value to the request field,
+// the enum types with the Enum option are parsed from the text form.
+func (g *restServer) writeConvertParam(p *stdtypes.Var, valueID string) {
+	fieldName := "req." + strings.UcFirst(p.Name())
+	if o, ok := enumOption(g.info.EnumOptions, p.Type()); ok {
+		typeName := o.Type.Obj().Name()
+		parseFunc := "Parse" + typeName
+		if pkg := o.Type.Obj().Pkg(); pkg.Path() != g.i.Pkg().PkgPath {
+			parseFunc = g.i.QualifyPkg(pkg) + "." + parseFunc
+		}
+		g.W("if s := %s; s != \"\" {\n", valueID)
+		g.W("v, err := %s(s)\n", parseFunc)
+		g.W("if err != nil {\nreturn nil, errInvalidEnum(%s, s)\n}\n", strconv.Quote(typeName))
+		g.W("%s = v\n", fieldName)
+		g.W("}\n")
+		return
+	}
+	g.WriteConvertType(g.i.Import, fieldName, valueID, p, "", false, "")
+}
+
+func (g *restServer) writeEventStreamEncoder(m model.ServiceMethod, httpPkg, contextPkg string) {
+	timePkg := g.i.Import("time", "time")
+
+	g.W("func(ctx %s.Context, w %s.ResponseWriter, response interface{}) error {\n", contextPkg, httpPkg)
+	g.W("events, _ := response.(%s)\n", stdtypes.TypeString(m.Results[0].Type(), g.i.QualifyPkg))
+	g.W("s, err := newEventStream(w)\n")
+	g.W("if err != nil {\n")
+	g.W("return err\n")
+	g.W("}\n")
+	g.W("if events == nil {\n")
+	g.W("return nil\n")
+	g.W("}\n")
+	g.W("heartbeat := %s.NewTicker(sopt.eventStreamHeartbeat)\n", timePkg)
+	g.W("defer heartbeat.Stop()\n")
+	g.W("for {\n")
+	g.W("select {\n")
+	g.W("case <-ctx.Done():\n")
+	g.W("return nil\n")
+	g.W("case <-heartbeat.C:\n")
+	g.W("if err := s.Heartbeat(); err != nil {\n")
+	g.W("return err\n")
+	g.W("}\n")
+	g.W("case event, ok := <-events:\n")
+	g.W("if !ok {\n")
+	g.W("return nil\n")
+	g.W("}\n")
+	g.W("if err := s.Event(event); err != nil {\n")
+	g.W("return err\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("}\n")
+	g.W("}")
+}
+
 func (g *restServer) PkgName() string {
 	return ""
 }
@@ -0,0 +1,181 @@
+package generator
+
+import (
+	"context"
+	stdtypes "go/types"
+	"strconv"
+
+	"github.com/swipe-io/swipe/pkg/domain/model"
+	"github.com/swipe-io/swipe/pkg/importer"
+	"github.com/swipe-io/swipe/pkg/types"
+	"github.com/swipe-io/swipe/pkg/writer"
+)
+
+type tracing struct {
+	*writer.GoLangWriter
+	filename string
+	info     model.GenerateInfo
+	o        model.ServiceOption
+	i        *importer.Importer
+}
+
+func (g *tracing) Prepare(ctx context.Context) error {
+	return nil
+}
+
+func (g *tracing) Process(ctx context.Context) error {
+	tracePkg := g.i.Import("trace", "go.opentelemetry.io/otel/trace")
+	typeStr := stdtypes.TypeString(g.o.Type, g.i.QualifyPkg)
+
+	name := "tracingMiddleware" + g.o.ID
+	constructName := "NewTracingMiddleware" + g.o.ID
+
+	g.WriteTypeStruct(
+		name,
+		[]string{
+			"next", typeStr,
+			"tracer", tracePkg + ".Tracer",
+		},
+	)
+
+	for _, m := range g.o.Methods {
+		var params, results []string
+
+		ctxName := "ctx"
+		if m.ParamCtx != nil {
+			ctxName = m.ParamCtx.Name()
+			params = append(params, ctxName, stdtypes.TypeString(m.ParamCtx.Type(), g.i.QualifyPkg))
+		}
+
+		params = append(params, types.NameTypeParams(m.Params, g.i.QualifyPkg, nil)...)
+
+		if len(m.Results) > 0 {
+			if m.ResultsNamed {
+				results = types.NameTypeParams(m.Results, g.i.QualifyPkg, nil)
+			} else {
+				results = append(results, "result", stdtypes.TypeString(m.Results[0].Type(), g.i.QualifyPkg))
+			}
+		}
+
+		var errName string
+		if m.ReturnErr != nil {
+			errName = m.ReturnErr.Name()
+			if errName == "" || errName == "_" {
+				errName = "err"
+			}
+			results = append(results, errName, "error")
+		}
+
+		g.WriteFunc(m.Name, "s *"+name, params, results, func() {
+			// the span of the method without the context would be the orphan root span.
+			if m.ParamCtx == nil {
+				g.writeNext(m, ctxName)
+				return
+			}
+			g.W("%[1]s, span := s.tracer.Start(%[1]s, %[2]s)\n", ctxName, strconv.Quote(g.o.ID+"."+m.Name))
+			if errName != "" {
+				codesPkg := g.i.Import("codes", "go.opentelemetry.io/otel/codes")
+
+				g.W("defer func() {\n")
+				g.W("if %s != nil {\n", errName)
+				g.W("span.RecordError(%s)\n", errName)
+				g.W("span.SetStatus(%s.Error, %s.Error())\n", codesPkg, errName)
+				g.W("}\n")
+				g.W("span.End()\n")
+				g.W("}()\n")
+			} else {
+				g.W("defer span.End()\n")
+			}
+			var attributes []string
+			for _, p := range m.Params {
+				if attribute, ok := g.makeAttribute(p); ok {
+					attributes = append(attributes, attribute)
+				}
+			}
+			if len(attributes) > 0 {
+				g.W("span.SetAttributes(\n")
+				for _, attribute := range attributes {
+					g.W("%s,\n", attribute)
+				}
+				g.W(")\n")
+			}
+			g.writeNext(m, ctxName)
+		})
+	}
+
+	g.W("func %[1]s(s %[2]s, tracer %[4]s.Tracer) %[2]s {\n return &%[3]s{next: s, tracer: tracer}\n}\n", constructName, typeStr, name, tracePkg)
+	return nil
+}
+
+func (g *tracing) writeNext(m model.ServiceMethod, ctxName string) {
+	if len(m.Results) > 0 || m.ReturnErr != nil {
+		g.W("return ")
+	}
+	g.W("s.next.%s(", m.Name)
+	if m.ParamCtx != nil {
+		g.W("%s,", ctxName)
+	}
+	for i, p := range m.Params {
+		if i > 0 {
+			g.W(",")
+		}
+		g.W("%s", p.Name())
+	}
+	g.W(")\n")
+}
+
+// makeAttribute returns the span attribute of the param, the values of the structs, the pointers and
+// the interfaces are not added, so the fields hidden from the logs are not exported with the spans.
+func (g *tracing) makeAttribute(p *stdtypes.Var) (string, bool) {
+	attributePkg := g.i.Import("attribute", "go.opentelemetry.io/otel/attribute")
+
+	key := strconv.Quote(p.Name())
+
+	if _, ok := g.o.Tracing.Redact[p.Name()]; ok {
+		return attributePkg + ".String(" + key + ", \"[REDACTED]\")", true
+	}
+
+	convert := func(kind stdtypes.BasicKind) string {
+		if stdtypes.Identical(p.Type(), stdtypes.Typ[kind]) {
+			return p.Name()
+		}
+		return stdtypes.Typ[kind].Name() + "(" + p.Name() + ")"
+	}
+
+	switch t := p.Type().Underlying().(type) {
+	case *stdtypes.Basic:
+		switch {
+		case t.Info()&stdtypes.IsString != 0:
+			return attributePkg + ".String(" + key + ", " + convert(stdtypes.String) + ")", true
+		case t.Info()&stdtypes.IsBoolean != 0:
+			return attributePkg + ".Bool(" + key + ", " + convert(stdtypes.Bool) + ")", true
+		case t.Info()&stdtypes.IsInteger != 0:
+			return attributePkg + ".Int64(" + key + ", " + convert(stdtypes.Int64) + ")", true
+		case t.Info()&stdtypes.IsFloat != 0:
+			return attributePkg + ".Float64(" + key + ", " + convert(stdtypes.Float64) + ")", true
+		}
+	case *stdtypes.Slice, *stdtypes.Array, *stdtypes.Map, *stdtypes.Chan:
+		return attributePkg + ".Int(" + key + ", len(" + p.Name() + "))", true
+	}
+	return "", false
+}
+
+func (g *tracing) PkgName() string {
+	return ""
+}
+
+func (g *tracing) OutputDir() string {
+	return ""
+}
+
+func (g *tracing) Filename() string {
+	return g.filename
+}
+
+func (g *tracing) SetImporter(i *importer.Importer) {
+	g.i = i
+}
+
+func NewTracing(filename string, info model.GenerateInfo, o model.ServiceOption) Generator {
+	return &tracing{GoLangWriter: writer.NewGoLangWriter(), filename: filename, info: info, o: o}
+}
@@ -2,10 +2,16 @@ package generator
 
 import (
 	stdtypes "go/types"
+	"sort"
 	"strconv"
 	"strings"
 
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/swipe-io/swipe/pkg/domain/model"
+	"github.com/swipe-io/swipe/pkg/importer"
 	"github.com/swipe-io/swipe/pkg/types"
+	"github.com/swipe-io/swipe/pkg/writer"
 )
 
 func structKeyValue(vars []*stdtypes.Var, filterFn types.FilterFn) (results []string) {
@@ -20,24 +26,6 @@ func structKeyValue(vars []*stdtypes.Var, filterFn types.FilterFn) (results []st
 	)
 }
 
-func makeLogParams(data ...*stdtypes.Var) (result []string) {
-	for _, v := range data {
-		if logParam := makeLogParam(v.Name(), v.Type()); logParam != "" {
-			result = append(result, strconv.Quote(v.Name()), logParam)
-		}
-	}
-	return
-}
-
-func makeLogParam(name string, t stdtypes.Type) string {
-	switch t.(type) {
-	default:
-		return name
-	case *stdtypes.Slice, *stdtypes.Array, *stdtypes.Map, *stdtypes.Chan:
-		return "len(" + name + ")"
-	}
-}
-
 func isGolangNamedType(t stdtypes.Type) bool {
 	t = normalizeType(t)
 	switch stdtypes.TypeString(t, nil) {
@@ -68,3 +56,165 @@ func normalizeType(t stdtypes.Type) stdtypes.Type {
 		return v
 	}
 }
+
+func stringSliceLiteral(values []string) string {
+	if len(values) == 0 {
+		return "nil"
+	}
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
+
+func writeRateLimiter(w *writer.GoLangWriter, i *importer.Importer, opt model.RateLimitHTTPTransportOption) {
+	ratePkg := i.Import("rate", "golang.org/x/time/rate")
+
+	w.W("%[1]s.NewLimiter(%[1]s.Limit(", ratePkg)
+	writer.WriteAST(w, i, opt.RPS)
+	w.W("), ")
+	writer.WriteAST(w, i, opt.Burst)
+	w.W(")")
+}
+
+func writeCircuitBreaker(w *writer.GoLangWriter, i *importer.Importer, name string, opt model.CircuitBreakerHTTPTransportOption) {
+	gobreakerPkg := i.Import("gobreaker", "github.com/sony/gobreaker")
+
+	w.W("%[1]s.NewCircuitBreaker(%[1]s.Settings{\n", gobreakerPkg)
+	w.W("Name: %s,\n", strconv.Quote(name))
+	if opt.MaxRequests != nil {
+		w.W("MaxRequests: ")
+		writer.WriteAST(w, i, opt.MaxRequests)
+		w.W(",\n")
+	}
+	if opt.Interval != nil {
+		w.W("Interval: ")
+		writer.WriteAST(w, i, opt.Interval)
+		w.W(",\n")
+	}
+	if opt.Timeout != nil {
+		w.W("Timeout: ")
+		writer.WriteAST(w, i, opt.Timeout)
+		w.W(",\n")
+	}
+	if opt.Failures != nil {
+		w.W("ReadyToTrip: func(counts %s.Counts) bool {\n", gobreakerPkg)
+		w.W("return counts.ConsecutiveFailures > ")
+		writer.WriteAST(w, i, opt.Failures)
+		w.W("\n},\n")
+	}
+	w.W("})")
+}
+
+type enumGroup struct {
+	named *stdtypes.Named
+	enums []model.Enum
+}
+
+// sortedEnums returns the const groups ordered by the type name, so the output is stable between runs.
+func sortedEnums(enums *typeutil.Map) (groups []enumGroup) {
+	if enums == nil {
+		return nil
+	}
+	enums.Iterate(func(key stdtypes.Type, value interface{}) {
+		if named, ok := key.(*stdtypes.Named); ok {
+			groups = append(groups, enumGroup{named: named, enums: value.([]model.Enum)})
+		}
+	})
+	sort.Slice(groups, func(i, j int) bool {
+		return stdtypes.TypeString(groups[i].named, nil) < stdtypes.TypeString(groups[j].named, nil)
+	})
+	return
+}
+
+func isNotificationMethod(o model.TransportOption, m model.ServiceMethod) bool {
+	if !o.JsonRPC.Enable || o.FastHTTP || m.StreamElem != nil {
+		return false
+	}
+	return o.MethodOptions[m.Name].Notification || len(m.Results) == 0
+}
+
+func isIdempotentMethod(o model.TransportOption, m model.ServiceMethod) bool {
+	mopt := o.MethodOptions[m.Name]
+	if mopt.Idempotent {
+		return true
+	}
+	if o.JsonRPC.Enable {
+		return false
+	}
+	switch restHTTPMethod(mopt) {
+	case "GET", "HEAD", "PUT", "DELETE", "OPTIONS":
+		return true
+	}
+	return false
+}
+
+// hasDefaultHTTPMetrics reports whether the default HTTP metrics are created by the metrics backend,
+// the statsd and dogstatsd providers are not passed to the handler.
+func hasDefaultHTTPMetrics(o model.ServiceOption) bool {
+	switch o.Instrumenting.Backend {
+	case "statsd", "dogstatsd":
+		return false
+	}
+	return true
+}
+
+// loggerType returns the logger type of the logging backend.
+func loggerType(i *importer.Importer, backend string) string {
+	switch backend {
+	case "zap":
+		return "*" + i.Import("zap", "go.uber.org/zap") + ".Logger"
+	case "zerolog":
+		return i.Import("zerolog", "github.com/rs/zerolog") + ".Logger"
+	case "slog":
+		return "*" + i.Import("slog", "log/slog") + ".Logger"
+	default:
+		return i.Import("log", "github.com/go-kit/kit/log") + ".Logger"
+	}
+}
+
+// writeDefaultPanicLogger writes the logger of the logging backend that logs the recovered panics to stderr.
+func writeDefaultPanicLogger(w *writer.GoLangWriter, i *importer.Importer, backend string) {
+	osPkg := i.Import("os", "os")
+
+	switch backend {
+	case "zap":
+		zapPkg := i.Import("zap", "go.uber.org/zap")
+		zapcorePkg := i.Import("zapcore", "go.uber.org/zap/zapcore")
+
+		w.W("%[1]s.New(%[2]s.NewCore(%[2]s.NewJSONEncoder(%[1]s.NewProductionEncoderConfig()), %[2]s.Lock(%[3]s.Stderr), %[1]s.ErrorLevel))", zapPkg, zapcorePkg, osPkg)
+	case "zerolog":
+		w.W("%s.New(%s.Stderr).With().Timestamp().Logger()", i.Import("zerolog", "github.com/rs/zerolog"), osPkg)
+	case "slog":
+		w.W("%[1]s.New(%[1]s.NewTextHandler(%[2]s.Stderr, nil))", i.Import("slog", "log/slog"), osPkg)
+	default:
+		w.W("%[1]s.NewLogfmtLogger(%[1]s.NewSyncWriter(%[2]s.Stderr))", i.Import("log", "github.com/go-kit/kit/log"), osPkg)
+	}
+}
+
+// statsdProviderType returns the provider type of the statsd and dogstatsd metrics backends.
+func statsdProviderType(i *importer.Importer, backend string) string {
+	if backend == "dogstatsd" {
+		return "*" + i.Import("dogstatsd", "github.com/go-kit/kit/metrics/dogstatsd") + ".Dogstatsd"
+	}
+	return "*" + i.Import("statsd", "github.com/go-kit/kit/metrics/statsd") + ".Statsd"
+}
+
+// restHTTPMethod returns the HTTP method of the REST route, the server routes
+// the method without the Method option as GET.
+func restHTTPMethod(mopt model.MethodHTTPTransportOption) string {
+	if mopt.MethodName == "" {
+		return "GET"
+	}
+	return strings.ToUpper(mopt.MethodName)
+}
+
+// enumOption returns the Enum option of the type, if the type has the generated text form.
+func enumOption(enumOptions *typeutil.Map, t stdtypes.Type) (model.EnumOption, bool) {
+	if enumOptions == nil {
+		return model.EnumOption{}, false
+	}
+	o, ok := enumOptions.At(t).(model.EnumOption)
+	return o, ok
+}
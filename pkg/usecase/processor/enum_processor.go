@@ -0,0 +1,27 @@
+package processor
+
+import (
+	"github.com/swipe-io/swipe/pkg/domain/model"
+	ug "github.com/swipe-io/swipe/pkg/usecase/generator"
+)
+
+type enum struct {
+	info   model.GenerateInfo
+	option model.EnumOption
+}
+
+func (p *enum) SetOption(option interface{}) bool {
+	o, ok := option.(model.EnumOption)
+	p.option = o
+	return ok
+}
+
+func (p *enum) Generators() []ug.Generator {
+	return []ug.Generator{
+		ug.NewEnum(p.option),
+	}
+}
+
+func NewEnum(info model.GenerateInfo) Processor {
+	return &enum{info: info}
+}
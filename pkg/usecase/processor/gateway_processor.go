@@ -17,9 +17,13 @@ func (g *gatewayProcessor) SetOption(option interface{}) bool {
 }
 
 func (g *gatewayProcessor) Generators() []ug.Generator {
-	return []ug.Generator{
+	generators := []ug.Generator{
 		ug.NewGatewayGenerator("gateway_gen.go", g.info, g.option),
 	}
+	if g.option.Openapi.Enable {
+		generators = append(generators, ug.NewGatewayOpenapi(g.info, g.option))
+	}
+	return generators
 }
 
 func NewGatewayProcessor(info model.GenerateInfo) Processor {
@@ -27,13 +27,20 @@ func (p *service) Generators() []ug.Generator {
 		generators = append(generators, ug.NewReadme(p.info, p.option))
 	}
 	if p.option.Transport.MarkdownDoc.Enable {
-		generators = append(generators, ug.NewJsonrpcMarkdownDoc(p.info, p.option))
+		if p.option.Transport.JsonRPC.Enable {
+			generators = append(generators, ug.NewJsonrpcMarkdownDoc(p.info, p.option))
+		} else {
+			generators = append(generators, ug.NewRestMarkdownDoc(p.info, p.option))
+		}
 	}
 	if p.option.Transport.Protocol == "http" {
 		generators = append(generators, ug.NewHttpTransport("http_gen.go", p.info, p.option))
-		if p.option.Logging {
+		if p.option.Logging.Enable {
 			generators = append(generators, ug.NewLogging("logging_gen.go", p.info, p.option))
 		}
+		if p.option.Tracing.Enable {
+			generators = append(generators, ug.NewTracing("tracing_gen.go", p.info, p.option))
+		}
 		if p.option.Instrumenting.Enable {
 			generators = append(generators, ug.NewInstrumenting("instrumenting_gen.go", p.info, p.option))
 		}
@@ -58,6 +65,9 @@ func (p *service) Generators() []ug.Generator {
 	if p.option.Transport.Openapi.Enable {
 		generators = append(generators, ug.NewOpenapi(p.info, p.option))
 	}
+	if p.option.Transport.OpenRPC.Enable {
+		generators = append(generators, ug.NewOpenRPC(p.info, p.option))
+	}
 	return generators
 }
 
@@ -48,11 +48,11 @@ func (w *GoLangWriter) WriteFunc(name, recv string, params, results []string, bo
 
 func (w *GoLangWriter) WriteDefer(params []string, calls []string, body func()) {
 	w.W("defer func(")
-	w.W(stdstrings.Join(params, ","))
+	w.W("%s", stdstrings.Join(params, ","))
 	w.W(") {\n")
 	body()
 	w.W("}(")
-	w.W(stdstrings.Join(calls, ","))
+	w.W("%s", stdstrings.Join(calls, ","))
 	w.W(")\n")
 }
 
@@ -118,8 +118,8 @@ func (w *GoLangWriter) WriteStructAssign(keyvals []string) {
 }
 
 func (w *GoLangWriter) WriteFuncCall(id, name string, params []string) {
-	w.W(id + "." + name + "(")
-	w.W(stdstrings.Join(params, ","))
+	w.W("%s", id+"."+name+"(")
+	w.W("%s", stdstrings.Join(params, ","))
 	w.W(")\n")
 }
 
@@ -171,7 +171,6 @@ func (w *GoLangWriter) GetFormatType(importFn func(string, string) string, value
 func (w *GoLangWriter) writeConvertBasicType(importFn func(string, string) string, name, assignId, valueId string, t *stdtypes.Basic, sliceErr string, declareVar bool, msgErrTemplate string) {
 	useCheckErr := true
 
-	fmtPkg := importFn("fmt", "fmt")
 	tmpId := stdstrings.ToLower(name) + strings.UcFirst(t.String())
 
 	funcName := w.getConvertFuncName(t.Kind())
@@ -182,6 +181,8 @@ func (w *GoLangWriter) writeConvertBasicType(importFn func(string, string) strin
 		tmpId = valueId
 	}
 	if useCheckErr {
+		fmtPkg := importFn("fmt", "fmt")
+
 		if msgErrTemplate == "" {
 			msgErrTemplate = "convert error"
 		}